@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// exportTables - таблицы, доступные для выгрузки, и их исключенные колонки:
+// служебные (search_vector) и секретные (key_hash)
+var exportTables = map[string][]string{
+	"users":                      nil,
+	"employees":                  nil,
+	"employers":                  nil,
+	"resumes":                    nil,
+	"vacancies":                  {"search_vector"},
+	"reactions":                  nil,
+	"employer_reactions":         nil,
+	"matches":                    nil,
+	"applications":               nil,
+	"application_status_history": nil,
+	"api_keys":                   {"key_hash"},
+}
+
+const columnsQuery = `
+	SELECT column_name
+	FROM information_schema.columns
+	WHERE table_schema = current_schema() AND table_name = $1
+	ORDER BY ordinal_position
+`
+
+// runExport выгружает таблицу в JSON (массив объектов) или CSV (с заголовком) в stdout или файл
+func runExport(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	table := flags.String("table", "", "table to export: "+strings.Join(exportTableNames(), ", "))
+	format := flags.String("format", "json", "output format: json or csv")
+	output := flags.String("o", "", "output file (default stdout)")
+
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+
+	excluded, ok := exportTables[*table]
+	if !ok {
+		return fmt.Errorf("%w: unknown table %q, expected one of: %s", errUsage, *table, strings.Join(exportTableNames(), ", "))
+	}
+
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+
+	_, db, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	columns, err := exportColumns(ctx, db, *table, excluded)
+	if err != nil {
+		return err
+	}
+
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = pgx.Identifier{column}.Sanitize()
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(quoted, ", "), pgx.Identifier{*table}.Sanitize())
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", *table, err)
+	}
+	defer rows.Close()
+
+	var count int
+	if *format == "csv" {
+		count, err = writeCSV(out, columns, rows)
+	} else {
+		count, err = writeJSON(out, columns, rows)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", *table, err)
+	}
+
+	if *output != "" {
+		fmt.Fprintf(os.Stdout, "Exported %d row(s) from %s to %s\n", count, *table, *output)
+	}
+
+	return nil
+}
+
+func exportColumns(ctx context.Context, db *pgxpool.Pool, table string, excluded []string) ([]string, error) {
+	rows, err := db.Query(ctx, columnsQuery, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns of %s: %w", table, err)
+	}
+
+	columns, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns of %s: %w", table, err)
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s does not exist, apply migrations first", table)
+	}
+
+	return slices.DeleteFunc(columns, func(column string) bool {
+		return slices.Contains(excluded, column)
+	}), nil
+}
+
+func writeJSON(out io.Writer, columns []string, rows pgx.Rows) (int, error) {
+	if _, err := io.WriteString(out, "["); err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return count, err
+		}
+
+		record := make(map[string]any, len(columns))
+		for i, column := range columns {
+			record[column] = exportValue(values[i])
+		}
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return count, err
+		}
+
+		separator := "\n  "
+		if count > 0 {
+			separator = ",\n  "
+		}
+
+		if _, err := io.WriteString(out, separator+string(data)); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+
+	_, err := io.WriteString(out, "\n]\n")
+
+	return count, err
+}
+
+func writeCSV(out io.Writer, columns []string, rows pgx.Rows) (int, error) {
+	w := csv.NewWriter(out)
+
+	if err := w.Write(columns); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	record := make([]string, len(columns))
+
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return count, err
+		}
+
+		for i := range columns {
+			record[i], err = csvValue(values[i])
+			if err != nil {
+				return count, err
+			}
+		}
+
+		if err := w.Write(record); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+
+	w.Flush()
+
+	return count, w.Error()
+}
+
+// exportValue приводит значения pgx к виду для выгрузки: UUID и время - строками
+func exportValue(value any) any {
+	switch v := value.(type) {
+	case [16]byte:
+		return uuid.UUID(v).String()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = exportValue(item)
+		}
+
+		return items
+	default:
+		return v
+	}
+}
+
+// csvValue - значение ячейки CSV: NULL - пустая строка, массивы - JSON
+func csvValue(value any) (string, error) {
+	switch v := exportValue(value).(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []any:
+		data, err := json.Marshal(v)
+
+		return string(data), err
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func exportTableNames() []string {
+	names := make([]string, 0, len(exportTables))
+	for name := range exportTables {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunExportValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		msg  string
+	}{
+		{name: "no table", args: nil, msg: `unknown table ""`},
+		{name: "table outside whitelist", args: []string{"-table", "schema_migrations"}, msg: `unknown table "schema_migrations"`},
+		{name: "conversations are not exported", args: []string{"-table", "conversations"}, msg: `unknown table "conversations"`},
+		{name: "unknown format", args: []string{"-table", "users", "-format", "xml"}, msg: `unknown format "xml"`},
+		{name: "unknown flag", args: []string{"-table", "users", "-limit", "10"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			err := runExport(context.Background(), tt.args, &out)
+
+			require.ErrorIs(t, err, errUsage)
+			assert.Contains(t, err.Error(), tt.msg)
+			assert.Empty(t, out.String())
+		})
+	}
+}
+
+func TestExportTableNames(t *testing.T) {
+	t.Parallel()
+
+	names := exportTableNames()
+
+	assert.IsIncreasing(t, names)
+	assert.Len(t, names, len(exportTables))
+	assert.Contains(t, names, "api_keys")
+}
+
+func TestCSVValue(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("7b0c3c0e-3c5e-4d4a-9a57-5f0c2b9a8e11")
+	createdAt := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "null", value: nil, want: ""},
+		{name: "string", value: "go", want: "go"},
+		{name: "uuid", value: [16]byte(id), want: id.String()},
+		{name: "time", value: createdAt, want: "2024-05-01T10:30:00Z"},
+		{name: "array", value: []any{"go", "sql"}, want: `["go","sql"]`},
+		{name: "number", value: int64(42), want: "42"},
+		{name: "bool", value: true, want: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := csvValue(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
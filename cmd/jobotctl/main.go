@@ -0,0 +1,105 @@
+// jobotctl - CLI для операционных задач: миграции БД, тестовые данные, управление пользователями и выгрузка таблиц.
+// Использует те же переменные окружения БД, что и cmd/app (application.CLIConfig).
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"jobot/internal/application"
+	"jobot/pkg/database"
+	"jobot/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var errUsage = errors.New("invalid usage")
+
+const usage = `Usage: jobotctl <command> [arguments]
+
+Commands:
+  migrate up [-dry-run]              apply pending migrations
+  migrate down [-steps N] [-dry-run] revert the last N applied migrations (default 1)
+  migrate status                     list applied and pending migrations
+  seed [file ...]                    load SQL fixtures (default: embedded migrations/test_data.sql)
+  users deactivate <tg_chat_id>      deactivate a user
+  users reactivate <tg_chat_id>      reactivate a user
+  users purge -yes <tg_chat_id>      delete a user with all profiles, resumes, vacancies, reactions and bot dialog
+  vacancies -employer <id>           list vacancies of an employer
+  vacancies -tg-chat-id <id>         list vacancies of the employer user with tg_chat_id
+            [-format table|json]
+  export -table <name>               export a table to stdout or a file
+         [-format json|csv] [-o file]
+
+Database settings are read from the same DB_* environment variables as the application (see env.example).
+`
+
+func main() {
+	log := logger.InitDevLogger()
+	_ = log.SetLevel("info")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err := run(ctx, os.Args[1:], os.Stdout)
+
+	cancel()
+
+	if errors.Is(err, errUsage) {
+		if err != errUsage {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "migrate":
+		return runMigrate(ctx, args[1:], out)
+	case "seed":
+		return runSeed(ctx, args[1:], out)
+	case "users":
+		return runUsers(ctx, args[1:], out)
+	case "vacancies":
+		return runVacancies(ctx, args[1:], out)
+	case "export":
+		return runExport(ctx, args[1:], out)
+	case "help", "-h", "--help":
+		fmt.Fprint(out, usage)
+
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+	}
+}
+
+// connect загружает конфигурацию утилиты и подключается к БД
+func connect(ctx context.Context) (*application.CLIConfig, *pgxpool.Pool, error) {
+	cfg, err := application.LoadCLIConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := database.NewPostgresPool(ctx, cfg.Database)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return cfg, db, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"jobot/migrations"
+	"jobot/pkg/migrator"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+func runMigrate(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "up", "down", "status":
+	default:
+		return fmt.Errorf("%w: unknown migrate command %q", errUsage, args[0])
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "print SQL that would run without executing it")
+	steps := flags.Int("steps", 1, "number of migrations to revert")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return errUsage
+	}
+
+	_, db, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := migrator.New(db, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	switch args[0] {
+	case "up":
+		if *dryRun {
+			plan, err := m.PlanUp(ctx)
+			if err != nil {
+				return err
+			}
+
+			printPlan(out, plan, false)
+
+			return nil
+		}
+
+		count, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Applied %d migration(s)\n", count)
+	case "down":
+		if *dryRun {
+			plan, err := m.PlanDown(ctx, *steps)
+			if err != nil {
+				return err
+			}
+
+			printPlan(out, plan, true)
+
+			return nil
+		}
+
+		count, err := m.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Reverted %d migration(s)\n", count)
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		return printStatus(out, statuses)
+	}
+
+	return nil
+}
+
+// printPlan печатает SQL миграций в порядке выполнения
+func printPlan(out io.Writer, plan []migrator.Migration, down bool) {
+	if len(plan) == 0 {
+		fmt.Fprintln(out, "-- Nothing to run")
+
+		return
+	}
+
+	for _, migration := range plan {
+		direction, sql := "up", migration.UpSQL
+		if down {
+			direction, sql = "down", migration.DownSQL
+		}
+
+		fmt.Fprintf(out, "-- %03d_%s (%s)\n%s\n", migration.Version, migration.Name, direction, sql)
+	}
+}
+
+// printStatus печатает таблицу версий: applied, pending, changed (файл изменен после применения)
+// или missing (версия применена, но файла нет)
+func printStatus(out io.Writer, statuses []migrator.MigrationStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT\tDOWN")
+
+	pending := 0
+
+	for _, status := range statuses {
+		state := "pending"
+		switch {
+		case status.Missing:
+			state = "missing"
+		case status.Changed:
+			state = "changed"
+		case status.Applied:
+			state = "applied"
+		default:
+			pending++
+		}
+
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(timeFormat)
+		}
+
+		down := "no"
+		if status.HasDown {
+			down = "yes"
+		}
+
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt, down)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d migration(s), %d pending\n", len(statuses), pending)
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/pkg/migrator"
+)
+
+func TestRunMigrateUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"redo"}},
+		{name: "unknown flag", args: []string{"down", "-force"}},
+		{name: "invalid steps", args: []string{"down", "-steps", "two"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := runMigrate(context.Background(), tt.args, &bytes.Buffer{})
+			require.ErrorIs(t, err, errUsage)
+		})
+	}
+}
+
+func TestPrintPlan(t *testing.T) {
+	t.Parallel()
+
+	plan := []migrator.Migration{
+		{Version: 12, Name: "create_matches", UpSQL: "CREATE TABLE matches ();", DownSQL: "DROP TABLE matches;"},
+		{Version: 11, Name: "create_reactions", UpSQL: "CREATE TABLE reactions ();", DownSQL: "DROP TABLE reactions;"},
+	}
+
+	tests := []struct {
+		name string
+		plan []migrator.Migration
+		down bool
+		want string
+	}{
+		{
+			name: "up",
+			plan: plan[:1],
+			want: "-- 012_create_matches (up)\nCREATE TABLE matches ();\n",
+		},
+		{
+			name: "down",
+			plan: plan,
+			down: true,
+			want: "-- 012_create_matches (down)\nDROP TABLE matches;\n" +
+				"-- 011_create_reactions (down)\nDROP TABLE reactions;\n",
+		},
+		{
+			name: "empty",
+			want: "-- Nothing to run\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			printPlan(&out, tt.plan, tt.down)
+
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestPrintStatus(t *testing.T) {
+	t.Parallel()
+
+	appliedAt := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	var out bytes.Buffer
+	err := printStatus(&out, []migrator.MigrationStatus{
+		{Version: 1, Name: "create_users", Applied: true, AppliedAt: &appliedAt, HasDown: true},
+		{Version: 2, Name: "create_employees", Applied: true, AppliedAt: &appliedAt, Changed: true},
+		{Version: 3, Name: "create_employers", HasDown: true},
+		{Version: 4, Name: "removed", Applied: true, AppliedAt: &appliedAt, Missing: true},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, ""+
+		"VERSION  NAME              STATUS   APPLIED AT           DOWN\n"+
+		"001      create_users      applied  2024-05-01 10:30:00  yes\n"+
+		"002      create_employees  changed  2024-05-01 10:30:00  no\n"+
+		"003      create_employers  pending  -                    yes\n"+
+		"004      removed           missing  2024-05-01 10:30:00  no\n"+
+		"\n4 migration(s), 1 pending\n", out.String())
+}
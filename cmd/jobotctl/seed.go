@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"jobot/migrations"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// defaultFixture - тестовые данные, встроенные в бинарник вместе с миграциями
+const defaultFixture = "test_data.sql"
+
+// runSeed выполняет SQL файлы с данными в формате migrations/test_data.sql.
+// Каждый файл выполняется в своей транзакции, без аргументов - встроенный test_data.sql.
+func runSeed(ctx context.Context, args []string, out io.Writer) error {
+	_, db, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if len(args) == 0 {
+		content, err := migrations.FS.ReadFile(defaultFixture)
+		if err != nil {
+			return fmt.Errorf("failed to read embedded fixture: %w", err)
+		}
+
+		return seed(ctx, db, "embedded "+defaultFixture, string(content), out)
+	}
+
+	for _, path := range args {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read fixture: %w", err)
+		}
+
+		if err := seed(ctx, db, path, string(content), out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func seed(ctx context.Context, db *pgxpool.Pool, name, sql string, out io.Writer) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return fmt.Errorf("failed to load fixture %s: %w", name, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit fixture %s: %w", name, err)
+	}
+
+	fmt.Fprintf(out, "Loaded %s\n", name)
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	conversationRepo "jobot/internal/repository/conversation"
+	"jobot/internal/repository/transaction"
+	userRepo "jobot/internal/repository/user"
+)
+
+// runUsers - управление пользователями по tg_chat_id: deactivate, reactivate и purge
+func runUsers(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	command := args[0]
+
+	switch command {
+	case "deactivate", "reactivate", "purge":
+	default:
+		return fmt.Errorf("%w: unknown users command %q", errUsage, command)
+	}
+
+	flags := flag.NewFlagSet("users "+command, flag.ContinueOnError)
+	confirm := flags.Bool("yes", false, "confirm deletion of all user data")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return errUsage
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: users %s expects a tg_chat_id", errUsage, command)
+	}
+
+	tgChatID := flags.Arg(0)
+
+	// Удаление подтверждается до подключения к БД, чтобы без -yes утилита ничего не трогала
+	if command == "purge" && !*confirm {
+		return fmt.Errorf("purge deletes user %s with all profiles, resumes, vacancies, "+
+			"reactions, applications and bot dialog: rerun with -yes to confirm", tgChatID)
+	}
+
+	_, db, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	userRepository := userRepo.NewUserRepository(db)
+
+	user, err := userRepository.GetUserByTgChatID(ctx, tgChatID)
+	if err != nil {
+		return fmt.Errorf("failed to get user by tg_chat_id %s: %w", tgChatID, err)
+	}
+
+	switch command {
+	case "deactivate", "reactivate":
+		isActive := command == "reactivate"
+		if user.IsActive == isActive {
+			fmt.Fprintf(out, "User %s (%s) is already %sd\n", user.ID, tgChatID, command)
+
+			return nil
+		}
+
+		user.IsActive = isActive
+		if err := userRepository.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		fmt.Fprintf(out, "User %s (%s) %sd\n", user.ID, tgChatID, command)
+	case "purge":
+		// Профили, резюме, вакансии, реакции и заявки пользователя удаляются каскадно,
+		// диалог бота с черновиком не связан с users внешним ключом и удаляется в той же транзакции
+		conversationRepository := conversationRepo.NewConversationRepository(db)
+
+		err := transaction.NewManager(db).WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := userRepository.DeleteUser(ctx, user.ID); err != nil {
+				return fmt.Errorf("failed to delete user: %w", err)
+			}
+
+			err := conversationRepository.DeleteConversation(ctx, tgChatID)
+			if err != nil && !errors.Is(err, conversationRepo.ErrConversationNotFound) {
+				return err
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "User %s (%s) purged\n", user.ID, tgChatID)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunUsersUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"delete", "111"}},
+		{name: "no tg_chat_id", args: []string{"deactivate"}},
+		{name: "several tg_chat_ids", args: []string{"reactivate", "111", "222"}},
+		{name: "unknown flag", args: []string{"purge", "-force", "111"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := runUsers(context.Background(), tt.args, &bytes.Buffer{})
+			require.ErrorIs(t, err, errUsage)
+		})
+	}
+}
+
+func TestRunUsersPurgeRequiresConfirmation(t *testing.T) {
+	t.Parallel()
+
+	// Без -yes утилита отказывает до подключения к БД, поэтому тест не требует Postgres
+	var out bytes.Buffer
+	err := runUsers(context.Background(), []string{"purge", "111"}, &out)
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errUsage)
+	assert.Contains(t, err.Error(), "rerun with -yes")
+	assert.Empty(t, out.String())
+}
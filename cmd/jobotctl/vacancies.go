@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	employerRepo "jobot/internal/repository/employer"
+	userRepo "jobot/internal/repository/user"
+	vacancyRepo "jobot/internal/repository/vacancy"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+// runVacancies печатает вакансии работодателя, заданного ID профиля или tg_chat_id пользователя
+func runVacancies(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("vacancies", flag.ContinueOnError)
+	employerID := flags.String("employer", "", "employer ID")
+	tgChatID := flags.String("tg-chat-id", "", "tg_chat_id of the employer user")
+	format := flags.String("format", "table", "output format: table or json")
+
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if (*employerID == "") == (*tgChatID == "") {
+		return fmt.Errorf("%w: vacancies expects either -employer or -tg-chat-id", errUsage)
+	}
+
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+
+	_, db, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var employerUUID uuid.UUID
+
+	if *employerID != "" {
+		employerUUID, err = uuid.Parse(*employerID)
+		if err != nil {
+			return fmt.Errorf("invalid employer ID: %w", err)
+		}
+	} else {
+		user, err := userRepo.NewUserRepository(db).GetUserByTgChatID(ctx, *tgChatID)
+		if err != nil {
+			return fmt.Errorf("failed to get user by tg_chat_id %s: %w", *tgChatID, err)
+		}
+
+		employer, err := employerRepo.NewEmployerRepository(db).GetEmployerByUserID(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get employer by user ID: %w", err)
+		}
+
+		employerUUID = employer.EmployerID
+	}
+
+	list, err := vacancyRepo.NewVacancyRepository(db).GetVacanciesByEmployer(ctx, employerUUID)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(list)
+	}
+
+	return printVacancies(out, list.Vacansies)
+}
+
+func printVacancies(out io.Writer, vacancies []models.Vacancy) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tTITLE\tLOCATION\tSALARY\tTAGS\tCREATED AT")
+
+	for _, vacancy := range vacancies {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			vacancy.VacansieID,
+			vacancy.Title,
+			vacancy.Location,
+			vacancy.Salary,
+			strings.Join(vacancy.Tags, ","),
+			vacancy.CreatedAt.Format(timeFormat),
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d vacancy(ies)\n", len(vacancies))
+
+	return nil
+}
@@ -12,6 +12,11 @@ type Controller struct {
 	EmployerController
 	VacancyController
 	ReactionController
+	FeedController
+	ApplicationController
+	AuthController
+	APIKeyController
+	NotificationController
 }
 
 // Controller interfaces
@@ -22,6 +27,10 @@ type UserController interface {
 	UpdateUser(w http.ResponseWriter, r *http.Request)
 	DeleteUser(w http.ResponseWriter, r *http.Request)
 	GetUserProfile(w http.ResponseWriter, r *http.Request)
+	GetUserProfileByTgChatID(w http.ResponseWriter, r *http.Request)
+	GetUserProfileByTgUserName(w http.ResponseWriter, r *http.Request)
+	Onboard(w http.ResponseWriter, r *http.Request)
+	ChangeUserRole(w http.ResponseWriter, r *http.Request)
 }
 
 type EmployeeController interface {
@@ -52,6 +61,7 @@ type VacancyController interface {
 	CreateVacancy(w http.ResponseWriter, r *http.Request)
 	GetVacancy(w http.ResponseWriter, r *http.Request)
 	GetVacancyList(w http.ResponseWriter, r *http.Request)
+	SearchVacancies(w http.ResponseWriter, r *http.Request)
 	GetEmployerVacancies(w http.ResponseWriter, r *http.Request)
 	UpdateVacancy(w http.ResponseWriter, r *http.Request)
 	DeleteVacancy(w http.ResponseWriter, r *http.Request)
@@ -61,5 +71,49 @@ type ReactionController interface {
 	CreateReaction(w http.ResponseWriter, r *http.Request)
 	GetReaction(w http.ResponseWriter, r *http.Request)
 	GetEmployeeReactions(w http.ResponseWriter, r *http.Request)
+	UpdateReaction(w http.ResponseWriter, r *http.Request)
 	DeleteReaction(w http.ResponseWriter, r *http.Request)
+	GetVacancyReactions(w http.ResponseWriter, r *http.Request)
+
+	CreateEmployerReaction(w http.ResponseWriter, r *http.Request)
+	GetEmployerReaction(w http.ResponseWriter, r *http.Request)
+	GetEmployerReactions(w http.ResponseWriter, r *http.Request)
+	UpdateEmployerReaction(w http.ResponseWriter, r *http.Request)
+	DeleteEmployerReaction(w http.ResponseWriter, r *http.Request)
+
+	GetEmployeeMatches(w http.ResponseWriter, r *http.Request)
+	GetEmployerMatches(w http.ResponseWriter, r *http.Request)
+}
+
+type FeedController interface {
+	GetEmployeeFeed(w http.ResponseWriter, r *http.Request)
+	GetVacancyCandidates(w http.ResponseWriter, r *http.Request)
+}
+
+type ApplicationController interface {
+	CreateApplication(w http.ResponseWriter, r *http.Request)
+	GetApplication(w http.ResponseWriter, r *http.Request)
+	TransitApplication(w http.ResponseWriter, r *http.Request)
+	GetEmployeeApplications(w http.ResponseWriter, r *http.Request)
+	GetEmployerApplications(w http.ResponseWriter, r *http.Request)
+}
+
+type AuthController interface {
+	LoginTelegramWidget(w http.ResponseWriter, r *http.Request)
+	LoginTelegramWebApp(w http.ResponseWriter, r *http.Request)
+	RefreshToken(w http.ResponseWriter, r *http.Request)
+	GetCurrentUser(w http.ResponseWriter, r *http.Request)
+	Authenticate(next http.Handler) http.Handler
+}
+
+type NotificationController interface {
+	GetNotificationPreferences(w http.ResponseWriter, r *http.Request)
+	UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request)
+}
+
+type APIKeyController interface {
+	CreateAPIKey(w http.ResponseWriter, r *http.Request)
+	GetAPIKeys(w http.ResponseWriter, r *http.Request)
+	RotateAPIKey(w http.ResponseWriter, r *http.Request)
+	RevokeAPIKey(w http.ResponseWriter, r *http.Request)
 }
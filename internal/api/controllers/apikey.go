@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"net/http"
+
+	"jobot/internal/api/converter"
+	"jobot/internal/api/models"
+	"jobot/internal/service"
+	serviceModels "jobot/internal/service/models"
+	"jobot/pkg/logger"
+)
+
+const (
+	APIKeyIDPathValue = "ApiKeyID"
+)
+
+// APIKeyController - управление API ключами сервисов, доступно только администраторам
+type APIKeyController struct {
+	apiKeyService service.APIKeyService
+	accessService service.AccessService
+	BaseController
+}
+
+func NewAPIKeyController(apiKeyService service.APIKeyService, accessService service.AccessService) *APIKeyController {
+	return &APIKeyController{apiKeyService: apiKeyService, accessService: accessService}
+}
+
+func (c *APIKeyController) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("create_api_key")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start create api key request")
+
+	err := c.accessService.RequireRole(ctx, serviceModels.UserRoleAdmin)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	req := &models.APIKeyCreateRequest{}
+
+	err = c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	issuedKey, err := c.apiKeyService.CreateAPIKey(ctx, converter.APIKeyCreateRequestToServiceAPIKey(req))
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusCreated, converter.ServiceIssuedAPIKeyToIssuedAPIKeyResponse(issuedKey))
+
+	log.Info("Create api key request completed")
+}
+
+func (c *APIKeyController) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_api_keys")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get api keys request")
+
+	err := c.accessService.RequireRole(ctx, serviceModels.UserRoleAdmin)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	keys, err := c.apiKeyService.GetAPIKeys(ctx)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceAPIKeysToAPIKeyResponses(keys))
+
+	log.Info("Get api keys request completed")
+}
+
+func (c *APIKeyController) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("rotate_api_key")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start rotate api key request")
+
+	apiKeyUUID, err := c.GetUUIDFromPath(r, APIKeyIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.RequireRole(ctx, serviceModels.UserRoleAdmin)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	issuedKey, err := c.apiKeyService.RotateAPIKey(ctx, apiKeyUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceIssuedAPIKeyToIssuedAPIKeyResponse(issuedKey))
+
+	log.Info("Rotate api key request completed")
+}
+
+func (c *APIKeyController) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("revoke_api_key")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start revoke api key request")
+
+	apiKeyUUID, err := c.GetUUIDFromPath(r, APIKeyIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.RequireRole(ctx, serviceModels.UserRoleAdmin)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	err = c.apiKeyService.RevokeAPIKey(ctx, apiKeyUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, nil)
+
+	log.Info("Revoke api key request completed")
+}
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"net/http"
+
+	"jobot/internal/api/converter"
+	"jobot/internal/api/models"
+	"jobot/internal/service"
+	"jobot/pkg/logger"
+)
+
+const (
+	ApplicationIDPathValue = "ApplicationID"
+
+	StatusQueryValue = "status"
+)
+
+type ApplicationController struct {
+	applicationService service.ApplicationService
+	accessService      service.AccessService
+	BaseController
+}
+
+func NewApplicationController(applicationService service.ApplicationService, accessService service.AccessService) *ApplicationController {
+	return &ApplicationController{applicationService: applicationService, accessService: accessService}
+}
+
+func (c *ApplicationController) CreateApplication(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("create_application")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start create application request")
+
+	req := &models.ApplicationCreateRequest{}
+
+	err := c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	serviceApplication, err := converter.ApplicationCreateRequestToServiceApplication(req)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployee(ctx, serviceApplication.EmployeeID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	createdApplication, err := c.applicationService.CreateApplication(ctx, serviceApplication)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusCreated, converter.ServiceApplicationToApplicationResponse(createdApplication))
+
+	log.Info("Create application request completed")
+}
+
+func (c *ApplicationController) GetApplication(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_application")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get application request")
+
+	applicationUUID, err := c.GetUUIDFromPath(r, ApplicationIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckApplication(ctx, applicationUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	application, err := c.applicationService.GetApplication(ctx, applicationUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceApplicationToApplicationResponse(application))
+
+	log.Info("Get application request completed")
+}
+
+func (c *ApplicationController) TransitApplication(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("transit_application")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start transit application request")
+
+	applicationUUID, err := c.GetUUIDFromPath(r, ApplicationIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	req := &models.ApplicationTransitionRequest{}
+
+	err = c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	serviceReq, err := converter.ApplicationTransitionRequestToServiceApplicationTransitionRequest(req)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckApplicationActor(ctx, serviceReq.Actor, serviceReq.ActorID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	application, err := c.applicationService.TransitApplication(ctx, serviceReq, applicationUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceApplicationToApplicationResponse(application))
+
+	log.Info("Transit application request completed")
+}
+
+func (c *ApplicationController) GetEmployeeApplications(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_employee_applications")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get employee applications request")
+
+	employeeUUID, err := c.GetUUIDFromPath(r, EmployeeIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter, err := converter.ApplicationFilterFromQuery(r.URL.Query().Get(StatusQueryValue), r.URL.Query().Get(VacansieIDQueryValue))
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	pagination, err := converter.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployee(ctx, employeeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	applicationList, err := c.applicationService.GetEmployeeApplications(ctx, employeeUUID, filter, pagination)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	meta := NewMeta(applicationList.Pagination.Page, applicationList.Pagination.PerPage, applicationList.Total)
+
+	c.JSONSuccess(w, converter.ServiceApplicationListToApplicationListResponse(applicationList), "", http.StatusOK, meta)
+
+	log.Info("Get employee applications request completed")
+}
+
+func (c *ApplicationController) GetEmployerApplications(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_employer_applications")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get employer applications request")
+
+	employerUUID, err := c.GetUUIDFromPath(r, EmployerIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter, err := converter.ApplicationFilterFromQuery(r.URL.Query().Get(StatusQueryValue), r.URL.Query().Get(VacansieIDQueryValue))
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	pagination, err := converter.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployer(ctx, employerUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	applicationList, err := c.applicationService.GetEmployerApplications(ctx, employerUUID, filter, pagination)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	meta := NewMeta(applicationList.Pagination.Page, applicationList.Pagination.PerPage, applicationList.Total)
+
+	c.JSONSuccess(w, converter.ServiceApplicationListToApplicationListResponse(applicationList), "", http.StatusOK, meta)
+
+	log.Info("Get employer applications request completed")
+}
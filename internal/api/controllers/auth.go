@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"jobot/internal/api/converter"
+	"jobot/internal/api/models"
+	"jobot/internal/service"
+	"jobot/internal/service/auth"
+	"jobot/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Схемы заголовка Authorization: access токен пользователя и API ключ сервиса
+const (
+	bearerPrefix = "Bearer "
+	apiKeyPrefix = "ApiKey "
+
+	authenticateChallenge = "Bearer, ApiKey"
+)
+
+// OnBehalfOfUserHeader - ID пользователя, от имени которого сервис выполняет запрос по API ключу
+const OnBehalfOfUserHeader = "X-On-Behalf-Of-User"
+
+type AuthController struct {
+	authService   service.AuthService
+	apiKeyService service.APIKeyService
+	BaseController
+}
+
+func NewAuthController(authService service.AuthService, apiKeyService service.APIKeyService) *AuthController {
+	return &AuthController{authService: authService, apiKeyService: apiKeyService}
+}
+
+func (c *AuthController) LoginTelegramWidget(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("login_telegram_widget")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start login telegram widget request")
+
+	req := &models.TelegramWidgetLoginRequest{}
+
+	err := c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	result, err := c.authService.LoginTelegramWidget(ctx, converter.TelegramWidgetLoginRequestToAuthData(req), req.Role)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceAuthResultToAuthResponse(result))
+
+	log.Info("Login telegram widget request completed")
+}
+
+func (c *AuthController) LoginTelegramWebApp(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("login_telegram_webapp")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start login telegram webapp request")
+
+	req := &models.TelegramWebAppLoginRequest{}
+
+	err := c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	result, err := c.authService.LoginTelegramWebApp(ctx, req.InitData, req.Role)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceAuthResultToAuthResponse(result))
+
+	log.Info("Login telegram webapp request completed")
+}
+
+func (c *AuthController) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("refresh_token")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start refresh token request")
+
+	req := &models.RefreshTokenRequest{}
+
+	err := c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	result, err := c.authService.RefreshTokens(ctx, req.RefreshToken)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceAuthResultToAuthResponse(result))
+
+	log.Info("Refresh token request completed")
+}
+
+// GetCurrentUser возвращает пользователя, которому выдан access токен запроса
+func (c *AuthController) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		c.JSONSimpleError(w, "authentication required", http.StatusUnauthorized)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceUserToUserResponse(user))
+}
+
+// Authenticate - middleware, который проверяет заголовок Authorization и кладет в контекст запроса
+// пользователя (см. auth.UserFromContext) и API ключ (см. auth.APIKeyFromContext).
+// Bearer - access токен пользователя, ApiKey - ключ сервиса, с ним можно передать X-On-Behalf-Of-User.
+// Без заголовка отвечает 401.
+func (c *AuthController) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+
+		var ctx context.Context
+		var err error
+
+		switch {
+		case strings.HasPrefix(header, bearerPrefix):
+			ctx, err = c.authenticateUser(r.Context(), strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix)))
+		case strings.HasPrefix(header, apiKeyPrefix):
+			ctx, err = c.authenticateAPIKey(r.Context(), strings.TrimSpace(strings.TrimPrefix(header, apiKeyPrefix)), r.Header.Get(OnBehalfOfUserHeader))
+		default:
+			w.Header().Set("WWW-Authenticate", authenticateChallenge)
+			c.JSONSimpleError(w, "authentication required", http.StatusUnauthorized)
+
+			return
+		}
+
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", authenticateChallenge)
+			c.HandleServiceError(w, r, err)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (c *AuthController) authenticateUser(ctx context.Context, accessToken string) (context.Context, error) {
+	user, err := c.authService.Authenticate(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.FromContext(ctx).With(zap.String("user_id", user.ID.String()))
+
+	return auth.ContextWithUser(logger.ContextWithLogger(ctx, log), user), nil
+}
+
+func (c *AuthController) authenticateAPIKey(ctx context.Context, rawKey string, onBehalfOf string) (context.Context, error) {
+	key, user, err := c.apiKeyService.Authenticate(ctx, rawKey, onBehalfOf)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.FromContext(ctx).With(zap.String("api_key_id", key.ID.String()))
+	ctx = auth.ContextWithAPIKey(ctx, key)
+
+	if user != nil {
+		log = log.With(zap.String("user_id", user.ID.String()))
+		ctx = auth.ContextWithUser(ctx, user)
+	}
+
+	return logger.ContextWithLogger(ctx, log), nil
+}
@@ -57,8 +57,10 @@ type SuccessResponse struct {
 }
 
 // Структура для ошибок
+// ErrorCode - стабильный машиночитаемый код ошибки (см. errors.go), Code - HTTP статус
 type ErrorResponse struct {
 	Error     string      `json:"error"`
+	ErrorCode string      `json:"error_code"`
 	Message   string      `json:"message"`
 	Code      int         `json:"code,omitempty"`
 	Timestamp time.Time   `json:"timestamp"`
@@ -73,8 +75,19 @@ type Meta struct {
 	TotalPages int `json:"total_pages,omitempty"`
 }
 
-func (c *BaseController) ReadRequestBody(r *http.Request, v interface{}) error {
-	return json.NewDecoder(r.Body).Decode(v)
+// NewMeta собирает мета-информацию пагинации
+func NewMeta(page, perPage, total int) *Meta {
+	totalPages := 0
+	if perPage > 0 {
+		totalPages = (total + perPage - 1) / perPage
+	}
+
+	return &Meta{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      total,
+		TotalPages: totalPages,
+	}
 }
 
 // Успешный ответ
@@ -93,11 +106,21 @@ func (c *BaseController) JSONSuccess(w http.ResponseWriter, data interface{}, me
 
 // Ответ с ошибкой
 func (c *BaseController) JSONError(w http.ResponseWriter, errorMsg string, message string, code int, details interface{}) {
+	c.writeJSONError(w, errorMsg, defaultErrorCode(code), message, code, details)
+}
+
+// writeError отвечает ошибкой с заданным кодом ошибки API
+func (c *BaseController) writeError(w http.ResponseWriter, code int, errorCode string, message string, details interface{}) {
+	c.writeJSONError(w, http.StatusText(code), errorCode, message, code, details)
+}
+
+func (c *BaseController) writeJSONError(w http.ResponseWriter, errorMsg string, errorCode string, message string, code int, details interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 
 	response := ErrorResponse{
 		Error:     errorMsg,
+		ErrorCode: errorCode,
 		Message:   message,
 		Code:      code,
 		Timestamp: time.Now(),
@@ -6,6 +6,7 @@ import (
 	"jobot/internal/api/converter"
 	"jobot/internal/api/models"
 	"jobot/internal/service"
+	serviceModels "jobot/internal/service/models"
 	"jobot/pkg/logger"
 )
 
@@ -15,11 +16,12 @@ const (
 
 type EmployeeController struct {
 	employeeService service.EmployeeService
+	accessService   service.AccessService
 	BaseController
 }
 
-func NewEmployeeController(employeeService service.EmployeeService) *EmployeeController {
-	return &EmployeeController{employeeService: employeeService}
+func NewEmployeeController(employeeService service.EmployeeService, accessService service.AccessService) *EmployeeController {
+	return &EmployeeController{employeeService: employeeService, accessService: accessService}
 }
 
 func (c *EmployeeController) CreateEmployee(w http.ResponseWriter, r *http.Request) {
@@ -32,7 +34,7 @@ func (c *EmployeeController) CreateEmployee(w http.ResponseWriter, r *http.Reque
 
 	err := c.ReadRequestBody(r, req)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -44,9 +46,16 @@ func (c *EmployeeController) CreateEmployee(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = c.accessService.CheckNewProfile(ctx, serviceEmployee.UserID, serviceModels.UserRoleEmployee)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	createdEmployee, err := c.employeeService.CreateEmployee(ctx, serviceEmployee)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -71,7 +80,7 @@ func (c *EmployeeController) GetEmployee(w http.ResponseWriter, r *http.Request)
 
 	employee, err := c.employeeService.GetEmployee(ctx, employeeUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -96,7 +105,7 @@ func (c *EmployeeController) GetEmployeeByUserID(w http.ResponseWriter, r *http.
 
 	employee, err := c.employeeService.GetEmployeeByUserID(ctx, userUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -123,7 +132,7 @@ func (c *EmployeeController) UpdateEmployee(w http.ResponseWriter, r *http.Reque
 
 	err = c.ReadRequestBody(r, employee)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -135,9 +144,16 @@ func (c *EmployeeController) UpdateEmployee(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = c.accessService.CheckEmployee(ctx, employeeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.employeeService.UpdateEmployee(ctx, updateEmployee, employeeUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -160,9 +176,16 @@ func (c *EmployeeController) DeleteEmployee(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = c.accessService.CheckEmployee(ctx, employeeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.employeeService.DeleteEmployee(ctx, employeeUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
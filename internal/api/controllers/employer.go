@@ -6,6 +6,7 @@ import (
 	"jobot/internal/api/converter"
 	"jobot/internal/api/models"
 	"jobot/internal/service"
+	serviceModels "jobot/internal/service/models"
 	"jobot/pkg/logger"
 )
 
@@ -15,11 +16,12 @@ const (
 
 type EmployerController struct {
 	employerService service.EmployerService
+	accessService   service.AccessService
 	BaseController
 }
 
-func NewEmployerController(employerService service.EmployerService) *EmployerController {
-	return &EmployerController{employerService: employerService}
+func NewEmployerController(employerService service.EmployerService, accessService service.AccessService) *EmployerController {
+	return &EmployerController{employerService: employerService, accessService: accessService}
 }
 
 func (c *EmployerController) CreateEmployer(w http.ResponseWriter, r *http.Request) {
@@ -32,7 +34,7 @@ func (c *EmployerController) CreateEmployer(w http.ResponseWriter, r *http.Reque
 
 	err := c.ReadRequestBody(r, req)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -44,9 +46,16 @@ func (c *EmployerController) CreateEmployer(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = c.accessService.CheckNewProfile(ctx, serviceEmployer.UserID, serviceModels.UserRoleEmployer)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	createdEmployer, err := c.employerService.CreateEmployer(ctx, serviceEmployer)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -71,7 +80,7 @@ func (c *EmployerController) GetEmployer(w http.ResponseWriter, r *http.Request)
 
 	employer, err := c.employerService.GetEmployer(ctx, employerUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -96,7 +105,7 @@ func (c *EmployerController) GetEmployerByUserID(w http.ResponseWriter, r *http.
 
 	employer, err := c.employerService.GetEmployerByUserID(ctx, userUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -123,7 +132,7 @@ func (c *EmployerController) UpdateEmployer(w http.ResponseWriter, r *http.Reque
 
 	err = c.ReadRequestBody(r, employer)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -135,9 +144,16 @@ func (c *EmployerController) UpdateEmployer(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = c.accessService.CheckEmployer(ctx, employerUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.employerService.UpdateEmployer(ctx, updateEmployer, employerUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -160,9 +176,16 @@ func (c *EmployerController) DeleteEmployer(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = c.accessService.CheckEmployer(ctx, employerUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.employerService.DeleteEmployer(ctx, employerUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	apiKeyRepo "jobot/internal/repository/apikey"
+	applicationRepo "jobot/internal/repository/application"
+	employeeRepo "jobot/internal/repository/employee"
+	employerRepo "jobot/internal/repository/employer"
+	"jobot/internal/repository/pgerr"
+	reactionRepo "jobot/internal/repository/reaction"
+	resumeRepo "jobot/internal/repository/resume"
+	userRepo "jobot/internal/repository/user"
+	vacancyRepo "jobot/internal/repository/vacancy"
+	accessSrv "jobot/internal/service/access"
+	apiKeySrv "jobot/internal/service/apikey"
+	applicationSrv "jobot/internal/service/application"
+	authSrv "jobot/internal/service/auth"
+	notificationSrv "jobot/internal/service/notification"
+	reactionSrv "jobot/internal/service/reaction"
+	userSrv "jobot/internal/service/user"
+	vacancySrv "jobot/internal/service/vacancy"
+	"jobot/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Общие коды ошибок API, используются, когда для ошибки нет более точного кода
+const (
+	ErrorCodeBadRequest       = "bad_request"
+	ErrorCodeValidationFailed = "validation_failed"
+	ErrorCodeInvalidReference = "invalid_reference"
+	ErrorCodeUnauthorized     = "unauthorized"
+	ErrorCodeForbidden        = "forbidden"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeConflict         = "conflict"
+	ErrorCodeInternal         = "internal_error"
+)
+
+// internalErrorMessage - сообщение для 500, детали ошибки пишутся только в лог
+const internalErrorMessage = "internal server error"
+
+// errorMapping - соответствие доменной ошибки HTTP статусу и стабильному коду ошибки
+type errorMapping struct {
+	target error
+	status int
+	code   string
+}
+
+// errorMappings - таблица трансляции доменных ошибок, первая совпавшая через errors.Is запись побеждает.
+// Коды ошибок - часть контракта API: клиенты (в том числе бот) ветвятся по ним, поэтому их нельзя переименовывать.
+var errorMappings = []errorMapping{
+	// Аутентификация
+	{authSrv.ErrInvalidTelegramData, http.StatusUnauthorized, "invalid_telegram_data"},
+	{authSrv.ErrInvalidTelegramSignature, http.StatusUnauthorized, "invalid_telegram_signature"},
+	{authSrv.ErrTelegramAuthExpired, http.StatusUnauthorized, "telegram_auth_expired"},
+	{authSrv.ErrInvalidToken, http.StatusUnauthorized, "invalid_token"},
+	{authSrv.ErrTokenExpired, http.StatusUnauthorized, "token_expired"},
+	{authSrv.ErrUserInactive, http.StatusForbidden, "user_inactive"},
+	{authSrv.ErrInvalidRole, http.StatusUnprocessableEntity, "invalid_role"},
+	{accessSrv.ErrAuthenticationRequired, http.StatusUnauthorized, ErrorCodeUnauthorized},
+	{apiKeySrv.ErrInvalidAPIKey, http.StatusUnauthorized, "invalid_api_key"},
+	{apiKeySrv.ErrAPIKeyRevoked, http.StatusUnauthorized, "api_key_revoked"},
+	{apiKeySrv.ErrInvalidOnBehalfOfUser, http.StatusUnauthorized, "invalid_on_behalf_of_user"},
+
+	// Авторизация
+	{accessSrv.ErrRoleNotAllowed, http.StatusForbidden, "role_not_allowed"},
+	{accessSrv.ErrForbidden, http.StatusForbidden, ErrorCodeForbidden},
+	{accessSrv.ErrOnBehalfOfUserRequired, http.StatusForbidden, "on_behalf_of_user_required"},
+	{apiKeySrv.ErrScopeRequired, http.StatusForbidden, "scope_required"},
+
+	// Не найдено
+	{userRepo.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{userSrv.ErrUserRoleNotFound, http.StatusNotFound, "user_role_not_found"},
+	{employeeRepo.ErrEmployeeNotFound, http.StatusNotFound, "employee_not_found"},
+	{employerRepo.ErrEmployerNotFound, http.StatusNotFound, "employer_not_found"},
+	{resumeRepo.ErrResumeNotFound, http.StatusNotFound, "resume_not_found"},
+	{vacancyRepo.ErrVacancyNotFound, http.StatusNotFound, "vacancy_not_found"},
+	{reactionRepo.ErrReactionNotFound, http.StatusNotFound, "reaction_not_found"},
+	{reactionRepo.ErrEmployerReactionNotFound, http.StatusNotFound, "employer_reaction_not_found"},
+	{applicationRepo.ErrApplicationNotFound, http.StatusNotFound, "application_not_found"},
+	{apiKeyRepo.ErrAPIKeyNotFound, http.StatusNotFound, "api_key_not_found"},
+
+	// Уже существует
+	{userRepo.ErrUserAlreadyExists, http.StatusConflict, "user_already_exists"},
+	{userRepo.ErrUserNameAmbiguous, http.StatusConflict, "tg_user_name_ambiguous"},
+	{employeeRepo.ErrEmployeeAlreadyExists, http.StatusConflict, "employee_already_exists"},
+	{employerRepo.ErrEmployerAlreadyExists, http.StatusConflict, "employer_already_exists"},
+	{resumeRepo.ErrResumeAlreadyExists, http.StatusConflict, "resume_already_exists"},
+	{vacancyRepo.ErrVacancyAlreadyExists, http.StatusConflict, "vacancy_already_exists"},
+	{reactionRepo.ErrReactionAlreadyExists, http.StatusConflict, "reaction_already_exists"},
+	{reactionRepo.ErrEmployerReactionAlreadyExists, http.StatusConflict, "employer_reaction_already_exists"},
+	{applicationRepo.ErrApplicationAlreadyExists, http.StatusConflict, "application_already_exists"},
+	{applicationRepo.ErrApplicationStatusConflict, http.StatusConflict, "application_status_conflict"},
+
+	// Нарушение бизнес-правил
+	{vacancySrv.ErrInvalidDateRange, http.StatusUnprocessableEntity, "invalid_date_range"},
+	{vacancySrv.ErrEmptySearchQuery, http.StatusUnprocessableEntity, "empty_search_query"},
+	{reactionSrv.ErrInvalidReactionType, http.StatusUnprocessableEntity, "invalid_reaction_type"},
+	{reactionSrv.ErrInvalidDecision, http.StatusUnprocessableEntity, "invalid_decision"},
+	{reactionSrv.ErrCandidateNotInterested, http.StatusUnprocessableEntity, "candidate_not_interested"},
+	{applicationSrv.ErrInvalidApplicationStatus, http.StatusUnprocessableEntity, "invalid_application_status"},
+	{applicationSrv.ErrInvalidActor, http.StatusUnprocessableEntity, "invalid_actor"},
+	{applicationSrv.ErrTransitionNotAllowed, http.StatusUnprocessableEntity, "transition_not_allowed"},
+	{apiKeySrv.ErrInvalidScope, http.StatusUnprocessableEntity, "invalid_scope"},
+	{userSrv.ErrInvalidOnboardingRole, http.StatusUnprocessableEntity, "invalid_role"},
+	{userSrv.ErrTgUserNameRequired, http.StatusUnprocessableEntity, "tg_user_name_required"},
+	{userSrv.ErrProfileDataRequired, http.StatusUnprocessableEntity, "profile_data_required"},
+	{userSrv.ErrRoleMismatch, http.StatusConflict, "role_mismatch"},
+	{userSrv.ErrRoleUnchanged, http.StatusConflict, "role_unchanged"},
+	{userSrv.ErrInvalidRole, http.StatusUnprocessableEntity, "invalid_role"},
+	{userSrv.ErrRoleChangeNotAllowed, http.StatusUnprocessableEntity, "role_change_not_allowed"},
+	{notificationSrv.ErrInvalidQuietHours, http.StatusUnprocessableEntity, "invalid_quiet_hours"},
+	{notificationSrv.ErrInvalidTimezone, http.StatusUnprocessableEntity, "invalid_timezone"},
+
+	// Чужие ресурсы
+	{reactionSrv.ErrVacancyNotOwned, http.StatusForbidden, "vacancy_not_owned"},
+	{applicationSrv.ErrApplicationNotOwned, http.StatusForbidden, "application_not_owned"},
+	{applicationSrv.ErrResumeNotOwned, http.StatusForbidden, "resume_not_owned"},
+
+	// Нарушения ограничений БД, для которых репозиторий не вернул более точную ошибку
+	{pgerr.ErrForeignKeyViolation, http.StatusBadRequest, ErrorCodeInvalidReference},
+	{pgerr.ErrUniqueViolation, http.StatusConflict, ErrorCodeConflict},
+	{pgerr.ErrCheckViolation, http.StatusUnprocessableEntity, ErrorCodeValidationFailed},
+}
+
+// TranslateError возвращает HTTP статус и код ошибки API для ошибки сервисного слоя.
+// Неизвестные ошибки транслируются в 500 internal_error.
+func TranslateError(err error) (int, string) {
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.target) {
+			return mapping.status, mapping.code
+		}
+	}
+
+	return http.StatusInternalServerError, ErrorCodeInternal
+}
+
+// HandleServiceError отвечает клиенту ошибкой сервисного слоя с подходящим статусом и кодом.
+// Детали внутренних ошибок не отдаются клиенту, а пишутся в лог запроса.
+func (c *BaseController) HandleServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	status, code := TranslateError(err)
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		logger.FromContext(r.Context()).Error("Request failed", zap.Error(err))
+
+		message = internalErrorMessage
+	}
+
+	c.writeError(w, status, code, message, nil)
+}
+
+// defaultErrorCode возвращает общий код ошибки для HTTP статуса
+func defaultErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return ErrorCodeRequestTooLarge
+	case http.StatusUnprocessableEntity:
+		return ErrorCodeValidationFailed
+	default:
+		return ErrorCodeInternal
+	}
+}
@@ -0,0 +1,60 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/api/controllers"
+	"jobot/internal/repository/pgerr"
+	reactionRepo "jobot/internal/repository/reaction"
+	vacancyRepo "jobot/internal/repository/vacancy"
+	applicationSrv "jobot/internal/service/application"
+	vacancySrv "jobot/internal/service/vacancy"
+)
+
+func TestTranslateError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{fmt.Errorf("failed to get vacancy by ID: %w", vacancyRepo.ErrVacancyNotFound), http.StatusNotFound, "vacancy_not_found"},
+		{fmt.Errorf("failed to create reaction: %w", reactionRepo.ErrReactionAlreadyExists), http.StatusConflict, "reaction_already_exists"},
+		{fmt.Errorf("%w: offer → viewed by employer", applicationSrv.ErrTransitionNotAllowed), http.StatusUnprocessableEntity, "transition_not_allowed"},
+		{vacancySrv.ErrEmptySearchQuery, http.StatusUnprocessableEntity, "empty_search_query"},
+		{fmt.Errorf("failed to create reaction: %w", pgerr.Wrap(&pgconn.PgError{Code: pgerr.ForeignKeyViolationCode})), http.StatusBadRequest, ErrorCodeInvalidReference},
+		{errors.New("connection refused"), http.StatusInternalServerError, ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		status, code := TranslateError(tt.err)
+		assert.Equal(t, tt.status, status, tt.err.Error())
+		assert.Equal(t, tt.code, code, tt.err.Error())
+	}
+}
+
+func TestHandleServiceErrorHidesInternalErrors(t *testing.T) {
+	t.Parallel()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	(&BaseController{}).HandleServiceError(w, r, errors.New("dial tcp 10.0.0.1:5432: connection refused"))
+
+	var response ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, ErrorCodeInternal, response.ErrorCode)
+	assert.NotContains(t, response.Message, "10.0.0.1")
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"net/http"
+
+	"jobot/internal/api/converter"
+	"jobot/internal/service"
+	"jobot/pkg/logger"
+)
+
+type FeedController struct {
+	feedService   service.FeedService
+	accessService service.AccessService
+	BaseController
+}
+
+func NewFeedController(feedService service.FeedService, accessService service.AccessService) *FeedController {
+	return &FeedController{feedService: feedService, accessService: accessService}
+}
+
+func (c *FeedController) GetEmployeeFeed(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_employee_feed")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get employee feed request")
+
+	employeeUUID, err := c.GetUUIDFromPath(r, EmployeeIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	pagination, err := converter.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployee(ctx, employeeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	feed, err := c.feedService.GetEmployeeFeed(ctx, employeeUUID, pagination)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	meta := NewMeta(feed.Pagination.Page, feed.Pagination.PerPage, feed.Total)
+
+	c.JSONSuccess(w, converter.ServiceVacancyFeedToVacancyFeedResponse(feed), "", http.StatusOK, meta)
+
+	log.Info("Get employee feed request completed")
+}
+
+func (c *FeedController) GetVacancyCandidates(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_vacancy_candidates")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get vacancy candidates request")
+
+	vacancyUUID, err := c.GetUUIDFromPath(r, VacancyIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	pagination, err := converter.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckVacancy(ctx, vacancyUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	candidates, err := c.feedService.GetVacancyCandidates(ctx, vacancyUUID, pagination)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	meta := NewMeta(candidates.Pagination.Page, candidates.Pagination.PerPage, candidates.Total)
+
+	c.JSONSuccess(w, converter.ServiceCandidateListToCandidateListResponse(candidates), "", http.StatusOK, meta)
+
+	log.Info("Get vacancy candidates request completed")
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"net/http"
+
+	"jobot/internal/api/converter"
+	"jobot/internal/api/models"
+	"jobot/internal/service"
+	"jobot/pkg/logger"
+)
+
+// NotificationController - настройки уведомлений пользователя
+type NotificationController struct {
+	notificationService service.NotificationService
+	accessService       service.AccessService
+	BaseController
+}
+
+func NewNotificationController(notificationService service.NotificationService, accessService service.AccessService) *NotificationController {
+	return &NotificationController{notificationService: notificationService, accessService: accessService}
+}
+
+func (c *NotificationController) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_notification_preferences")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get notification preferences request")
+
+	userUUID, err := c.GetUUIDFromPath(r, UserIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckUser(ctx, userUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	preferences, err := c.notificationService.GetNotificationPreferences(ctx, userUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServicePreferencesToNotificationPreferencesResponse(preferences))
+
+	log.Info("Get notification preferences request completed")
+}
+
+func (c *NotificationController) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("update_notification_preferences")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start update notification preferences request")
+
+	userUUID, err := c.GetUUIDFromPath(r, UserIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	req := &models.NotificationPreferencesRequest{}
+
+	err = c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	err = c.accessService.CheckUser(ctx, userUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	preferences, err := c.notificationService.UpdateNotificationPreferences(ctx,
+		converter.NotificationPreferencesRequestToServicePreferences(req, userUUID))
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServicePreferencesToNotificationPreferencesResponse(preferences))
+
+	log.Info("Update notification preferences request completed")
+}
@@ -10,16 +10,22 @@ import (
 )
 
 const (
-	ReactionIDPathValue = "ReactionID"
+	ReactionIDPathValue         = "ReactionID"
+	EmployerReactionIDPathValue = "EmployerReactionID"
+
+	ReactionQueryValue   = "reaction"
+	DecisionQueryValue   = "decision"
+	VacansieIDQueryValue = "vacansie_id"
 )
 
 type ReactionController struct {
 	reactionService service.ReactionService
+	accessService   service.AccessService
 	BaseController
 }
 
-func NewReactionController(reactionService service.ReactionService) *ReactionController {
-	return &ReactionController{reactionService: reactionService}
+func NewReactionController(reactionService service.ReactionService, accessService service.AccessService) *ReactionController {
+	return &ReactionController{reactionService: reactionService, accessService: accessService}
 }
 
 func (c *ReactionController) CreateReaction(w http.ResponseWriter, r *http.Request) {
@@ -32,7 +38,7 @@ func (c *ReactionController) CreateReaction(w http.ResponseWriter, r *http.Reque
 
 	err := c.ReadRequestBody(r, req)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -44,17 +50,21 @@ func (c *ReactionController) CreateReaction(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	createdReaction, err := c.reactionService.CreateReaction(ctx, serviceReaction)
+	err = c.accessService.CheckEmployee(ctx, serviceReaction.EmployeeID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
 
-	response := converter.ServiceReactionToReactionResponse(createdReaction)
-	response.Reaction = req.Reaction
+	createdReaction, err := c.reactionService.CreateReaction(ctx, serviceReaction)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
 
-	c.JSONSimpleSuccess(w, http.StatusCreated, response)
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusCreated, converter.ServiceReactionToReactionResponse(createdReaction))
 
 	log.Info("Create reaction request completed")
 }
@@ -72,9 +82,16 @@ func (c *ReactionController) GetReaction(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = c.accessService.CheckReaction(ctx, reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	reaction, err := c.reactionService.GetReaction(ctx, reactionUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -97,9 +114,18 @@ func (c *ReactionController) GetEmployeeReactions(w http.ResponseWriter, r *http
 		return
 	}
 
-	reactionList, err := c.reactionService.GetEmployeeReactions(ctx, employeeUUID)
+	filter := converter.ReactionFilterFromQuery(r.URL.Query().Get(ReactionQueryValue))
+
+	err = c.accessService.CheckEmployee(ctx, employeeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	reactionList, err := c.reactionService.GetEmployeeReactions(ctx, employeeUUID, filter)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -109,6 +135,47 @@ func (c *ReactionController) GetEmployeeReactions(w http.ResponseWriter, r *http
 	log.Info("Get employee reactions request completed")
 }
 
+func (c *ReactionController) UpdateReaction(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("update_reaction")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start update reaction request")
+
+	reactionUUID, err := c.GetUUIDFromPath(r, ReactionIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	req := &models.ReactionUpdateRequest{}
+
+	err = c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	err = c.accessService.CheckReaction(ctx, reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	updatedReaction, err := c.reactionService.UpdateReaction(ctx, converter.ReactionUpdateRequestToServiceReactionUpdateRequest(req), reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceReactionToReactionResponse(updatedReaction))
+
+	log.Info("Update reaction request completed")
+}
+
 func (c *ReactionController) DeleteReaction(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromContext(r.Context()).Named("delete_reaction")
 	ctx := logger.ContextWithLogger(r.Context(), log)
@@ -122,9 +189,16 @@ func (c *ReactionController) DeleteReaction(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	err = c.accessService.CheckReaction(ctx, reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.reactionService.DeleteReaction(ctx, reactionUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -133,3 +207,304 @@ func (c *ReactionController) DeleteReaction(w http.ResponseWriter, r *http.Reque
 
 	log.Info("Delete reaction request completed")
 }
+
+func (c *ReactionController) GetVacancyReactions(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_vacancy_reactions")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get vacancy reactions request")
+
+	vacancyUUID, err := c.GetUUIDFromPath(r, VacancyIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter := converter.ReactionFilterFromQuery(r.URL.Query().Get(ReactionQueryValue))
+
+	err = c.accessService.CheckVacancy(ctx, vacancyUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	reactionList, err := c.reactionService.GetVacancyReactions(ctx, vacancyUUID, filter)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceVacancyReactionListToReactionVacancyListResponse(reactionList))
+
+	log.Info("Get vacancy reactions request completed")
+}
+
+func (c *ReactionController) CreateEmployerReaction(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("create_employer_reaction")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start create employer reaction request")
+
+	req := &models.EmployerReactionCreateRequest{}
+
+	err := c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	serviceReaction, err := converter.EmployerReactionCreateRequestToServiceEmployerReaction(req)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployer(ctx, serviceReaction.EmployerID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	createdReaction, err := c.reactionService.CreateEmployerReaction(ctx, serviceReaction)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusCreated, converter.ServiceEmployerReactionToEmployerReactionResponse(createdReaction))
+
+	log.Info("Create employer reaction request completed")
+}
+
+func (c *ReactionController) GetEmployerReaction(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_employer_reaction")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get employer reaction request")
+
+	reactionUUID, err := c.GetUUIDFromPath(r, EmployerReactionIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployerReaction(ctx, reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	reaction, err := c.reactionService.GetEmployerReaction(ctx, reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceEmployerReactionToEmployerReactionResponse(reaction))
+
+	log.Info("Get employer reaction request completed")
+}
+
+func (c *ReactionController) GetEmployerReactions(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_employer_reactions")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get employer reactions request")
+
+	employerUUID, err := c.GetUUIDFromPath(r, EmployerIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter, err := converter.EmployerReactionFilterFromQuery(r.URL.Query().Get(DecisionQueryValue), r.URL.Query().Get(VacansieIDQueryValue))
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployer(ctx, employerUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	reactionList, err := c.reactionService.GetEmployerReactions(ctx, employerUUID, filter)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceEmployerReactionListToEmployerReactionListResponse(reactionList))
+
+	log.Info("Get employer reactions request completed")
+}
+
+func (c *ReactionController) UpdateEmployerReaction(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("update_employer_reaction")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start update employer reaction request")
+
+	reactionUUID, err := c.GetUUIDFromPath(r, EmployerReactionIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	req := &models.EmployerReactionUpdateRequest{}
+
+	err = c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployerReaction(ctx, reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	updatedReaction, err := c.reactionService.UpdateEmployerReaction(ctx, converter.EmployerReactionUpdateRequestToServiceEmployerReactionUpdateRequest(req), reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceEmployerReactionToEmployerReactionResponse(updatedReaction))
+
+	log.Info("Update employer reaction request completed")
+}
+
+func (c *ReactionController) DeleteEmployerReaction(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("delete_employer_reaction")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start delete employer reaction request")
+
+	reactionUUID, err := c.GetUUIDFromPath(r, EmployerReactionIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployerReaction(ctx, reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	err = c.reactionService.DeleteEmployerReaction(ctx, reactionUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, nil)
+
+	log.Info("Delete employer reaction request completed")
+}
+
+func (c *ReactionController) GetEmployeeMatches(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_employee_matches")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get employee matches request")
+
+	employeeUUID, err := c.GetUUIDFromPath(r, EmployeeIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	pagination, err := converter.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployee(ctx, employeeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	matchList, err := c.reactionService.GetEmployeeMatches(ctx, employeeUUID, pagination)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	meta := NewMeta(matchList.Pagination.Page, matchList.Pagination.PerPage, matchList.Total)
+
+	c.JSONSuccess(w, converter.ServiceMatchListToMatchListResponse(matchList), "", http.StatusOK, meta)
+
+	log.Info("Get employee matches request completed")
+}
+
+func (c *ReactionController) GetEmployerMatches(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_employer_matches")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get employer matches request")
+
+	employerUUID, err := c.GetUUIDFromPath(r, EmployerIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	pagination, err := converter.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	err = c.accessService.CheckEmployer(ctx, employerUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	matchList, err := c.reactionService.GetEmployerMatches(ctx, employerUUID, pagination)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	meta := NewMeta(matchList.Pagination.Page, matchList.Pagination.PerPage, matchList.Total)
+
+	c.JSONSuccess(w, converter.ServiceMatchListToMatchListResponse(matchList), "", http.StatusOK, meta)
+
+	log.Info("Get employer matches request completed")
+}
@@ -15,11 +15,12 @@ const (
 
 type ResumeController struct {
 	resumeService service.ResumeService
+	accessService service.AccessService
 	BaseController
 }
 
-func NewResumeController(resumeService service.ResumeService) *ResumeController {
-	return &ResumeController{resumeService: resumeService}
+func NewResumeController(resumeService service.ResumeService, accessService service.AccessService) *ResumeController {
+	return &ResumeController{resumeService: resumeService, accessService: accessService}
 }
 
 func (c *ResumeController) CreateResume(w http.ResponseWriter, r *http.Request) {
@@ -32,7 +33,7 @@ func (c *ResumeController) CreateResume(w http.ResponseWriter, r *http.Request)
 
 	err := c.ReadRequestBody(r, req)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -44,9 +45,16 @@ func (c *ResumeController) CreateResume(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = c.accessService.CheckEmployee(ctx, serviceResume.EmployeeID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	createdResume, err := c.resumeService.CreateResume(ctx, serviceResume)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -69,9 +77,16 @@ func (c *ResumeController) GetResume(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = c.accessService.CheckResumeRead(ctx, resumeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	resume, err := c.resumeService.GetResume(ctx, resumeUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -94,9 +109,16 @@ func (c *ResumeController) GetResumeByEmployeeID(w http.ResponseWriter, r *http.
 		return
 	}
 
+	err = c.accessService.CheckCandidateResume(ctx, employeeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	resume, err := c.resumeService.GetResumeByEmployeeID(ctx, employeeUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -123,7 +145,7 @@ func (c *ResumeController) UpdateResume(w http.ResponseWriter, r *http.Request)
 
 	err = c.ReadRequestBody(r, resume)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -135,9 +157,16 @@ func (c *ResumeController) UpdateResume(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = c.accessService.CheckResume(ctx, resumeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.resumeService.UpdateResume(ctx, updateResume, resumeUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -160,9 +189,16 @@ func (c *ResumeController) DeleteResume(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = c.accessService.CheckResume(ctx, resumeUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.resumeService.DeleteResume(ctx, resumeUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
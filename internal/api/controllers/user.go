@@ -1,27 +1,29 @@
 package controllers
 
 import (
-	"errors"
 	"net/http"
 
 	"jobot/internal/api/converter"
 	"jobot/internal/api/models"
-	repo "jobot/internal/repository/user"
 	"jobot/internal/service"
+	serviceModels "jobot/internal/service/models"
 	"jobot/pkg/logger"
 )
 
 const (
-	UserIDPathValue = "UserID"
+	UserIDPathValue     = "UserID"
+	TgChatIDPathValue   = "ChatID"
+	TgUserNamePathValue = "UserName"
 )
 
 type UserController struct {
-	userService service.UserService
+	userService   service.UserService
+	accessService service.AccessService
 	BaseController
 }
 
-func NewUserController(userService service.UserService) *UserController {
-	return &UserController{userService: userService}
+func NewUserController(userService service.UserService, accessService service.AccessService) *UserController {
+	return &UserController{userService: userService, accessService: accessService}
 }
 
 func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -34,16 +36,23 @@ func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	err := c.ReadRequestBody(r, req)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
 
 	serviceUser := converter.UserCreateRequestToServiceUser(req)
 
+	err = c.accessService.RequireRole(ctx, serviceModels.UserRoleAdmin)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	createdUser, err := c.userService.CreateUser(ctx, serviceUser)
 	if err != nil {
-		c.handleUserServiceError(w, err)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -53,7 +62,6 @@ func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
 	log.Info("Create user request completed")
 }
 
-// TODO: Конвертер
 func (c *UserController) GetUserProfile(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromContext(r.Context()).Named("get_user_profile")
 	ctx := logger.ContextWithLogger(r.Context(), log)
@@ -67,18 +75,172 @@ func (c *UserController) GetUserProfile(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = c.accessService.CheckUser(ctx, userUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	profile, err := c.userService.GetUserProfile(ctx, userUUID)
 	if err != nil {
-		c.handleUserServiceError(w, err)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
 
-	c.JSONSimpleSuccess(w, http.StatusOK, profile)
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceUserProfileToUserProfileResponse(profile))
 
 	log.Info("Get user profile request completed")
 }
 
+// GetUserProfileByTgChatID возвращает полный профиль пользователя по Telegram chat ID
+func (c *UserController) GetUserProfileByTgChatID(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_user_profile_by_tg_chat_id")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get user profile by tg chat id request")
+
+	tgChatID := r.PathValue(TgChatIDPathValue)
+
+	err := c.accessService.CheckTelegramUser(ctx, tgChatID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	profile, err := c.userService.GetUserProfileByTgChatID(ctx, tgChatID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceUserProfileToUserProfileResponse(profile))
+
+	log.Info("Get user profile by tg chat id request completed")
+}
+
+// GetUserProfileByTgUserName возвращает полный профиль пользователя по Telegram username
+func (c *UserController) GetUserProfileByTgUserName(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("get_user_profile_by_tg_user_name")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start get user profile by tg user name request")
+
+	tgUserName := r.PathValue(TgUserNamePathValue)
+
+	profile, err := c.userService.GetUserProfileByTgUserName(ctx, tgUserName)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	// Username может записать себе любой пользователь, поэтому права проверяются по найденной записи
+	err = c.accessService.CheckUser(ctx, profile.User.ID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceUserProfileToUserProfileResponse(profile))
+
+	log.Info("Get user profile by tg user name request completed")
+}
+
+// Onboard регистрирует пользователя Telegram вместе с профилем роли.
+// Отвечает 201, если что-то было создано, и 200, если пользователь с профилем уже существовал.
+func (c *UserController) Onboard(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("onboard")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start onboard request")
+
+	req := &models.OnboardingRequest{}
+
+	err := c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	err = c.accessService.CheckTelegramUser(ctx, req.TgChatID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	profile, created, err := c.userService.Onboard(ctx, converter.OnboardingRequestToServiceUserProfile(req))
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	c.JSONSimpleSuccess(w, status, converter.ServiceUserProfileToUserProfileResponse(profile))
+
+	log.Info("Onboard request completed")
+}
+
+// ChangeUserRole меняет роль пользователя: профиль прежней роли архивируется, профиль новой создается или восстанавливается
+func (c *UserController) ChangeUserRole(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("change_user_role")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start change user role request")
+
+	userUUID, err := c.GetUUIDFromPath(r, UserIDPathValue)
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	req := &models.RoleChangeRequest{}
+
+	err = c.ReadRequestBody(r, req)
+	if err != nil {
+		c.HandleRequestBodyError(w, err)
+
+		return
+	}
+
+	err = c.accessService.CheckUser(ctx, userUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	err = c.accessService.CheckRoleChange(ctx, req.Role)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	profile, err := c.userService.ChangeRole(ctx, userUUID, converter.RoleChangeRequestToServiceRoleChangeRequest(req))
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceUserProfileToUserProfileResponse(profile))
+
+	log.Info("Change user role request completed")
+}
+
 func (c *UserController) GetUser(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromContext(r.Context()).Named("get_user")
 	ctx := logger.ContextWithLogger(r.Context(), log)
@@ -92,9 +254,16 @@ func (c *UserController) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = c.accessService.CheckUser(ctx, userUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	user, err := c.userService.GetUser(ctx, userUUID)
 	if err != nil {
-		c.handleUserServiceError(w, err)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -121,7 +290,7 @@ func (c *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	err = c.ReadRequestBody(r, user)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -133,9 +302,36 @@ func (c *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = c.accessService.CheckUser(ctx, userUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	// Telegram ID, активность и премиум меняет только администратор: иначе пользователь мог бы выдать себе
+	// премиум, перенести аккаунт на чужой Telegram ID или снять блокировку
+	if updateUser.TgChatID != nil || updateUser.IsActive != nil || updateUser.IsPremium != nil {
+		err = c.accessService.RequireRole(ctx, serviceModels.UserRoleAdmin)
+		if err != nil {
+			c.HandleServiceError(w, r, err)
+
+			return
+		}
+	}
+
+	if updateUser.Role != nil {
+		err = c.accessService.CheckRoleChange(ctx, *updateUser.Role)
+		if err != nil {
+			c.HandleServiceError(w, r, err)
+
+			return
+		}
+	}
+
 	err = c.userService.UpdateUser(ctx, updateUser, userUUID)
 	if err != nil {
-		c.handleUserServiceError(w, err)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -158,9 +354,16 @@ func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = c.accessService.CheckUser(ctx, userUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.userService.DeleteUser(ctx, userUUID)
 	if err != nil {
-		c.handleUserServiceError(w, err)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -169,14 +372,3 @@ func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 	log.Info("Delete user request completed")
 }
-
-func (c *UserController) handleUserServiceError(w http.ResponseWriter, err error) {
-	switch {
-	case errors.Is(err, repo.ErrUserNotFound):
-		c.JSONSimpleError(w, err.Error(), http.StatusNotFound)
-	case errors.Is(err, repo.ErrUserAlreadyExists):
-		c.JSONSimpleError(w, err.Error(), http.StatusConflict)
-	default:
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
-	}
-}
@@ -0,0 +1,112 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	. "jobot/internal/api/controllers"
+	userRepo "jobot/internal/repository/user"
+	"jobot/internal/service"
+	accessSrv "jobot/internal/service/access"
+	"jobot/internal/service/auth"
+	"jobot/internal/service/models"
+)
+
+type userServiceStub struct {
+	service.UserService
+	profiles map[string]*models.UserProfile
+	updates  []*models.UserUpdateRequest
+	err      error
+}
+
+func (s *userServiceStub) UpdateUser(_ context.Context, req *models.UserUpdateRequest, _ uuid.UUID) error {
+	s.updates = append(s.updates, req)
+
+	return nil
+}
+
+func (s *userServiceStub) GetUserProfileByTgUserName(_ context.Context, tgUserName string) (*models.UserProfile, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	profile, ok := s.profiles[tgUserName]
+	if !ok {
+		return nil, userRepo.ErrUserNotFound
+	}
+
+	return profile, nil
+}
+
+func TestGetUserProfileByTgUserName(t *testing.T) {
+	t.Parallel()
+
+	victim := &models.User{ID: uuid.New(), TgUserName: "alice", Role: models.UserRoleEmployee, IsActive: true}
+	users := &userServiceStub{profiles: map[string]*models.UserProfile{"alice": {User: victim}}}
+
+	get := func(user *models.User, users *userServiceStub) int {
+		controller := NewUserController(users, accessSrv.NewAccessService(nil, nil, nil, nil, nil, nil, nil))
+
+		r := httptest.NewRequest(http.MethodGet, "/api/users/by-username/alice", nil)
+		r.SetPathValue(TgUserNamePathValue, "alice")
+		r = r.WithContext(auth.ContextWithUser(r.Context(), user))
+		w := httptest.NewRecorder()
+
+		controller.GetUserProfileByTgUserName(w, r)
+
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get(victim, users))
+
+	// Пользователь записал себе чужой username: права проверяются по найденной записи
+	attacker := &models.User{ID: uuid.New(), TgUserName: "alice", Role: models.UserRoleEmployee, IsActive: true}
+	assert.Equal(t, http.StatusForbidden, get(attacker, users))
+
+	assert.Equal(t, http.StatusConflict, get(victim, &userServiceStub{err: userRepo.ErrUserNameAmbiguous}))
+}
+
+func TestUpdateUserProtectedFields(t *testing.T) {
+	t.Parallel()
+
+	owner := &models.User{ID: uuid.New(), Role: models.UserRoleEmployee, IsActive: true}
+	admin := &models.User{ID: uuid.New(), Role: models.UserRoleAdmin, IsActive: true}
+
+	tests := []struct {
+		name string
+		user *models.User
+		body string
+		code int
+	}{
+		{name: "owner changes username", user: owner, body: `{"tg_user_name":"bob"}`, code: http.StatusOK},
+		{name: "owner grants premium", user: owner, body: `{"is_premium":true}`, code: http.StatusForbidden},
+		{name: "owner moves to another chat", user: owner, body: `{"tg_chat_id":"100"}`, code: http.StatusForbidden},
+		{name: "owner reactivates", user: owner, body: `{"is_active":true}`, code: http.StatusForbidden},
+		{name: "admin grants premium", user: admin, body: `{"is_premium":true}`, code: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			users := &userServiceStub{}
+			controller := NewUserController(users, accessSrv.NewAccessService(nil, nil, nil, nil, nil, nil, nil))
+
+			r := httptest.NewRequest(http.MethodPut, "/api/users/"+owner.ID.String(), strings.NewReader(tt.body))
+			r.SetPathValue(UserIDPathValue, owner.ID.String())
+			r = r.WithContext(auth.ContextWithUser(r.Context(), tt.user))
+			w := httptest.NewRecorder()
+
+			controller.UpdateUser(w, r)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, tt.code == http.StatusOK, len(users.updates) == 1)
+		})
+	}
+}
@@ -15,11 +15,12 @@ const (
 
 type VacancyController struct {
 	vacancyService service.VacancyService
+	accessService  service.AccessService
 	BaseController
 }
 
-func NewVacancyController(vacancyService service.VacancyService) *VacancyController {
-	return &VacancyController{vacancyService: vacancyService}
+func NewVacancyController(vacancyService service.VacancyService, accessService service.AccessService) *VacancyController {
+	return &VacancyController{vacancyService: vacancyService, accessService: accessService}
 }
 
 func (c *VacancyController) CreateVacancy(w http.ResponseWriter, r *http.Request) {
@@ -32,7 +33,7 @@ func (c *VacancyController) CreateVacancy(w http.ResponseWriter, r *http.Request
 
 	err := c.ReadRequestBody(r, req)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -44,9 +45,16 @@ func (c *VacancyController) CreateVacancy(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	err = c.accessService.CheckEmployer(ctx, serviceVacancy.EmployerID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	createdVacancy, err := c.vacancyService.CreateVacancy(ctx, serviceVacancy)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -71,7 +79,7 @@ func (c *VacancyController) GetVacancy(w http.ResponseWriter, r *http.Request) {
 
 	vacancy, err := c.vacancyService.GetVacancyByID(ctx, vacancyUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -87,18 +95,68 @@ func (c *VacancyController) GetVacancyList(w http.ResponseWriter, r *http.Reques
 
 	log.Info("Start get vacancy list request")
 
-	vacancyList, err := c.vacancyService.GetVacancyList(ctx)
+	pagination, err := converter.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter, err := converter.VacancyListQueryToServiceVacancyFilter(r.URL.Query())
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
 
 		return
 	}
 
-	c.JSONSimpleSuccess(w, http.StatusOK, converter.ServiceVacancyListToVacancyListResponse(vacancyList))
+	vacancyList, err := c.vacancyService.GetVacancyList(ctx, filter, pagination)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	meta := NewMeta(vacancyList.Pagination.Page, vacancyList.Pagination.PerPage, vacancyList.Total)
+
+	c.JSONSuccess(w, converter.ServiceVacancyListToVacancyListResponse(vacancyList), "", http.StatusOK, meta)
 
 	log.Info("Get vacancy list request completed")
 }
 
+func (c *VacancyController) SearchVacancies(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).Named("search_vacancies")
+	ctx := logger.ContextWithLogger(r.Context(), log)
+
+	log.Info("Start search vacancies request")
+
+	pagination, err := converter.PaginationFromQuery(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	filter, err := converter.VacancyListQueryToServiceVacancyFilter(r.URL.Query())
+	if err != nil {
+		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	result, err := c.vacancyService.SearchVacancies(ctx, r.URL.Query().Get(converter.SearchQueryValue), filter, pagination)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
+	meta := NewMeta(result.Pagination.Page, result.Pagination.PerPage, result.Total)
+
+	c.JSONSuccess(w, converter.ServiceVacancySearchResultToVacancySearchResponse(result), "", http.StatusOK, meta)
+
+	log.Info("Search vacancies request completed")
+}
+
 func (c *VacancyController) GetEmployerVacancies(w http.ResponseWriter, r *http.Request) {
 	log := logger.FromContext(r.Context()).Named("get_employer_vacancies")
 	ctx := logger.ContextWithLogger(r.Context(), log)
@@ -114,7 +172,7 @@ func (c *VacancyController) GetEmployerVacancies(w http.ResponseWriter, r *http.
 
 	vacancyList, err := c.vacancyService.GetEmployerVacancies(ctx, employerUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -141,7 +199,7 @@ func (c *VacancyController) UpdateVacancy(w http.ResponseWriter, r *http.Request
 
 	err = c.ReadRequestBody(r, vacancy)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusBadRequest)
+		c.HandleRequestBodyError(w, err)
 
 		return
 	}
@@ -153,9 +211,16 @@ func (c *VacancyController) UpdateVacancy(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	err = c.accessService.CheckVacancy(ctx, vacancyUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.vacancyService.UpdateVacancy(ctx, updateVacancy, vacancyUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -178,9 +243,16 @@ func (c *VacancyController) DeleteVacancy(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	err = c.accessService.CheckVacancy(ctx, vacancyUUID)
+	if err != nil {
+		c.HandleServiceError(w, r, err)
+
+		return
+	}
+
 	err = c.vacancyService.DeleteVacancy(ctx, vacancyUUID)
 	if err != nil {
-		c.JSONSimpleError(w, err.Error(), http.StatusInternalServerError)
+		c.HandleServiceError(w, r, err)
 
 		return
 	}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxRequestBodyBytes - максимальный размер тела запроса
+const MaxRequestBodyBytes = 1 << 20
+
+// ErrorCodeRequestTooLarge - код ошибки для тела запроса больше MaxRequestBodyBytes
+const ErrorCodeRequestTooLarge = "request_too_large"
+
+// validate проверяет DTO по тегам validate, в ошибках используются имена полей из тегов json
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
+	return v
+}
+
+// FieldError - ошибка валидации поля запроса, передается в ErrorResponse.Details
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationError - тело запроса не прошло проверку тегов validate
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		fields = append(fields, field.Field)
+	}
+
+	return "invalid fields: " + strings.Join(fields, ", ")
+}
+
+// ReadRequestBody декодирует JSON тело запроса в v и проверяет его по тегам validate.
+// Неизвестные поля и данные после JSON объекта считаются ошибкой.
+func (c *BaseController) ReadRequestBody(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, MaxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return errors.New("request body must contain a single JSON object")
+	}
+
+	return validateStruct(v)
+}
+
+// HandleRequestBodyError отвечает клиенту ошибкой чтения тела запроса:
+// 422 с ошибками полей в Details, 413 для слишком большого тела и 400 для некорректного JSON
+func (c *BaseController) HandleRequestBodyError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		c.writeError(w, http.StatusUnprocessableEntity, ErrorCodeValidationFailed, err.Error(), validationErr.Fields)
+
+		return
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		message := fmt.Sprintf("request body must not exceed %d bytes", maxBytesErr.Limit)
+		c.writeError(w, http.StatusRequestEntityTooLarge, ErrorCodeRequestTooLarge, message, nil)
+
+		return
+	}
+
+	c.writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, err.Error(), nil)
+}
+
+func validateStruct(v interface{}) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, FieldError{
+			Field:   fieldPath(fieldErr),
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: fieldMessage(fieldErr),
+		})
+	}
+
+	return &ValidationError{Fields: fields}
+}
+
+// fieldPath возвращает путь к полю без имени корневой структуры, например tags[0]
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+
+	return path
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required", "required_if":
+		return "field is required"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "uuid":
+		return "must be a valid UUID"
+	case "max":
+		return "must not be longer than " + fieldErr.Param()
+	default:
+		return fmt.Sprintf("failed on the %q rule", fieldErr.Tag())
+	}
+}
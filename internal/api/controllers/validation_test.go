@@ -0,0 +1,93 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/api/controllers"
+	"jobot/internal/api/models"
+)
+
+func readBody(t *testing.T, body string, v interface{}) (*httptest.ResponseRecorder, ErrorResponse) {
+	t.Helper()
+
+	c := &BaseController{}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+
+	err := c.ReadRequestBody(r, v)
+	require.Error(t, err)
+
+	c.HandleRequestBodyError(w, err)
+
+	var response ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+
+	return w, response
+}
+
+func TestReadRequestBodyValidatesTags(t *testing.T) {
+	t.Parallel()
+
+	w, response := readBody(t, `{"employee_id":"not-a-uuid","reaction":"love"}`, &models.ReactionCreateRequest{})
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, ErrorCodeValidationFailed, response.ErrorCode)
+
+	details, ok := response.Details.([]interface{})
+	require.True(t, ok)
+
+	rules := make(map[string]string)
+	for _, detail := range details {
+		field := detail.(map[string]interface{})
+		rules[field["field"].(string)] = field["rule"].(string)
+	}
+
+	assert.Equal(t, map[string]string{
+		"employee_id": "uuid",
+		"vacansie_id": "required",
+		"reaction":    "oneof",
+	}, rules)
+}
+
+func TestReadRequestBodyRejectsMalformedPayloads(t *testing.T) {
+	t.Parallel()
+
+	for _, body := range []string{
+		`{"reaction":"like","extra":true}`,
+		`{"reaction":"like"}{"reaction":"dislike"}`,
+		`{"reaction":`,
+	} {
+		w, response := readBody(t, body, &models.ReactionUpdateRequest{})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		assert.Equal(t, ErrorCodeBadRequest, response.ErrorCode, body)
+	}
+}
+
+func TestReadRequestBodyCapsSize(t *testing.T) {
+	t.Parallel()
+
+	body := `{"tg_file_id":"` + strings.Repeat("a", MaxRequestBodyBytes) + `"}`
+
+	w, response := readBody(t, body, &models.ResumeUpdateRequest{})
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, ErrorCodeRequestTooLarge, response.ErrorCode)
+}
+
+func TestReadRequestBodyAcceptsValidPayload(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"tg_user_name":"bob","tg_chat_id":"42","is_active":false,"is_premium":false,"role":"employee"}`))
+
+	req := &models.UserCreateRequest{}
+	require.NoError(t, (&BaseController{}).ReadRequestBody(r, req))
+	assert.Equal(t, "42", req.TgChatID)
+}
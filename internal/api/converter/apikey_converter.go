@@ -0,0 +1,62 @@
+package converter
+
+import (
+	apiModels "jobot/internal/api/models"
+	serviceModels "jobot/internal/service/models"
+)
+
+// API → Service конвертеры
+
+// APIKeyCreateRequestToServiceAPIKey конвертирует API запрос в сервисную модель APIKey
+func APIKeyCreateRequestToServiceAPIKey(req *apiModels.APIKeyCreateRequest) *serviceModels.APIKey {
+	scopes := req.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return &serviceModels.APIKey{
+		Name:   req.Name,
+		Scopes: scopes,
+	}
+}
+
+// Service → API конвертеры
+
+// ServiceAPIKeyToAPIKeyResponse конвертирует сервисную модель в API ответ
+func ServiceAPIKeyToAPIKeyResponse(key *serviceModels.APIKey) *apiModels.APIKeyResponse {
+	response := &apiModels.APIKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+		UpdatedAt:  key.UpdatedAt,
+	}
+
+	if key.CreatedBy != nil {
+		createdBy := key.CreatedBy.String()
+		response.CreatedBy = &createdBy
+	}
+
+	return response
+}
+
+// ServiceAPIKeysToAPIKeyResponses конвертирует список сервисных моделей в API ответ
+func ServiceAPIKeysToAPIKeyResponses(keys []serviceModels.APIKey) []apiModels.APIKeyResponse {
+	responses := make([]apiModels.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		responses = append(responses, *ServiceAPIKeyToAPIKeyResponse(&keys[i]))
+	}
+
+	return responses
+}
+
+// ServiceIssuedAPIKeyToIssuedAPIKeyResponse конвертирует выпущенный ключ в API ответ
+func ServiceIssuedAPIKeyToIssuedAPIKeyResponse(issued *serviceModels.IssuedAPIKey) *apiModels.IssuedAPIKeyResponse {
+	return &apiModels.IssuedAPIKeyResponse{
+		APIKeyResponse: *ServiceAPIKeyToAPIKeyResponse(issued.APIKey),
+		Key:            issued.Key,
+	}
+}
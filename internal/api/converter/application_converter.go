@@ -0,0 +1,120 @@
+package converter
+
+import (
+	apiModels "jobot/internal/api/models"
+	serviceModels "jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+// API → Service конвертеры
+
+// ApplicationCreateRequestToServiceApplication конвертирует API запрос отклика в сервисную модель
+func ApplicationCreateRequestToServiceApplication(req *apiModels.ApplicationCreateRequest) (*serviceModels.Application, error) {
+	employeeID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	vacancyID, err := uuid.Parse(req.VacansieID)
+	if err != nil {
+		return nil, err
+	}
+
+	application := &serviceModels.Application{
+		EmployeeID: employeeID,
+		VacancyID:  vacancyID,
+	}
+
+	if req.ResumeID != nil {
+		resumeID, err := uuid.Parse(*req.ResumeID)
+		if err != nil {
+			return nil, err
+		}
+
+		application.ResumeID = &resumeID
+	}
+
+	return application, nil
+}
+
+// ApplicationTransitionRequestToServiceApplicationTransitionRequest конвертирует API запрос смены статуса в сервисную модель
+func ApplicationTransitionRequestToServiceApplicationTransitionRequest(req *apiModels.ApplicationTransitionRequest) (*serviceModels.ApplicationTransitionRequest, error) {
+	actorID, err := uuid.Parse(req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceModels.ApplicationTransitionRequest{
+		Status:  req.Status,
+		Actor:   req.Actor,
+		ActorID: actorID,
+		Comment: req.Comment,
+	}, nil
+}
+
+// ApplicationFilterFromQuery собирает фильтр заявок из query-параметров status и vacansie_id
+func ApplicationFilterFromQuery(status, vacancyID string) (*serviceModels.ApplicationFilter, error) {
+	filter := &serviceModels.ApplicationFilter{}
+
+	if status != "" {
+		filter.Status = &status
+	}
+
+	if vacancyID != "" {
+		id, err := uuid.Parse(vacancyID)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.VacancyID = &id
+	}
+
+	return filter, nil
+}
+
+// Service → API конвертеры
+
+// ServiceApplicationToApplicationResponse конвертирует заявку в API ответ
+func ServiceApplicationToApplicationResponse(application *serviceModels.Application) *apiModels.ApplicationResponse {
+	var resumeID *string
+	if application.ResumeID != nil {
+		id := application.ResumeID.String()
+		resumeID = &id
+	}
+
+	history := make([]apiModels.ApplicationTransitionResponse, 0, len(application.History))
+	for _, transition := range application.History {
+		history = append(history, apiModels.ApplicationTransitionResponse{
+			TransitionID: transition.ID.String(),
+			FromStatus:   transition.FromStatus,
+			ToStatus:     transition.ToStatus,
+			Actor:        transition.Actor,
+			Comment:      transition.Comment,
+			CreatedAt:    transition.CreatedAt,
+		})
+	}
+
+	return &apiModels.ApplicationResponse{
+		ApplicationID: application.ID.String(),
+		EmployeeID:    application.EmployeeID.String(),
+		VacansieID:    application.VacancyID.String(),
+		ResumeID:      resumeID,
+		Status:        application.Status,
+		CreatedAt:     application.CreatedAt,
+		UpdatedAt:     application.UpdatedAt,
+		History:       history,
+	}
+}
+
+// ServiceApplicationListToApplicationListResponse конвертирует страницу заявок в API ответ
+func ServiceApplicationListToApplicationListResponse(applicationList *serviceModels.ApplicationList) *apiModels.ApplicationListResponse {
+	applications := make([]apiModels.ApplicationResponse, 0, len(applicationList.Applications))
+	for _, application := range applicationList.Applications {
+		applications = append(applications, *ServiceApplicationToApplicationResponse(&application))
+	}
+
+	return &apiModels.ApplicationListResponse{
+		Applications: applications,
+	}
+}
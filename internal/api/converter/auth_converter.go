@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"strconv"
+
+	apiModels "jobot/internal/api/models"
+	serviceModels "jobot/internal/service/models"
+)
+
+// tokenTypeBearer - тип токена в ответе, клиент передает его в заголовке Authorization
+const tokenTypeBearer = "Bearer"
+
+// API → Service конвертеры
+
+// TelegramWidgetLoginRequestToAuthData конвертирует запрос входа через виджет в набор полей,
+// подписанных Telegram. Пустые поля виджет не передает, поэтому они не попадают в строку проверки.
+func TelegramWidgetLoginRequestToAuthData(req *apiModels.TelegramWidgetLoginRequest) map[string]string {
+	data := map[string]string{
+		"id":        strconv.FormatInt(req.ID, 10),
+		"auth_date": strconv.FormatInt(req.AuthDate, 10),
+		"hash":      req.Hash,
+	}
+
+	optional := map[string]string{
+		"first_name": req.FirstName,
+		"last_name":  req.LastName,
+		"username":   req.UserName,
+		"photo_url":  req.PhotoURL,
+	}
+	for key, value := range optional {
+		if value != "" {
+			data[key] = value
+		}
+	}
+
+	return data
+}
+
+// Service → API конвертеры
+
+// ServiceAuthResultToAuthResponse конвертирует результат входа в API ответ
+func ServiceAuthResultToAuthResponse(result *serviceModels.AuthResult) *apiModels.AuthResponse {
+	return &apiModels.AuthResponse{
+		AccessToken:      result.Tokens.AccessToken,
+		RefreshToken:     result.Tokens.RefreshToken,
+		TokenType:        tokenTypeBearer,
+		AccessExpiresAt:  result.Tokens.AccessExpiresAt,
+		RefreshExpiresAt: result.Tokens.RefreshExpiresAt,
+		User:             *ServiceUserToUserResponse(result.User),
+		Created:          result.Created,
+	}
+}
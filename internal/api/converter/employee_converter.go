@@ -43,5 +43,6 @@ func ServiceEmployeeToEmployeeResponse(employee *serviceModels.Employee) *apiMod
 		Tags:       employee.Tags,
 		CreatedAt:  employee.CreatedAt,
 		UpdatedAt:  employee.UpdatedAt,
+		ArchivedAt: employee.ArchivedAt,
 	}
 }
@@ -67,5 +67,6 @@ func ServiceEmployerToEmployerResponse(employer *serviceModels.Employer) *apiMod
 		CompanySize:        employer.CompanySize,
 		CreatedAt:          employer.CreatedAt,
 		UpdatedAt:          employer.UpdatedAt,
+		ArchivedAt:         employer.ArchivedAt,
 	}
 }
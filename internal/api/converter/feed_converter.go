@@ -0,0 +1,49 @@
+package converter
+
+import (
+	apiModels "jobot/internal/api/models"
+	serviceModels "jobot/internal/service/models"
+)
+
+// Service → API конвертеры
+
+// ServiceVacancyFeedToVacancyFeedResponse конвертирует ленту рекомендаций сотрудника в API ответ
+func ServiceVacancyFeedToVacancyFeedResponse(feed *serviceModels.VacancyFeed) *apiModels.VacansieFeedResponse {
+	items := make([]apiModels.VacansieFeedItemResponse, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		items = append(items, apiModels.VacansieFeedItemResponse{
+			VacansieResponse: *ServiceVacancyToVacancyResponse(&item.Vacancy),
+			MatchedTags:      item.MatchedTags,
+			Score:            item.Score,
+		})
+	}
+
+	return &apiModels.VacansieFeedResponse{
+		EmployeeID: feed.EmployeeID.String(),
+		Vacansies:  items,
+	}
+}
+
+// ServiceCandidateListToCandidateListResponse конвертирует список кандидатов на вакансию в API ответ
+func ServiceCandidateListToCandidateListResponse(candidateList *serviceModels.CandidateList) *apiModels.CandidateListResponse {
+	candidates := make([]apiModels.CandidateResponse, 0, len(candidateList.Candidates))
+	for _, candidate := range candidateList.Candidates {
+		response := apiModels.CandidateResponse{
+			EmployeeResponse: *ServiceEmployeeToEmployeeResponse(&candidate.Employee),
+			MatchedTags:      candidate.MatchedTags,
+			HasResume:        candidate.HasResume,
+		}
+
+		if candidate.ResumeID != nil {
+			resumeID := candidate.ResumeID.String()
+			response.ResumeID = &resumeID
+		}
+
+		candidates = append(candidates, response)
+	}
+
+	return &apiModels.CandidateListResponse{
+		VacansieID: candidateList.VacancyID.String(),
+		Candidates: candidates,
+	}
+}
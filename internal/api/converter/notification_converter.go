@@ -0,0 +1,41 @@
+package converter
+
+import (
+	apiModels "jobot/internal/api/models"
+	serviceModels "jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+// API → Service конвертеры
+
+// NotificationPreferencesRequestToServicePreferences конвертирует API запрос в настройки уведомлений пользователя
+func NotificationPreferencesRequestToServicePreferences(req *apiModels.NotificationPreferencesRequest, userID uuid.UUID) *serviceModels.NotificationPreferences {
+	candidateLikes := true
+	if req.CandidateLikes != nil {
+		candidateLikes = *req.CandidateLikes
+	}
+
+	return &serviceModels.NotificationPreferences{
+		UserID:          userID,
+		NewVacancies:    *req.NewVacancies,
+		CandidateLikes:  candidateLikes,
+		QuietHoursStart: req.QuietHoursStart,
+		QuietHoursEnd:   req.QuietHoursEnd,
+		Timezone:        req.Timezone,
+	}
+}
+
+// Service → API конвертеры
+
+// ServicePreferencesToNotificationPreferencesResponse конвертирует настройки уведомлений в API ответ
+func ServicePreferencesToNotificationPreferencesResponse(preferences *serviceModels.NotificationPreferences) *apiModels.NotificationPreferencesResponse {
+	return &apiModels.NotificationPreferencesResponse{
+		UserID:          preferences.UserID.String(),
+		NewVacancies:    preferences.NewVacancies,
+		CandidateLikes:  preferences.CandidateLikes,
+		QuietHoursStart: preferences.QuietHoursStart,
+		QuietHoursEnd:   preferences.QuietHoursEnd,
+		Timezone:        preferences.Timezone,
+	}
+}
@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	serviceModels "jobot/internal/service/models"
+)
+
+const (
+	PageQueryValue    = "page"
+	PerPageQueryValue = "per_page"
+)
+
+// PaginationFromQuery собирает параметры пагинации из query-параметров page и per_page
+func PaginationFromQuery(query url.Values) (serviceModels.Pagination, error) {
+	pagination := serviceModels.Pagination{
+		Page:    serviceModels.DefaultPage,
+		PerPage: serviceModels.DefaultPerPage,
+	}
+
+	if value := query.Get(PageQueryValue); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return pagination, fmt.Errorf("invalid %s: must be a positive integer", PageQueryValue)
+		}
+
+		pagination.Page = page
+	}
+
+	if value := query.Get(PerPageQueryValue); value != "" {
+		perPage, err := strconv.Atoi(value)
+		if err != nil || perPage < 1 || perPage > serviceModels.MaxPerPage {
+			return pagination, fmt.Errorf("invalid %s: must be between 1 and %d", PerPageQueryValue, serviceModels.MaxPerPage)
+		}
+
+		pagination.PerPage = perPage
+	}
+
+	return pagination, nil
+}
@@ -24,9 +24,80 @@ func ReactionCreateRequestToServiceReaction(req *apiModels.ReactionCreateRequest
 	return &serviceModels.Reaction{
 		EmployeeID: employeeID,
 		VacancyID:  vacancyID,
+		Type:       req.Reaction,
 	}, nil
 }
 
+// ReactionUpdateRequestToServiceReactionUpdateRequest конвертирует API запрос обновления в сервисную модель
+func ReactionUpdateRequestToServiceReactionUpdateRequest(req *apiModels.ReactionUpdateRequest) *serviceModels.ReactionUpdateRequest {
+	return &serviceModels.ReactionUpdateRequest{
+		Type: &req.Reaction,
+	}
+}
+
+// EmployerReactionCreateRequestToServiceEmployerReaction конвертирует API запрос в сервисную модель EmployerReaction
+func EmployerReactionCreateRequestToServiceEmployerReaction(req *apiModels.EmployerReactionCreateRequest) (*serviceModels.EmployerReaction, error) {
+	employerID, err := uuid.Parse(req.EmployerID)
+	if err != nil {
+		return nil, err
+	}
+
+	employeeID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	vacancyID, err := uuid.Parse(req.VacansieID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serviceModels.EmployerReaction{
+		EmployerID: employerID,
+		EmployeeID: employeeID,
+		VacancyID:  vacancyID,
+		Decision:   req.Decision,
+	}, nil
+}
+
+// EmployerReactionUpdateRequestToServiceEmployerReactionUpdateRequest конвертирует API запрос обновления в сервисную модель
+func EmployerReactionUpdateRequestToServiceEmployerReactionUpdateRequest(req *apiModels.EmployerReactionUpdateRequest) *serviceModels.EmployerReactionUpdateRequest {
+	return &serviceModels.EmployerReactionUpdateRequest{
+		Decision: &req.Decision,
+	}
+}
+
+// EmployerReactionFilterFromQuery собирает фильтр решений работодателя из query-параметров decision и vacansie_id
+func EmployerReactionFilterFromQuery(decision, vacancyID string) (*serviceModels.EmployerReactionFilter, error) {
+	filter := &serviceModels.EmployerReactionFilter{}
+
+	if decision != "" {
+		filter.Decision = &decision
+	}
+
+	if vacancyID != "" {
+		id, err := uuid.Parse(vacancyID)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.VacancyID = &id
+	}
+
+	return filter, nil
+}
+
+// ReactionFilterFromQuery собирает фильтр реакций из query-параметра reaction
+func ReactionFilterFromQuery(reaction string) *serviceModels.ReactionFilter {
+	filter := &serviceModels.ReactionFilter{}
+
+	if reaction != "" {
+		filter.Type = &reaction
+	}
+
+	return filter
+}
+
 // Service → API конвертеры
 
 // ServiceReactionToReactionResponse конвертирует сервисную модель в API ответ
@@ -35,19 +106,82 @@ func ServiceReactionToReactionResponse(reaction *serviceModels.Reaction) *apiMod
 		ReactionID: reaction.ID.String(),
 		EmployeeID: reaction.EmployeeID.String(),
 		VacansieID: reaction.VacancyID.String(),
+		Reaction:   reaction.Type,
 		CreatedAt:  reaction.CreatedAt,
+		UpdatedAt:  reaction.UpdatedAt,
 	}
 }
 
 // ServiceEmployeeReactionListToReactionEmployeeListResponse конвертирует список реакций сотрудника в API ответ
 func ServiceEmployeeReactionListToReactionEmployeeListResponse(reactionList *serviceModels.EmployeeReactionList) *apiModels.ReactionEmployeeListResponse {
 	reactionIDs := make([]string, 0, len(reactionList.Reactions))
+	reactions := make([]apiModels.ReactionResponse, 0, len(reactionList.Reactions))
 	for _, reaction := range reactionList.Reactions {
 		reactionIDs = append(reactionIDs, reaction.ID.String())
+		reactions = append(reactions, *ServiceReactionToReactionResponse(&reaction))
 	}
 
 	return &apiModels.ReactionEmployeeListResponse{
 		ReactionsIDs: reactionIDs,
+		Reactions:    reactions,
 		EmployeeID:   reactionList.EmployeeID.String(),
 	}
 }
+
+// ServiceVacancyReactionListToReactionVacancyListResponse конвертирует список реакций на вакансию в API ответ
+func ServiceVacancyReactionListToReactionVacancyListResponse(reactionList *serviceModels.VacancyReactionList) *apiModels.ReactionVacancyListResponse {
+	reactions := make([]apiModels.ReactionResponse, 0, len(reactionList.Reactions))
+	for _, reaction := range reactionList.Reactions {
+		reactions = append(reactions, *ServiceReactionToReactionResponse(&reaction))
+	}
+
+	return &apiModels.ReactionVacancyListResponse{
+		VacansieID: reactionList.VacancyID.String(),
+		Reactions:  reactions,
+	}
+}
+
+// ServiceEmployerReactionToEmployerReactionResponse конвертирует решение работодателя в API ответ
+func ServiceEmployerReactionToEmployerReactionResponse(reaction *serviceModels.EmployerReaction) *apiModels.EmployerReactionResponse {
+	return &apiModels.EmployerReactionResponse{
+		EmployerReactionID: reaction.ID.String(),
+		EmployerID:         reaction.EmployerID.String(),
+		EmployeeID:         reaction.EmployeeID.String(),
+		VacansieID:         reaction.VacancyID.String(),
+		Decision:           reaction.Decision,
+		Matched:            reaction.Matched,
+		CreatedAt:          reaction.CreatedAt,
+		UpdatedAt:          reaction.UpdatedAt,
+	}
+}
+
+// ServiceEmployerReactionListToEmployerReactionListResponse конвертирует список решений работодателя в API ответ
+func ServiceEmployerReactionListToEmployerReactionListResponse(reactionList *serviceModels.EmployerReactionList) *apiModels.EmployerReactionListResponse {
+	reactions := make([]apiModels.EmployerReactionResponse, 0, len(reactionList.Reactions))
+	for _, reaction := range reactionList.Reactions {
+		reactions = append(reactions, *ServiceEmployerReactionToEmployerReactionResponse(&reaction))
+	}
+
+	return &apiModels.EmployerReactionListResponse{
+		EmployerID: reactionList.EmployerID.String(),
+		Reactions:  reactions,
+	}
+}
+
+// ServiceMatchListToMatchListResponse конвертирует список матчей в API ответ
+func ServiceMatchListToMatchListResponse(matchList *serviceModels.MatchList) *apiModels.MatchListResponse {
+	matches := make([]apiModels.MatchResponse, 0, len(matchList.Matches))
+	for _, match := range matchList.Matches {
+		matches = append(matches, apiModels.MatchResponse{
+			MatchID:    match.ID.String(),
+			EmployeeID: match.EmployeeID.String(),
+			VacansieID: match.VacancyID.String(),
+			EmployerID: match.EmployerID.String(),
+			CreatedAt:  match.CreatedAt,
+		})
+	}
+
+	return &apiModels.MatchListResponse{
+		Matches: matches,
+	}
+}
@@ -18,6 +18,58 @@ func UserCreateRequestToServiceUser(req *apiModels.UserCreateRequest) *serviceMo
 	}
 }
 
+// OnboardingRequestToServiceUserProfile конвертирует API запрос онбординга в профиль пользователя.
+// Данные профиля другой роли отбрасываются.
+func OnboardingRequestToServiceUserProfile(req *apiModels.OnboardingRequest) *serviceModels.UserProfile {
+	profile := &serviceModels.UserProfile{
+		User: &serviceModels.User{
+			TgUserName: req.TgUserName,
+			TgChatID:   req.TgChatID,
+			Role:       req.Role,
+		},
+	}
+
+	if req.Role == serviceModels.UserRoleEmployee && req.Employee != nil {
+		profile.Employee = onboardingEmployeeToServiceEmployee(req.Employee)
+	}
+
+	if req.Role == serviceModels.UserRoleEmployer && req.Employer != nil {
+		profile.Employer = onboardingEmployerToServiceEmployer(req.Employer)
+	}
+
+	return profile
+}
+
+// RoleChangeRequestToServiceRoleChangeRequest конвертирует API запрос смены роли в сервисную модель.
+// Данные профиля, не относящиеся к новой роли, отбрасываются.
+func RoleChangeRequestToServiceRoleChangeRequest(req *apiModels.RoleChangeRequest) *serviceModels.RoleChangeRequest {
+	roleChange := &serviceModels.RoleChangeRequest{Role: req.Role}
+
+	if req.Role == serviceModels.UserRoleEmployee && req.Employee != nil {
+		roleChange.Employee = onboardingEmployeeToServiceEmployee(req.Employee)
+	}
+
+	if req.Role == serviceModels.UserRoleEmployer && req.Employer != nil {
+		roleChange.Employer = onboardingEmployerToServiceEmployer(req.Employer)
+	}
+
+	return roleChange
+}
+
+func onboardingEmployeeToServiceEmployee(req *apiModels.OnboardingEmployeeRequest) *serviceModels.Employee {
+	return &serviceModels.Employee{Tags: req.Tags}
+}
+
+func onboardingEmployerToServiceEmployer(req *apiModels.OnboardingEmployerRequest) *serviceModels.Employer {
+	return &serviceModels.Employer{
+		CompanyName:        req.CompanyName,
+		CompanyDescription: req.CompanyDescription,
+		CompanyWebsite:     req.CompanyWebsite,
+		CompanyLocation:    req.CompanyLocation,
+		CompanySize:        req.CompanySize,
+	}
+}
+
 // UserUpdateRequestToServiceUser конвертирует API запрос обновления в сервисную модель
 func UserUpdateRequestToServiceUserUpdateRequest(req *apiModels.UserUpdateRequest) (*serviceModels.UserUpdateRequest, error) {
 	updateUser := &serviceModels.UserUpdateRequest{}
@@ -60,3 +112,20 @@ func ServiceUserToUserResponse(user *serviceModels.User) *apiModels.UserResponse
 		UpdatedAt:  user.UpdatedAt,
 	}
 }
+
+// ServiceUserProfileToUserProfileResponse конвертирует профиль пользователя в API ответ
+func ServiceUserProfileToUserProfileResponse(profile *serviceModels.UserProfile) *apiModels.UserProfileResponse {
+	response := &apiModels.UserProfileResponse{
+		User: ServiceUserToUserResponse(profile.User),
+	}
+
+	if profile.Employee != nil {
+		response.Employee = ServiceEmployeeToEmployeeResponse(profile.Employee)
+	}
+
+	if profile.Employer != nil {
+		response.Employer = ServiceEmployerToEmployerResponse(profile.Employer)
+	}
+
+	return response
+}
@@ -1,12 +1,32 @@
 package converter
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
 	apiModels "jobot/internal/api/models"
 	serviceModels "jobot/internal/service/models"
 
 	"github.com/google/uuid"
 )
 
+// Query-параметры списка вакансий
+const (
+	SearchQueryValue      = "q"
+	TagsQueryValue        = "tags"
+	TagsMatchQueryValue   = "tags_match"
+	LocationQueryValue    = "location"
+	EmployerIDQueryValue  = "employer_id"
+	CreatedFromQueryValue = "created_from"
+	CreatedToQueryValue   = "created_to"
+	SortByQueryValue      = "sort_by"
+	SortOrderQueryValue   = "sort_order"
+)
+
+const dateLayout = "2006-01-02"
+
 // API → Service конвертеры
 
 // VacancyCreateRequestToServiceVacancy конвертирует API запрос в сервисную модель Vacancy
@@ -26,6 +46,96 @@ func VacancyCreateRequestToServiceVacancy(req *apiModels.VacansieCreateRequest)
 	}, nil
 }
 
+// VacancyListQueryToServiceVacancyFilter собирает фильтр списка вакансий из query-параметров
+func VacancyListQueryToServiceVacancyFilter(query url.Values) (*serviceModels.VacancyFilter, error) {
+	filter := &serviceModels.VacancyFilter{
+		TagsMatch: serviceModels.TagsMatchAny,
+		SortBy:    serviceModels.VacancySortByCreatedAt,
+		SortOrder: serviceModels.SortOrderDesc,
+	}
+
+	// Теги принимаются как через запятую (tags=go,sql), так и повторением параметра (tags=go&tags=sql)
+	for _, value := range query[TagsQueryValue] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				filter.Tags = append(filter.Tags, tag)
+			}
+		}
+	}
+
+	if value := query.Get(TagsMatchQueryValue); value != "" {
+		if value != serviceModels.TagsMatchAny && value != serviceModels.TagsMatchAll {
+			return nil, fmt.Errorf("invalid %s: must be one of any, all", TagsMatchQueryValue)
+		}
+
+		filter.TagsMatch = value
+	}
+
+	if value := strings.TrimSpace(query.Get(LocationQueryValue)); value != "" {
+		filter.Location = &value
+	}
+
+	if value := query.Get(EmployerIDQueryValue); value != "" {
+		employerID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EmployerIDQueryValue, err)
+		}
+
+		filter.EmployerID = &employerID
+	}
+
+	if value := query.Get(CreatedFromQueryValue); value != "" {
+		createdFrom, err := parseQueryTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", CreatedFromQueryValue, err)
+		}
+
+		filter.CreatedFrom = &createdFrom
+	}
+
+	if value := query.Get(CreatedToQueryValue); value != "" {
+		createdTo, err := parseQueryTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", CreatedToQueryValue, err)
+		}
+
+		// Дата без времени включает весь день целиком
+		if len(value) == len(dateLayout) {
+			createdTo = createdTo.Add(24*time.Hour - time.Nanosecond)
+		}
+
+		filter.CreatedTo = &createdTo
+	}
+
+	if value := query.Get(SortByQueryValue); value != "" {
+		switch value {
+		case serviceModels.VacancySortByCreatedAt, serviceModels.VacancySortByUpdatedAt, serviceModels.VacancySortByTitle:
+			filter.SortBy = value
+		default:
+			return nil, fmt.Errorf("invalid %s: must be one of created_at, updated_at, title", SortByQueryValue)
+		}
+	}
+
+	if value := query.Get(SortOrderQueryValue); value != "" {
+		if value != serviceModels.SortOrderAsc && value != serviceModels.SortOrderDesc {
+			return nil, fmt.Errorf("invalid %s: must be one of asc, desc", SortOrderQueryValue)
+		}
+
+		filter.SortOrder = value
+	}
+
+	return filter, nil
+}
+
+// parseQueryTime разбирает дату в формате RFC3339 или YYYY-MM-DD
+func parseQueryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(dateLayout, value)
+}
+
 // Service → API конвертеры
 
 // ServiceVacancyToVacancyResponse конвертирует сервисную модель в API ответ
@@ -94,3 +204,21 @@ func ServiceEmployerVacancyListToEmployerVacancyListResponse(vacancyList *servic
 		EmployerID: vacancyList.EmployerID.String(),
 	}
 }
+
+// ServiceVacancySearchResultToVacancySearchResponse конвертирует результаты поиска вакансий в API ответ
+func ServiceVacancySearchResultToVacancySearchResponse(result *serviceModels.VacancySearchResult) *apiModels.VacansieSearchResponse {
+	hits := make([]apiModels.VacansieSearchHitResponse, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		hits = append(hits, apiModels.VacansieSearchHitResponse{
+			VacansieResponse: *ServiceVacancyToVacancyResponse(&hit.Vacancy),
+			Rank:             hit.Rank,
+			TitleHighlight:   hit.TitleHighlight,
+			Snippet:          hit.Snippet,
+		})
+	}
+
+	return &apiModels.VacansieSearchResponse{
+		Query:     result.Query,
+		Vacansies: hits,
+	}
+}
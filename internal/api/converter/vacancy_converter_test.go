@@ -0,0 +1,69 @@
+package converter_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/api/converter"
+	serviceModels "jobot/internal/service/models"
+)
+
+func TestVacancyListQueryToServiceVacancyFilter(t *testing.T) {
+	t.Parallel()
+
+	query, err := url.ParseQuery("tags=go,sql&tags=docker&tags_match=all&location=Berlin&created_from=2025-01-01&created_to=2025-01-31&sort_by=title&sort_order=asc")
+	require.NoError(t, err)
+
+	filter, err := VacancyListQueryToServiceVacancyFilter(query)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"go", "sql", "docker"}, filter.Tags)
+	assert.Equal(t, serviceModels.TagsMatchAll, filter.TagsMatch)
+	assert.Equal(t, "Berlin", *filter.Location)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), *filter.CreatedFrom)
+	assert.Equal(t, time.Date(2025, 1, 31, 23, 59, 59, 999999999, time.UTC), *filter.CreatedTo)
+	assert.Equal(t, serviceModels.VacancySortByTitle, filter.SortBy)
+	assert.Equal(t, serviceModels.SortOrderAsc, filter.SortOrder)
+	assert.Nil(t, filter.EmployerID)
+}
+
+func TestVacancyListQueryToServiceVacancyFilterInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, rawQuery := range []string{
+		"sort_by=salary",
+		"sort_order=up",
+		"tags_match=some",
+		"employer_id=not-a-uuid",
+		"created_from=yesterday",
+	} {
+		query, err := url.ParseQuery(rawQuery)
+		require.NoError(t, err)
+
+		_, err = VacancyListQueryToServiceVacancyFilter(query)
+		assert.Error(t, err, rawQuery)
+	}
+}
+
+func TestPaginationFromQuery(t *testing.T) {
+	t.Parallel()
+
+	pagination, err := PaginationFromQuery(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, serviceModels.Pagination{Page: serviceModels.DefaultPage, PerPage: serviceModels.DefaultPerPage}, pagination)
+
+	pagination, err = PaginationFromQuery(url.Values{"page": {"3"}, "per_page": {"50"}})
+	require.NoError(t, err)
+	assert.Equal(t, 100, pagination.Offset())
+	assert.Equal(t, 50, pagination.Limit())
+
+	_, err = PaginationFromQuery(url.Values{"page": {"0"}})
+	assert.Error(t, err)
+
+	_, err = PaginationFromQuery(url.Values{"per_page": {"1000"}})
+	assert.Error(t, err)
+}
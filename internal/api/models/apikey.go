@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKeyCreateRequest - DTO для создания API ключа сервиса (API → Service)
+type APIKeyCreateRequest struct {
+	Name   string   `json:"name" validate:"required,max=255"`
+	Scopes []string `json:"scopes" validate:"dive,oneof=admin on_behalf_of_user"`
+}
+
+// APIKeyResponse - DTO API ключа без его значения (Service → API)
+type APIKeyResponse struct {
+	ID         string     `json:"api_key_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"key_prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  *string    `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IssuedAPIKeyResponse - DTO созданного или перевыпущенного ключа (Service → API)
+// Key возвращается только в этом ответе, повторно получить его нельзя
+type IssuedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// ApplicationCreateRequest - DTO отклика сотрудника на вакансию
+// ResumeID - необязательный, по умолчанию прикладывается текущее резюме сотрудника
+
+type ApplicationCreateRequest struct {
+	EmployeeID string  `json:"employee_id" validate:"required,uuid"`
+	VacansieID string  `json:"vacansie_id" validate:"required,uuid"`
+	ResumeID   *string `json:"resume_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// ApplicationTransitionRequest - DTO смены статуса заявки
+// Actor - сторона, меняющая статус (employee или employer), ActorID - её employee_id или employer_id
+
+type ApplicationTransitionRequest struct {
+	Status  string  `json:"status" validate:"required,oneof=applied viewed interview offer hired rejected"`
+	Actor   string  `json:"actor" validate:"required,oneof=employee employer"`
+	ActorID string  `json:"actor_id" validate:"required,uuid"`
+	Comment *string `json:"comment,omitempty" validate:"omitempty,max=1000"`
+}
+
+type ApplicationTransitionResponse struct {
+	TransitionID string    `json:"transition_id"`
+	FromStatus   *string   `json:"from_status"`
+	ToStatus     string    `json:"to_status"`
+	Actor        string    `json:"actor"`
+	Comment      *string   `json:"comment,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ApplicationResponse struct {
+	ApplicationID string                          `json:"application_id"`
+	EmployeeID    string                          `json:"employee_id"`
+	VacansieID    string                          `json:"vacansie_id"`
+	ResumeID      *string                         `json:"resume_id,omitempty"`
+	Status        string                          `json:"status"`
+	CreatedAt     time.Time                       `json:"created_at"`
+	UpdatedAt     time.Time                       `json:"updated_at"`
+	History       []ApplicationTransitionResponse `json:"history,omitempty"`
+}
+
+// ApplicationListResponse - страница заявок, пагинация передается в Meta ответа
+type ApplicationListResponse struct {
+	Applications []ApplicationResponse `json:"applications"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// TelegramWidgetLoginRequest - DTO входа через Telegram Login Widget (API → Service)
+// Поля совпадают с данными, которые виджет передает в callback, Role учитывается только при первом входе
+type TelegramWidgetLoginRequest struct {
+	ID        int64  `json:"id" validate:"required"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	UserName  string `json:"username,omitempty"`
+	PhotoURL  string `json:"photo_url,omitempty"`
+	AuthDate  int64  `json:"auth_date" validate:"required"`
+	Hash      string `json:"hash" validate:"required,hexadecimal"`
+	Role      string `json:"role,omitempty" validate:"omitempty,oneof=employee employer"`
+}
+
+// TelegramWebAppLoginRequest - DTO входа из Telegram Mini App (API → Service)
+// InitData - строка window.Telegram.WebApp.initData без изменений
+type TelegramWebAppLoginRequest struct {
+	InitData string `json:"init_data" validate:"required"`
+	Role     string `json:"role,omitempty" validate:"omitempty,oneof=employee employer"`
+}
+
+// RefreshTokenRequest - DTO обновления пары токенов (API → Service)
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// AuthResponse - DTO ответа на вход и обновление токенов (Service → API)
+type AuthResponse struct {
+	AccessToken      string       `json:"access_token"`
+	RefreshToken     string       `json:"refresh_token"`
+	TokenType        string       `json:"token_type"`
+	AccessExpiresAt  time.Time    `json:"access_expires_at"`
+	RefreshExpiresAt time.Time    `json:"refresh_expires_at"`
+	User             UserResponse `json:"user"`
+	Created          bool         `json:"created"`
+}
@@ -11,7 +11,7 @@ import "time"
 // Tags - Теги сотрудника
 
 type EmployeeCreateRequest struct {
-	UserID string   `json:"user_id" validate:"required"`
+	UserID string   `json:"user_id" validate:"required,uuid"`
 	Tags   []string `json:"tags" validate:"required"`
 }
 
@@ -27,9 +27,10 @@ type EmployeeUpdateRequest struct {
 }
 
 type EmployeeResponse struct {
-	EmployeeID string    `json:"employee_id"`
-	UserID     string    `json:"user_id"`
-	Tags       []string  `json:"tags"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	EmployeeID string     `json:"employee_id"`
+	UserID     string     `json:"user_id"`
+	Tags       []string   `json:"tags"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
 }
@@ -3,7 +3,7 @@ package models
 import "time"
 
 type EmployerCreateRequest struct {
-	UserID             string `json:"user_id" validate:"required"`
+	UserID             string `json:"user_id" validate:"required,uuid"`
 	CompanyName        string `json:"company_name" validate:"required"`
 	CompanyDescription string `json:"company_description" validate:"required"`
 	CompanyWebsite     string `json:"company_website" validate:"required"`
@@ -12,7 +12,7 @@ type EmployerCreateRequest struct {
 }
 
 type EmployerUpdateRequest struct {
-	EmployerID         string  `json:"employer_id" validate:"required"`
+	EmployerID         string  `json:"employer_id" validate:"omitempty,uuid"`
 	CompanyName        *string `json:"company_name,omitempty"`
 	CompanyDescription *string `json:"company_description,omitempty"`
 	CompanyWebsite     *string `json:"company_website,omitempty"`
@@ -21,13 +21,14 @@ type EmployerUpdateRequest struct {
 }
 
 type EmployerResponse struct {
-	EmployerID         string    `json:"employer_id"`
-	UserID             string    `json:"user_id"`
-	CompanyName        string    `json:"company_name"`
-	CompanyDescription string    `json:"company_description"`
-	CompanyWebsite     string    `json:"company_website"`
-	CompanyLocation    string    `json:"company_location"`
-	CompanySize        string    `json:"company_size"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	EmployerID         string     `json:"employer_id"`
+	UserID             string     `json:"user_id"`
+	CompanyName        string     `json:"company_name"`
+	CompanyDescription string     `json:"company_description"`
+	CompanyWebsite     string     `json:"company_website"`
+	CompanyLocation    string     `json:"company_location"`
+	CompanySize        string     `json:"company_size"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	ArchivedAt         *time.Time `json:"archived_at,omitempty"`
 }
@@ -0,0 +1,34 @@
+package models
+
+// VacansieFeedItemResponse - вакансия в ленте рекомендаций сотрудника
+// MatchedTags - теги вакансии, совпавшие с тегами сотрудника
+// Score - итоговый вес ранжирования (совпавшие теги + бонус за свежесть)
+
+type VacansieFeedItemResponse struct {
+	VacansieResponse
+	MatchedTags []string `json:"matched_tags"`
+	Score       float64  `json:"score"`
+}
+
+// VacansieFeedResponse - страница ленты рекомендаций, пагинация передается в Meta ответа
+type VacansieFeedResponse struct {
+	EmployeeID string                     `json:"employee_id"`
+	Vacansies  []VacansieFeedItemResponse `json:"vacansies"`
+}
+
+// CandidateResponse - сотрудник, подходящий под вакансию
+// MatchedTags - теги сотрудника, совпавшие с тегами вакансии
+// HasResume - загружено ли у сотрудника резюме
+
+type CandidateResponse struct {
+	EmployeeResponse
+	MatchedTags []string `json:"matched_tags"`
+	HasResume   bool     `json:"has_resume"`
+	ResumeID    *string  `json:"resume_id,omitempty"`
+}
+
+// CandidateListResponse - страница кандидатов, пагинация передается в Meta ответа
+type CandidateListResponse struct {
+	VacansieID string              `json:"vacansie_id"`
+	Candidates []CandidateResponse `json:"candidates"`
+}
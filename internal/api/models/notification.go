@@ -0,0 +1,22 @@
+package models
+
+// NotificationPreferencesRequest - DTO настроек уведомлений (API → Service), заменяет настройки целиком.
+// Тихие часы задаются часами 0-23 в часовом поясе timezone (IANA, по умолчанию UTC): оба или ни одного.
+// candidate_likes по умолчанию true, чтобы клиенты, которые его не знают, не выключали уведомления работодателю.
+type NotificationPreferencesRequest struct {
+	NewVacancies    *bool  `json:"new_vacancies" validate:"required"`
+	CandidateLikes  *bool  `json:"candidate_likes"`
+	QuietHoursStart *int   `json:"quiet_hours_start"`
+	QuietHoursEnd   *int   `json:"quiet_hours_end"`
+	Timezone        string `json:"timezone" validate:"max=64"`
+}
+
+// NotificationPreferencesResponse - DTO настроек уведомлений (Service → API)
+type NotificationPreferencesResponse struct {
+	UserID          string `json:"user_id"`
+	NewVacancies    bool   `json:"new_vacancies"`
+	CandidateLikes  bool   `json:"candidate_likes"`
+	QuietHoursStart *int   `json:"quiet_hours_start"`
+	QuietHoursEnd   *int   `json:"quiet_hours_end"`
+	Timezone        string `json:"timezone"`
+}
@@ -3,20 +3,77 @@ package models
 import "time"
 
 type ReactionCreateRequest struct {
-	EmployeeID string `json:"employee_id" validate:"required"`
-	VacansieID string `json:"vacansie_id" validate:"required"`
+	EmployeeID string `json:"employee_id" validate:"required,uuid"`
+	VacansieID string `json:"vacansie_id" validate:"required,uuid"`
 	Reaction   string `json:"reaction" validate:"required,oneof=like dislike"`
 }
 
+type ReactionUpdateRequest struct {
+	Reaction string `json:"reaction" validate:"required,oneof=like dislike"`
+}
+
 type ReactionResponse struct {
 	ReactionID string    `json:"reaction_id"`
 	EmployeeID string    `json:"employee_id"`
 	VacansieID string    `json:"vacansie_id"`
 	Reaction   string    `json:"reaction"`
 	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 type ReactionEmployeeListResponse struct {
-	ReactionsIDs []string `json:"reactions_ids"`
-	EmployeeID   string   `json:"employee_id"`
+	ReactionsIDs []string           `json:"reactions_ids"`
+	Reactions    []ReactionResponse `json:"reactions"`
+	EmployeeID   string             `json:"employee_id"`
+}
+
+type ReactionVacancyListResponse struct {
+	VacansieID string             `json:"vacansie_id"`
+	Reactions  []ReactionResponse `json:"reactions"`
+}
+
+// EmployerReactionCreateRequest - DTO решения работодателя по кандидату, лайкнувшему вакансию
+// Decision - accept (принять), reject (отклонить) или shortlist (отложить в шорт-лист)
+
+type EmployerReactionCreateRequest struct {
+	EmployerID string `json:"employer_id" validate:"required,uuid"`
+	EmployeeID string `json:"employee_id" validate:"required,uuid"`
+	VacansieID string `json:"vacansie_id" validate:"required,uuid"`
+	Decision   string `json:"decision" validate:"required,oneof=accept reject shortlist"`
+}
+
+type EmployerReactionUpdateRequest struct {
+	Decision string `json:"decision" validate:"required,oneof=accept reject shortlist"`
+}
+
+// EmployerReactionResponse - DTO решения работодателя
+// Matched - образовался ли матч (сотрудник лайкнул вакансию, работодатель принял сотрудника)
+
+type EmployerReactionResponse struct {
+	EmployerReactionID string    `json:"employer_reaction_id"`
+	EmployerID         string    `json:"employer_id"`
+	EmployeeID         string    `json:"employee_id"`
+	VacansieID         string    `json:"vacansie_id"`
+	Decision           string    `json:"decision"`
+	Matched            bool      `json:"matched"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type EmployerReactionListResponse struct {
+	EmployerID string                     `json:"employer_id"`
+	Reactions  []EmployerReactionResponse `json:"reactions"`
+}
+
+type MatchResponse struct {
+	MatchID    string    `json:"match_id"`
+	EmployeeID string    `json:"employee_id"`
+	VacansieID string    `json:"vacansie_id"`
+	EmployerID string    `json:"employer_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// MatchListResponse - страница матчей, пагинация передается в Meta ответа
+type MatchListResponse struct {
+	Matches []MatchResponse `json:"matches"`
 }
@@ -7,7 +7,7 @@ import "time"
 // TgFileID - ID файла в Telegram
 
 type ResumeCreateRequest struct {
-	EmployeeID string `json:"employee_id" validate:"required"`
+	EmployeeID string `json:"employee_id" validate:"required,uuid"`
 	TgFileID   string `json:"tg_file_id" validate:"required"`
 }
 
@@ -30,6 +30,6 @@ type ResumeResponse struct {
 // TgFileID - ID файла в Telegram
 
 type ResumeUpdateRequest struct {
-	ResumeID string  `json:"resume_id" validate:"required"`
+	ResumeID string  `json:"resume_id" validate:"omitempty,uuid"`
 	TgFileID *string `json:"tg_file_id,omitempty"`
 }
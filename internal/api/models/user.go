@@ -8,9 +8,9 @@ import (
 type UserCreateRequest struct {
 	TgUserName string `json:"tg_user_name" validate:"required"`
 	TgChatID   string `json:"tg_chat_id" validate:"required"`
-	IsActive   bool   `json:"is_active" validate:"required"`
-	IsPremium  bool   `json:"is_premium" validate:"required"`
-	Role       string `json:"role" validate:"required,oneof=employee employer"`
+	IsActive   bool   `json:"is_active"`
+	IsPremium  bool   `json:"is_premium"`
+	Role       string `json:"role" validate:"required,oneof=employee employer admin"`
 }
 
 // UserUpdateRequest - DTO для обновления пользователя (API → Service)
@@ -19,7 +19,7 @@ type UserUpdateRequest struct {
 	TgChatID   *string `json:"tg_chat_id,omitempty"`
 	IsActive   *bool   `json:"is_active,omitempty"`
 	IsPremium  *bool   `json:"is_premium,omitempty"`
-	Role       *string `json:"role" validate:"omitempty,oneof=employee employer"`
+	Role       *string `json:"role" validate:"omitempty,oneof=employee employer admin"`
 }
 
 // UserResponse - DTO для ответа API (Service → API)
@@ -36,7 +36,39 @@ type UserResponse struct {
 
 // UserProfileResponse - DTO для получения профиля пользователя (Service → API)
 type UserProfileResponse struct {
-	UserResponse
+	User     *UserResponse     `json:"user"`
 	Employee *EmployeeResponse `json:"employee,omitempty"`
 	Employer *EmployerResponse `json:"employer,omitempty"`
 }
+
+// OnboardingRequest - DTO регистрации пользователя Telegram вместе с профилем роли (API → Service)
+// Employee обязателен для роли employee, Employer - для роли employer.
+type OnboardingRequest struct {
+	TgUserName string                     `json:"tg_user_name"`
+	TgChatID   string                     `json:"tg_chat_id" validate:"required"`
+	Role       string                     `json:"role" validate:"required,oneof=employee employer"`
+	Employee   *OnboardingEmployeeRequest `json:"employee" validate:"required_if=Role employee"`
+	Employer   *OnboardingEmployerRequest `json:"employer" validate:"required_if=Role employer"`
+}
+
+// RoleChangeRequest - DTO смены роли пользователя (API → Service)
+// Employee или Employer нужны, только если у пользователя еще не было профиля новой роли.
+type RoleChangeRequest struct {
+	Role     string                     `json:"role" validate:"required,oneof=employee employer admin"`
+	Employee *OnboardingEmployeeRequest `json:"employee"`
+	Employer *OnboardingEmployerRequest `json:"employer"`
+}
+
+// OnboardingEmployeeRequest - данные профиля сотрудника при онбординге
+type OnboardingEmployeeRequest struct {
+	Tags []string `json:"tags" validate:"required"`
+}
+
+// OnboardingEmployerRequest - данные профиля работодателя при онбординге
+type OnboardingEmployerRequest struct {
+	CompanyName        string `json:"company_name" validate:"required"`
+	CompanyDescription string `json:"company_description" validate:"required"`
+	CompanyWebsite     string `json:"company_website" validate:"required"`
+	CompanyLocation    string `json:"company_location" validate:"required"`
+	CompanySize        string `json:"company_size" validate:"required"`
+}
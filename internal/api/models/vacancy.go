@@ -3,7 +3,7 @@ package models
 import "time"
 
 type VacansieCreateRequest struct {
-	EmployerID  string   `json:"employer_id" validate:"required"`
+	EmployerID  string   `json:"employer_id" validate:"required,uuid"`
 	Tags        []string `json:"tags" validate:"required"`
 	Title       string   `json:"title" validate:"required"`
 	Description string   `json:"description" validate:"required"`
@@ -12,7 +12,7 @@ type VacansieCreateRequest struct {
 }
 
 type VacansieUpdateRequest struct {
-	VacansieID  string    `json:"vacansie_id" validate:"required"`
+	VacansieID  string    `json:"vacansie_id" validate:"omitempty,uuid"`
 	Tags        *[]string `json:"tags,omitempty"`
 	Title       *string   `json:"title,omitempty"`
 	Description *string   `json:"description,omitempty"`
@@ -37,7 +37,22 @@ type VacansieEmployerListResponse struct {
 	EmployerID string             `json:"employer_id"`
 }
 
-// TODO: добавить pagination, search, filter
+// VacansieSearchHitResponse - вакансия, найденная поиском, с подсвеченными фрагментами
+// TitleHighlight и Snippet содержат совпадения, обернутые в <b></b>, остальной текст HTML-экранирован
+type VacansieSearchHitResponse struct {
+	VacansieResponse
+	Rank           float32 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+// VacansieSearchResponse - страница результатов поиска, пагинация передается в Meta ответа
+type VacansieSearchResponse struct {
+	Query     string                      `json:"query"`
+	Vacansies []VacansieSearchHitResponse `json:"vacansies"`
+}
+
+// VacansieListResponse - страница списка вакансий, пагинация передается в Meta ответа
 type VacansieListResponse struct {
 	Vacansies []VacansieResponse `json:"vacansies"`
 }
@@ -5,29 +5,49 @@ import (
 	"errors"
 	"fmt"
 	"jobot/internal/api/controllers"
+	outboxSink "jobot/internal/transport/outbox"
 	"jobot/internal/transport/rest"
+	"jobot/internal/transport/telegram"
+	"jobot/migrations"
 	"jobot/pkg/database"
 	"jobot/pkg/logger"
+	"jobot/pkg/migrator"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	api "jobot/internal/api"
+	apiKeyRepo "jobot/internal/repository/apikey"
+	applicationRepo "jobot/internal/repository/application"
+	conversationRepo "jobot/internal/repository/conversation"
 	employeeRepo "jobot/internal/repository/employee"
 	employerRepo "jobot/internal/repository/employer"
+	feedRepo "jobot/internal/repository/feed"
+	notificationRepo "jobot/internal/repository/notification"
+	outboxRepo "jobot/internal/repository/outbox"
 	reactionRepo "jobot/internal/repository/reaction"
 	resumeRepo "jobot/internal/repository/resume"
+	"jobot/internal/repository/transaction"
 	userRepo "jobot/internal/repository/user"
 	vacancyRepo "jobot/internal/repository/vacancy"
+	accessSrv "jobot/internal/service/access"
+	apiKeySrv "jobot/internal/service/apikey"
+	applicationSrv "jobot/internal/service/application"
+	authSrv "jobot/internal/service/auth"
+	conversationSrv "jobot/internal/service/conversation"
 	employeeSrv "jobot/internal/service/employee"
 	employerSrv "jobot/internal/service/employer"
+	feedSrv "jobot/internal/service/feed"
+	"jobot/internal/service/models"
+	notificationSrv "jobot/internal/service/notification"
+	outboxSrv "jobot/internal/service/outbox"
 	reactionSrv "jobot/internal/service/reaction"
 	resumeSrv "jobot/internal/service/resume"
 	userSrv "jobot/internal/service/user"
 	vacancySrv "jobot/internal/service/vacancy"
 
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
 )
 
@@ -41,14 +61,19 @@ type Application struct {
 	serverHTTP *http.Server
 	db         *pgxpool.Pool
 	controller *api.Controller
+	bot        *telegram.Bot
+
+	notificationService *notificationSrv.NotificationService
+	outboxService       *outboxSrv.OutboxService
+	outboxSinks         []outboxSrv.Sink
 }
 
 // NewApplication создает новое приложение с загруженной конфигурацией
 func NewApplication(log *logger.Logger) (*Application, error) {
 	// Загружаем конфигурацию из переменных окружения
-	cfg := &Config{}
-	if err := envconfig.Process("", cfg); err != nil {
-		return nil, fmt.Errorf("failed to load configuration from env: %w", err)
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
 	}
 
 	// Создаем логгер
@@ -61,6 +86,22 @@ func NewApplication(log *logger.Logger) (*Application, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Применяем миграции, если это включено в конфигурации
+	if cfg.Migrate.OnStartup {
+		ctx := logger.ContextWithLogger(context.Background(), log.ZapLogger())
+
+		m, err := migrator.New(db, migrations.FS)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to load migrations: %w", err)
+		}
+
+		if _, err := m.Up(ctx); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	}
+
 	return &Application{
 		config: cfg,
 		logger: log,
@@ -68,6 +109,12 @@ func NewApplication(log *logger.Logger) (*Application, error) {
 	}, nil
 }
 
+// AddOutboxSink добавляет приемник событий outbox внутри процесса (например, outbox.ChannelSink)
+// к приемникам из конфигурации. Вызывается до Initialize.
+func (app *Application) AddOutboxSink(sink outboxSrv.Sink) {
+	app.outboxSinks = append(app.outboxSinks, sink)
+}
+
 // Initialize инициализирует приложение (создает контроллеры, сервисы и т.д.)
 func (app *Application) Initialize(ctx context.Context) error {
 	app.logger.Info("Initializing application",
@@ -78,7 +125,9 @@ func (app *Application) Initialize(ctx context.Context) error {
 	)
 
 	// TODO: Создаем репозитории, сервисы и контроллеры
-	app.InitializeControllers()
+	if err := app.InitializeControllers(); err != nil {
+		return err
+	}
 
 	/*
 		handlersConfig := &rest.HandlersConfig{
@@ -100,6 +149,12 @@ func (app *Application) Initialize(ctx context.Context) error {
 		// IdleTimeout:  app.config.HTTP.IdleTimeout,  // TODO: добавить в rest.ConfigHTTPServer
 	}
 
+	if app.bot != nil && app.config.Telegram.Bot.Mode == telegram.ModeWebhook {
+		cfgHTTP.Webhooks = map[string]http.Handler{
+			app.config.Telegram.Bot.WebhookPath: app.bot.WebhookHandler(),
+		}
+	}
+
 	app.serverHTTP = rest.CreateHTTPServerWithChi(ctx, cfgHTTP, app.controller)
 
 	app.logger.Info("Application initialized successfully")
@@ -113,28 +168,102 @@ func (app *Application) InitializeControllers() error {
 	employerRepository := employerRepo.NewEmployerRepository(app.db)
 	vacancyRepository := vacancyRepo.NewVacancyRepository(app.db)
 	reactionRepository := reactionRepo.NewReactionRepository(app.db)
-
-	userService := userSrv.NewUserService(userRepository, employeeRepository, employerRepository)
+	employerReactionRepository := reactionRepo.NewEmployerReactionRepository(app.db)
+	matchRepository := reactionRepo.NewMatchRepository(app.db)
+	feedRepository := feedRepo.NewFeedRepository(app.db)
+	applicationRepository := applicationRepo.NewApplicationRepository(app.db)
+	apiKeyRepository := apiKeyRepo.NewAPIKeyRepository(app.db)
+	conversationRepository := conversationRepo.NewConversationRepository(app.db)
+	notificationRepository := notificationRepo.NewNotificationRepository(app.db)
+	outboxRepository := outboxRepo.NewOutboxRepository(app.db)
+	txManager := transaction.NewManager(app.db)
+
+	userService := userSrv.NewUserService(userRepository, employeeRepository, employerRepository, vacancyRepository, applicationRepository, txManager)
 	employeeService := employeeSrv.NewEmployeeService(employeeRepository)
 	resumeService := resumeSrv.NewResumeService(resumeRepository)
 	employerService := employerSrv.NewEmployerService(employerRepository)
-	vacancyService := vacancySrv.NewVacancyService(vacancyRepository)
-	reactionService := reactionSrv.NewReactionService(reactionRepository)
+	vacancyService := vacancySrv.NewVacancyService(vacancyRepository, notificationRepository, txManager)
+	reactionService := reactionSrv.NewReactionService(reactionRepository, employerReactionRepository, matchRepository, vacancyRepository, notificationRepository, txManager)
+	feedService := feedSrv.NewFeedService(feedRepository, employeeRepository, vacancyRepository)
+	applicationService := applicationSrv.NewApplicationService(applicationRepository, employeeRepository, vacancyRepository, resumeRepository)
+	accessService := accessSrv.NewAccessService(employeeRepository, employerRepository, resumeRepository, vacancyRepository, reactionRepository, employerReactionRepository, applicationRepository)
+	apiKeyService := apiKeySrv.NewAPIKeyService(apiKeyRepository, userRepository)
+	conversationService := conversationSrv.NewConversationService(conversationRepository, app.config.Telegram.Bot.ConversationTTL)
+	notificationService := notificationSrv.NewNotificationService(notificationRepository, userRepository, app.newTelegramNotifier(), notificationSrv.Config{
+		BatchSize:      app.config.Notifications.BatchSize,
+		MaxAttempts:    app.config.Notifications.MaxAttempts,
+		RetryDelay:     app.config.Notifications.RetryDelay,
+		Lease:          app.config.Notifications.Lease,
+		DigestInterval: app.config.Notifications.DigestInterval,
+	})
+	outboxSinks, err := app.newOutboxSinks()
+	if err != nil {
+		return err
+	}
+	outboxService := outboxSrv.NewOutboxService(outboxRepository, outboxSinks, outboxSrv.Config{
+		BatchSize:  app.config.Outbox.BatchSize,
+		RetryDelay: app.config.Outbox.RetryDelay,
+		Lease:      app.config.Outbox.Lease,
+		Retention:  app.config.Outbox.Retention,
+	})
+	tokenManager := authSrv.NewTokenManager(authSrv.TokenConfig{
+		Secret:     app.config.JWT.Secret,
+		Issuer:     app.config.JWT.Issuer,
+		AccessTTL:  app.config.JWT.Expiration,
+		RefreshTTL: app.config.JWT.RefreshExpiration,
+	})
+	authService := authSrv.NewAuthService(userRepository, tokenManager, authSrv.TelegramConfig{
+		BotToken: app.config.Telegram.BotToken,
+		MaxAge:   app.config.Telegram.AuthMaxAge,
+	})
+
+	userController := controllers.NewUserController(userService, accessService)
+	employeeController := controllers.NewEmployeeController(employeeService, accessService)
+	resumeController := controllers.NewResumeController(resumeService, accessService)
+	employerController := controllers.NewEmployerController(employerService, accessService)
+	vacancyController := controllers.NewVacancyController(vacancyService, accessService)
+	reactionController := controllers.NewReactionController(reactionService, accessService)
+	feedController := controllers.NewFeedController(feedService, accessService)
+	applicationController := controllers.NewApplicationController(applicationService, accessService)
+	authController := controllers.NewAuthController(authService, apiKeyService)
+	apiKeyController := controllers.NewAPIKeyController(apiKeyService, accessService)
+	notificationController := controllers.NewNotificationController(notificationService, accessService)
+
+	if app.config.Telegram.Bot.Enabled {
+		bot, err := app.newTelegramBot(telegram.Services{
+			User:     userService,
+			Employee: employeeService,
+			Resume:   resumeService,
+			Vacancy:  vacancyService,
+			Reaction: reactionService,
+			Feed:     feedService,
+			Access:   accessService,
+
+			Conversation: conversationService,
+			Notification: notificationService,
+		})
+		if err != nil {
+			return err
+		}
 
-	userController := controllers.NewUserController(userService)
-	employeeController := controllers.NewEmployeeController(employeeService)
-	resumeController := controllers.NewResumeController(resumeService)
-	employerController := controllers.NewEmployerController(employerService)
-	vacancyController := controllers.NewVacancyController(vacancyService)
-	reactionController := controllers.NewReactionController(reactionService)
+		app.bot = bot
+	}
+
+	app.notificationService = notificationService
+	app.outboxService = outboxService
 
 	app.controller = &api.Controller{
-		UserController:     userController,
-		EmployeeController: employeeController,
-		ResumeController:   resumeController,
-		EmployerController: employerController,
-		VacancyController:  vacancyController,
-		ReactionController: reactionController,
+		UserController:         userController,
+		EmployeeController:     employeeController,
+		ResumeController:       resumeController,
+		EmployerController:     employerController,
+		VacancyController:      vacancyController,
+		ReactionController:     reactionController,
+		FeedController:         feedController,
+		ApplicationController:  applicationController,
+		AuthController:         authController,
+		APIKeyController:       apiKeyController,
+		NotificationController: notificationController,
 	}
 
 	return nil
@@ -149,10 +278,187 @@ func (app *Application) Start(ctx context.Context, wg *sync.WaitGroup, cancel co
 	wg.Add(1)
 	go app.startHTTPServer(wg, cancel)
 
+	if app.bot != nil {
+		wg.Add(1)
+		go app.startTelegramBot(ctx, wg, cancel)
+	}
+
+	if app.config.Notifications.Enabled {
+		wg.Add(1)
+		go app.startNotificationDelivery(ctx, wg)
+	}
+
+	if app.config.Outbox.Enabled {
+		wg.Add(1)
+		go app.startOutboxDispatcher(ctx, wg)
+	}
+
 	wg.Add(1)
 	go app.gracefulStop(ctx, wg)
 }
 
+// newTelegramBot создает встроенного Telegram бота по конфигурации
+func (app *Application) newTelegramBot(services telegram.Services) (*telegram.Bot, error) {
+	cfg := app.config.Telegram.Bot
+
+	// Long polling держит запрос открытым PollTimeout, клиент должен ждать дольше
+	httpClient := &http.Client{Timeout: cfg.PollTimeout + shutDownTimeout}
+	client := telegram.NewClient(cfg.APIURL, app.config.Telegram.BotToken, httpClient)
+
+	bot, err := telegram.NewBot(client, services, telegram.Config{
+		Mode:          cfg.Mode,
+		PollTimeout:   cfg.PollTimeout,
+		WebhookURL:    cfg.WebhookURL,
+		WebhookSecret: cfg.WebhookSecret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telegram bot: %w", err)
+	}
+
+	return bot, nil
+}
+
+// startTelegramBot запускает Telegram бота, ошибка запуска останавливает приложение
+func (app *Application) startTelegramBot(ctx context.Context, wg *sync.WaitGroup, cancel context.CancelFunc) {
+	defer wg.Done()
+
+	app.logger.Info("Telegram bot starting",
+		zap.String("mode", app.config.Telegram.Bot.Mode),
+	)
+
+	ctx = logger.ContextWithLogger(ctx, app.logger.ZapLogger())
+
+	if err := app.bot.Start(ctx); err != nil {
+		app.logger.Error("Telegram bot failed to start",
+			zap.Error(err),
+		)
+		cancel()
+	}
+}
+
+// newTelegramNotifier создает отправителя уведомлений через Bot API, бот для этого запускать не нужно
+func (app *Application) newTelegramNotifier() *telegram.Notifier {
+	client := telegram.NewClient(app.config.Telegram.Bot.APIURL, app.config.Telegram.BotToken, &http.Client{Timeout: shutDownTimeout})
+
+	return telegram.NewNotifier(client)
+}
+
+// startNotificationDelivery доставляет уведомления из очереди каждые Notifications.Interval, пока ctx не отменен
+func (app *Application) startNotificationDelivery(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	app.logger.Info("Notification delivery starting",
+		zap.Duration("interval", app.config.Notifications.Interval),
+	)
+
+	ctx = logger.ContextWithLogger(ctx, app.logger.ZapLogger())
+
+	ticker := time.NewTicker(app.config.Notifications.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			app.logger.Info("Notification delivery stopped")
+
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := app.notificationService.DeliverDue(ctx)
+		if err != nil {
+			app.logger.Error("Notification delivery failed",
+				zap.Error(err),
+			)
+		}
+
+		if stats != nil && *stats != (models.NotificationDeliveryStats{}) {
+			app.logger.Info("Notifications delivered",
+				zap.Int("sent", stats.Sent),
+				zap.Int("retried", stats.Retried),
+				zap.Int("failed", stats.Failed),
+				zap.Int("postponed", stats.Postponed),
+				zap.Int("canceled", stats.Canceled),
+				zap.Int("digests", stats.Digests),
+			)
+		}
+	}
+}
+
+// newOutboxSinks создает приемники событий outbox из Outbox.Sinks и добавленные через AddOutboxSink
+func (app *Application) newOutboxSinks() ([]outboxSrv.Sink, error) {
+	cfg := app.config.Outbox
+
+	var sinks []outboxSrv.Sink
+	for _, name := range cfg.Sinks {
+		switch strings.TrimSpace(name) {
+		case outboxSink.LogSinkName:
+			sinks = append(sinks, outboxSink.NewLogSink(app.logger.ZapLogger()))
+		case outboxSink.WebhookSinkName:
+			if cfg.Webhook.URL == "" {
+				return nil, errors.New("outbox webhook sink requires OUTBOX_WEBHOOK_URL")
+			}
+			sinks = append(sinks, outboxSink.NewWebhookSink(cfg.Webhook.URL, cfg.Webhook.Secret, cfg.Webhook.Timeout))
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q", name)
+		}
+	}
+
+	sinks = append(sinks, app.outboxSinks...)
+
+	// По имени запоминается, кто уже принял событие, поэтому имена не должны совпадать
+	names := map[string]bool{}
+	for _, sink := range sinks {
+		if names[sink.Name()] {
+			return nil, fmt.Errorf("duplicate outbox sink %q", sink.Name())
+		}
+		names[sink.Name()] = true
+	}
+
+	return sinks, nil
+}
+
+// startOutboxDispatcher отправляет события outbox приемникам каждые Outbox.Interval, пока ctx не отменен
+func (app *Application) startOutboxDispatcher(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	app.logger.Info("Outbox dispatcher starting",
+		zap.Duration("interval", app.config.Outbox.Interval),
+		zap.Strings("sinks", app.config.Outbox.Sinks),
+	)
+
+	ctx = logger.ContextWithLogger(ctx, app.logger.ZapLogger())
+
+	ticker := time.NewTicker(app.config.Outbox.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			app.logger.Info("Outbox dispatcher stopped")
+
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := app.outboxService.DispatchPending(ctx)
+		if err != nil {
+			app.logger.Error("Outbox dispatch failed",
+				zap.Error(err),
+			)
+		}
+
+		if stats != nil && *stats != (models.OutboxDispatchStats{}) {
+			app.logger.Info("Outbox events dispatched",
+				zap.Int("dispatched", stats.Dispatched),
+				zap.Int("retried", stats.Retried),
+				zap.Int64("deleted", stats.Deleted),
+			)
+		}
+	}
+}
+
 // startHTTPServer запускает HTTP сервер
 func (app *Application) startHTTPServer(wg *sync.WaitGroup, cancel context.CancelFunc) {
 	defer wg.Done()
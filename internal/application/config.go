@@ -1,10 +1,13 @@
 package application
 
 import (
+	"fmt"
 	"time"
 
 	"jobot/pkg/database"
 	"jobot/pkg/logger"
+
+	"github.com/kelseyhightower/envconfig"
 )
 
 // Config - основная конфигурация приложения
@@ -21,8 +24,50 @@ type Config struct {
 	// Application конфигурация
 	App AppConfig `envconfig:"APP"`
 
-	// JWT конфигурация (для будущей аутентификации)
-	//JWT JWTConfig `env:", prefix=JWT_"`
+	// JWT конфигурация
+	JWT JWTConfig `envconfig:"JWT"`
+
+	// Telegram конфигурация
+	Telegram TelegramConfig `envconfig:"TELEGRAM"`
+
+	// Конфигурация миграций БД
+	Migrate MigrateConfig `envconfig:"MIGRATE"`
+
+	// Доставка уведомлений
+	Notifications NotificationsConfig `envconfig:"NOTIFICATIONS"`
+
+	// Отправка доменных событий из outbox
+	Outbox OutboxConfig `envconfig:"OUTBOX"`
+}
+
+// LoadConfig загружает конфигурацию из переменных окружения
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, fmt.Errorf("failed to load configuration from env: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// CLIConfig - конфигурация операционных утилит (cmd/jobotctl): только БД и логгер,
+// чтобы им не нужны были секреты JWT и Telegram
+type CLIConfig struct {
+	// Database конфигурация
+	Database database.Config `envconfig:"DB"`
+
+	// Logger конфигурация
+	Logger logger.Config `env:"LOG"`
+}
+
+// LoadCLIConfig загружает конфигурацию утилит из тех же переменных окружения, что и LoadConfig
+func LoadCLIConfig() (*CLIConfig, error) {
+	cfg := &CLIConfig{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, fmt.Errorf("failed to load configuration from env: %w", err)
+	}
+
+	return cfg, nil
 }
 
 // HTTPConfig - конфигурация HTTP сервера
@@ -43,10 +88,83 @@ type AppConfig struct {
 }
 
 // JWTConfig - конфигурация JWT токенов
+// Expiration - время жизни access токена, RefreshExpiration - refresh токена
 type JWTConfig struct {
-	Secret     string        `env:"SECRET" required:"true"`
-	Expiration time.Duration `env:"EXPIRATION" default:"24h"`
-	Issuer     string        `env:"ISSUER" default:"jobot"`
+	Secret            string        `env:"SECRET" required:"true"`
+	Expiration        time.Duration `env:"EXPIRATION" default:"24h"`
+	RefreshExpiration time.Duration `envconfig:"REFRESH_EXPIRATION" default:"720h"`
+	Issuer            string        `env:"ISSUER" default:"jobot"`
+}
+
+// TelegramConfig - конфигурация Telegram бота
+// AuthMaxAge - сколько действительны данные входа через Login Widget и Mini App после auth_date
+type TelegramConfig struct {
+	BotToken   string        `envconfig:"BOT_TOKEN" required:"true"`
+	AuthMaxAge time.Duration `envconfig:"AUTH_MAX_AGE" default:"24h"`
+
+	// Встроенный бот
+	Bot TelegramBotConfig `envconfig:"BOT"`
+}
+
+// TelegramBotConfig - конфигурация встроенного бота (internal/transport/telegram)
+// Mode - polling или webhook. В режиме webhook обновления принимает HTTP сервер по пути WebhookPath,
+// а WebhookURL - публичный адрес этого пути, который регистрируется в Telegram.
+// APIURL можно заменить адресом локального сервера, имитирующего Bot API.
+// ConversationTTL - через сколько без ответа прерывается многошаговый диалог (черновик сохраняется).
+type TelegramBotConfig struct {
+	Enabled       bool          `envconfig:"ENABLED" default:"false"`
+	Mode          string        `envconfig:"MODE" default:"polling"`
+	APIURL        string        `envconfig:"API_URL" default:"https://api.telegram.org"`
+	PollTimeout   time.Duration `envconfig:"POLL_TIMEOUT" default:"30s"`
+	WebhookURL    string        `envconfig:"WEBHOOK_URL"`
+	WebhookPath   string        `envconfig:"WEBHOOK_PATH" default:"/telegram/webhook"`
+	WebhookSecret string        `envconfig:"WEBHOOK_SECRET"`
+
+	ConversationTTL time.Duration `envconfig:"CONVERSATION_TTL" default:"30m"`
+}
+
+// NotificationsConfig - доставка уведомлений в Telegram
+// Уведомления ставятся в очередь всегда, Enabled включает их доставку в этом процессе.
+// Interval - пауза между проходами доставки, остальные поля - см. notification.Config.
+type NotificationsConfig struct {
+	Enabled        bool          `envconfig:"ENABLED" default:"true"`
+	Interval       time.Duration `envconfig:"INTERVAL" default:"10s"`
+	BatchSize      int           `envconfig:"BATCH_SIZE" default:"100"`
+	MaxAttempts    int           `envconfig:"MAX_ATTEMPTS" default:"5"`
+	RetryDelay     time.Duration `envconfig:"RETRY_DELAY" default:"1m"`
+	Lease          time.Duration `envconfig:"LEASE" default:"5m"`
+	DigestInterval time.Duration `envconfig:"DIGEST_INTERVAL" default:"10m"`
+}
+
+// OutboxConfig - отправка доменных событий из outbox внешним приемникам
+// События пишутся в outbox всегда, Enabled включает их отправку в этом процессе.
+// Interval - пауза между проходами отправки, Sinks - приемники (log, webhook),
+// остальные поля - см. outbox.Config.
+type OutboxConfig struct {
+	Enabled    bool          `envconfig:"ENABLED" default:"true"`
+	Interval   time.Duration `envconfig:"INTERVAL" default:"1s"`
+	BatchSize  int           `envconfig:"BATCH_SIZE" default:"100"`
+	RetryDelay time.Duration `envconfig:"RETRY_DELAY" default:"10s"`
+	Lease      time.Duration `envconfig:"LEASE" default:"1m"`
+	Retention  time.Duration `envconfig:"RETENTION" default:"168h"`
+	Sinks      []string      `envconfig:"SINKS" default:"log"`
+
+	// Приемник webhook
+	Webhook OutboxWebhookConfig `envconfig:"WEBHOOK"`
+}
+
+// OutboxWebhookConfig - приемник webhook
+// Если задан Secret, тело запроса подписывается HMAC-SHA256 в заголовке X-Jobot-Signature.
+type OutboxWebhookConfig struct {
+	URL     string        `envconfig:"URL"`
+	Secret  string        `envconfig:"SECRET"`
+	Timeout time.Duration `envconfig:"TIMEOUT" default:"10s"`
+}
+
+// MigrateConfig - конфигурация миграций БД
+// OnStartup - применять встроенные миграции при создании приложения
+type MigrateConfig struct {
+	OnStartup bool `envconfig:"ON_STARTUP" default:"false"`
 }
 
 // GetAddress возвращает полный адрес HTTP сервера
@@ -0,0 +1,205 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrAPIKeyAlreadyExists = errors.New("api key with this prefix already exists")
+)
+
+// Ограничения таблицы api_keys
+const (
+	keyPrefixUniqueConstraint = "api_keys_key_prefix_key" // уникальность key_prefix
+)
+
+// lastUsedPrecision - last_used_at обновляется не чаще, чтобы не писать в БД на каждый запрос
+const lastUsedPrecision = time.Minute
+
+const apiKeyColumns = `api_key_id, name, key_prefix, key_hash, scopes, created_by, last_used_at, revoked_at, created_at, updated_at`
+
+type APIKeyRepository struct {
+	db *transaction.DB
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{db: transaction.NewDB(db)}
+}
+
+// CreateAPIKey сохраняет новый API ключ
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	query := `
+		INSERT INTO api_keys (api_key_id, name, key_prefix, key_hash, scopes, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		key.Scopes,
+		key.CreatedBy,
+		key.CreatedAt,
+		key.UpdatedAt,
+	)
+
+	if err != nil {
+		if pgerr.IsUniqueViolation(err, keyPrefixUniqueConstraint) {
+			return ErrAPIKeyAlreadyExists
+		}
+
+		return fmt.Errorf("failed to create api key: %w", pgerr.Wrap(err))
+	}
+
+	return nil
+}
+
+// GetAPIKey получает API ключ по ID
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE api_key_id = $1`
+
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key by id: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetAPIKeyByPrefix получает API ключ по публичному префиксу
+func (r *APIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_prefix = $1`
+
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, prefix))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key by prefix: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetAPIKeys возвращает все API ключи, сначала новые
+func (r *APIKeyRepository) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RotateAPIKey заменяет префикс и хеш действующего ключа, старое значение ключа перестает работать
+func (r *APIKeyRepository) RotateAPIKey(ctx context.Context, key *models.APIKey) error {
+	query := `
+		UPDATE api_keys
+		SET key_prefix = $2, key_hash = $3, updated_at = $4
+		WHERE api_key_id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, key.ID, key.Prefix, key.Hash, key.UpdatedAt)
+	if err != nil {
+		if pgerr.IsUniqueViolation(err, keyPrefixUniqueConstraint) {
+			return ErrAPIKeyAlreadyExists
+		}
+
+		return fmt.Errorf("failed to rotate api key: %w", pgerr.Wrap(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// RevokeAPIKey отзывает ключ, повторный отзыв не меняет время отзыва
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, $2), updated_at = $2
+		WHERE api_key_id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, id, revokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// TouchAPIKey обновляет время последнего использования ключа не чаще раза в lastUsedPrecision
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = $2
+		WHERE api_key_id = $1 AND (last_used_at IS NULL OR last_used_at < $3)
+	`
+
+	_, err := r.db.Exec(ctx, query, id, usedAt, usedAt.Add(-lastUsedPrecision))
+	if err != nil {
+		return fmt.Errorf("failed to update api key last used time: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		&key.Scopes,
+		&key.CreatedBy,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,344 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrApplicationNotFound       = errors.New("application not found")
+	ErrApplicationAlreadyExists  = errors.New("application already exists")
+	ErrApplicationStatusConflict = errors.New("application status was changed concurrently")
+)
+
+// Условия выборки заявок по одной из сторон
+const (
+	employeeApplications = "a.employee_id = $1"
+	employerApplications = "v.employer_id = $1"
+)
+
+type ApplicationRepository struct {
+	db *transaction.DB
+}
+
+func NewApplicationRepository(db *pgxpool.Pool) *ApplicationRepository {
+	return &ApplicationRepository{db: transaction.NewDB(db)}
+}
+
+// CreateApplication создает заявку вместе с первой записью истории в одной транзакции
+func (r *ApplicationRepository) CreateApplication(ctx context.Context, application *models.Application, transition *models.ApplicationTransition) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO applications (application_id, employee_id, vacancy_id, resume_id, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (employee_id, vacancy_id) DO NOTHING
+	`
+
+	tag, err := tx.Exec(ctx, query,
+		application.ID,
+		application.EmployeeID,
+		application.VacancyID,
+		application.ResumeID,
+		application.Status,
+		application.CreatedAt,
+		application.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create application: %w", pgerr.Wrap(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrApplicationAlreadyExists
+	}
+
+	if err = insertTransition(ctx, tx, transition); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetApplication получает заявку по ID
+func (r *ApplicationRepository) GetApplication(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	query := `
+		SELECT application_id, employee_id, vacancy_id, resume_id, status, created_at, updated_at
+		FROM applications
+		WHERE application_id = $1
+	`
+
+	application := &models.Application{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&application.ID,
+		&application.EmployeeID,
+		&application.VacancyID,
+		&application.ResumeID,
+		&application.Status,
+		&application.CreatedAt,
+		&application.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("failed to get application by id: %w", err)
+	}
+
+	return application, nil
+}
+
+// GetApplicationHistory получает историю смены статусов заявки в хронологическом порядке
+func (r *ApplicationRepository) GetApplicationHistory(ctx context.Context, id uuid.UUID) ([]models.ApplicationTransition, error) {
+	query := `
+		SELECT id, application_id, from_status, to_status, actor, comment, created_at
+		FROM application_status_history
+		WHERE application_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]models.ApplicationTransition, 0)
+	for rows.Next() {
+		var transition models.ApplicationTransition
+		err := rows.Scan(
+			&transition.ID,
+			&transition.ApplicationID,
+			&transition.FromStatus,
+			&transition.ToStatus,
+			&transition.Actor,
+			&transition.Comment,
+			&transition.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan application transition: %w", err)
+		}
+		history = append(history, transition)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating application history: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetApplicationsByEmployee получает страницу заявок сотрудника
+func (r *ApplicationRepository) GetApplicationsByEmployee(ctx context.Context, employeeID uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error) {
+	return r.getApplications(ctx, employeeApplications, employeeID, filter, pagination)
+}
+
+// GetApplicationsByEmployer получает страницу заявок на вакансии работодателя
+func (r *ApplicationRepository) GetApplicationsByEmployer(ctx context.Context, employerID uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error) {
+	return r.getApplications(ctx, employerApplications, employerID, filter, pagination)
+}
+
+// getApplications получает страницу заявок по условию owner с фильтром по статусу и вакансии
+func (r *ApplicationRepository) getApplications(ctx context.Context, owner string, id uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error) {
+	var (
+		status    *string
+		vacancyID *uuid.UUID
+	)
+	if filter != nil {
+		status = filter.Status
+		vacancyID = filter.VacancyID
+	}
+
+	conditions := fmt.Sprintf(`
+		WHERE %s
+			AND ($2::varchar IS NULL OR a.status = $2)
+			AND ($3::uuid IS NULL OR a.vacancy_id = $3)
+	`, owner)
+
+	query := `
+		SELECT a.application_id, a.employee_id, a.vacancy_id, a.resume_id, a.status, a.created_at, a.updated_at,
+			COUNT(*) OVER() AS total
+		FROM applications a
+		JOIN vacancies v ON v.vacansie_id = a.vacancy_id
+	` + conditions + `
+		ORDER BY a.updated_at DESC, a.application_id
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.db.Query(ctx, query, id, status, vacancyID, pagination.Limit(), pagination.Offset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications: %w", err)
+	}
+	defer rows.Close()
+
+	var total int
+	applications := make([]models.Application, 0)
+	for rows.Next() {
+		var application models.Application
+		err := rows.Scan(
+			&application.ID,
+			&application.EmployeeID,
+			&application.VacancyID,
+			&application.ResumeID,
+			&application.Status,
+			&application.CreatedAt,
+			&application.UpdatedAt,
+			&total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan application: %w", err)
+		}
+		applications = append(applications, application)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating applications: %w", err)
+	}
+
+	// Страница за пределами выборки не содержит строк, поэтому общее количество считаем отдельно
+	if len(applications) == 0 && pagination.Offset() > 0 {
+		countQuery := `
+			SELECT COUNT(*)
+			FROM applications a
+			JOIN vacancies v ON v.vacansie_id = a.vacancy_id
+		` + conditions
+
+		err = r.db.QueryRow(ctx, countQuery, id, status, vacancyID).Scan(&total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count applications: %w", err)
+		}
+	}
+
+	return &models.ApplicationList{
+		Applications: applications,
+		Pagination:   pagination,
+		Total:        total,
+	}, nil
+}
+
+// UpdateApplicationStatus меняет статус заявки и записывает переход в историю в одной транзакции.
+// Статус меняется, только если он не изменился с момента чтения (transition.FromStatus),
+// иначе возвращается ErrApplicationStatusConflict.
+func (r *ApplicationRepository) UpdateApplicationStatus(ctx context.Context, application *models.Application, transition *models.ApplicationTransition) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE applications
+		SET status = $2, updated_at = $3
+		WHERE application_id = $1 AND status = $4
+	`
+
+	tag, err := tx.Exec(ctx, query,
+		application.ID,
+		application.Status,
+		application.UpdatedAt,
+		transition.FromStatus,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update application status: %w", pgerr.Wrap(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrApplicationStatusConflict
+	}
+
+	if err = insertTransition(ctx, tx, transition); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RejectOpenApplicationsByEmployee отклоняет незавершенные заявки сотрудника от его имени
+func (r *ApplicationRepository) RejectOpenApplicationsByEmployee(ctx context.Context, employeeID uuid.UUID, comment string) (int64, error) {
+	return r.rejectOpenApplications(ctx, employeeApplications, employeeID, models.ApplicationActorEmployee, comment)
+}
+
+// RejectOpenApplicationsByEmployer отклоняет незавершенные заявки на вакансии работодателя от его имени
+func (r *ApplicationRepository) RejectOpenApplicationsByEmployer(ctx context.Context, employerID uuid.UUID, comment string) (int64, error) {
+	return r.rejectOpenApplications(ctx, employerApplications, employerID, models.ApplicationActorEmployer, comment)
+}
+
+// rejectOpenApplications переводит заявки по условию owner из незавершенных статусов в rejected
+// и записывает переходы в историю одним запросом
+func (r *ApplicationRepository) rejectOpenApplications(ctx context.Context, owner string, id uuid.UUID, actor, comment string) (int64, error) {
+	query := `
+		WITH open_applications AS (
+			SELECT a.application_id, a.status
+			FROM applications a
+			JOIN vacancies v ON v.vacansie_id = a.vacancy_id
+			WHERE ` + owner + `
+				AND a.status NOT IN ($2, $3)
+			FOR UPDATE OF a
+		), rejected AS (
+			UPDATE applications a
+			SET status = $3, updated_at = NOW()
+			FROM open_applications o
+			WHERE a.application_id = o.application_id
+			RETURNING a.application_id, o.status AS from_status
+		)
+		INSERT INTO application_status_history (application_id, from_status, to_status, actor, comment, created_at)
+		SELECT application_id, from_status, $3, $4::varchar, $5::text, NOW()
+		FROM rejected
+	`
+
+	tag, err := r.db.Exec(ctx, query, id,
+		models.ApplicationStatusHired,
+		models.ApplicationStatusRejected,
+		actor,
+		comment,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reject open applications: %w", pgerr.Wrap(err))
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// insertTransition записывает переход статуса в историю заявки
+func insertTransition(ctx context.Context, tx pgx.Tx, transition *models.ApplicationTransition) error {
+	query := `
+		INSERT INTO application_status_history (id, application_id, from_status, to_status, actor, comment, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		transition.ID,
+		transition.ApplicationID,
+		transition.FromStatus,
+		transition.ToStatus,
+		transition.Actor,
+		transition.Comment,
+		transition.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create application transition: %w", pgerr.Wrap(err))
+	}
+
+	return nil
+}
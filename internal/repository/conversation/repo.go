@@ -0,0 +1,97 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"jobot/internal/repository/transaction"
+	"jobot/internal/service/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrConversationNotFound = errors.New("conversation not found")
+
+type ConversationRepository struct {
+	db *transaction.DB
+}
+
+func NewConversationRepository(db *pgxpool.Pool) *ConversationRepository {
+	return &ConversationRepository{db: transaction.NewDB(db)}
+}
+
+// GetConversation получает диалог чата, в том числе с истекшим таймаутом
+func (r *ConversationRepository) GetConversation(ctx context.Context, tgChatID string) (*models.Conversation, error) {
+	query := `
+		SELECT tg_chat_id, flow, step, draft, expires_at, created_at, updated_at
+		FROM conversations
+		WHERE tg_chat_id = $1
+	`
+
+	conversation := &models.Conversation{}
+	err := r.db.QueryRow(ctx, query, tgChatID).Scan(
+		&conversation.TgChatID,
+		&conversation.Flow,
+		&conversation.Step,
+		&conversation.Draft,
+		&conversation.ExpiresAt,
+		&conversation.CreatedAt,
+		&conversation.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// SaveConversation сохраняет диалог чата, заменяя прежний
+func (r *ConversationRepository) SaveConversation(ctx context.Context, conversation *models.Conversation) error {
+	query := `
+		INSERT INTO conversations (tg_chat_id, flow, step, draft, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tg_chat_id) DO UPDATE SET
+			flow = EXCLUDED.flow,
+			step = EXCLUDED.step,
+			draft = EXCLUDED.draft,
+			expires_at = EXCLUDED.expires_at,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		conversation.TgChatID,
+		conversation.Flow,
+		conversation.Step,
+		conversation.Draft,
+		conversation.ExpiresAt,
+		conversation.CreatedAt,
+		conversation.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save conversation: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteConversation удаляет диалог чата вместе с черновиком
+func (r *ConversationRepository) DeleteConversation(ctx context.Context, tgChatID string) error {
+	query := `DELETE FROM conversations WHERE tg_chat_id = $1`
+
+	result, err := r.db.Exec(ctx, query, tgChatID)
+	if err != nil {
+		return fmt.Errorf("failed to delete conversation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrConversationNotFound
+	}
+
+	return nil
+}
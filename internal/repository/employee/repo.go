@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
 	"jobot/internal/service/models"
 
 	"github.com/google/uuid"
@@ -17,12 +20,17 @@ var (
 	ErrEmployeeAlreadyExists = errors.New("employee already exists")
 )
 
+// Ограничения таблицы employees
+const (
+	userIDUniqueConstraint = "employees_user_id_key" // у пользователя может быть только один профиль сотрудника
+)
+
 type EmployeeRepository struct {
-	db *pgxpool.Pool
+	db *transaction.DB
 }
 
 func NewEmployeeRepository(db *pgxpool.Pool) *EmployeeRepository {
-	return &EmployeeRepository{db: db}
+	return &EmployeeRepository{db: transaction.NewDB(db)}
 }
 
 // CreateEmployee создает нового сотрудника в БД
@@ -41,11 +49,11 @@ func (r *EmployeeRepository) CreateEmployee(ctx context.Context, employee *model
 	)
 
 	if err != nil {
-		if err.Error() == "duplicate key value violates unique constraint" {
+		if pgerr.IsUniqueViolation(err, userIDUniqueConstraint) {
 			return ErrEmployeeAlreadyExists
 		}
 
-		return fmt.Errorf("failed to create employee: %w", err)
+		return fmt.Errorf("failed to create employee: %w", pgerr.Wrap(err))
 	}
 
 	return nil
@@ -54,7 +62,7 @@ func (r *EmployeeRepository) CreateEmployee(ctx context.Context, employee *model
 // GetEmployee получает сотрудника по ID
 func (r *EmployeeRepository) GetEmployee(ctx context.Context, id uuid.UUID) (*models.Employee, error) {
 	query := `
-		SELECT employee_id, user_id, tags, created_at, updated_at
+		SELECT employee_id, user_id, tags, created_at, updated_at, archived_at
 		FROM employees
 		WHERE employee_id = $1
 	`
@@ -66,6 +74,7 @@ func (r *EmployeeRepository) GetEmployee(ctx context.Context, id uuid.UUID) (*mo
 		&employee.Tags,
 		&employee.CreatedAt,
 		&employee.UpdatedAt,
+		&employee.ArchivedAt,
 	)
 
 	if err != nil {
@@ -81,7 +90,7 @@ func (r *EmployeeRepository) GetEmployee(ctx context.Context, id uuid.UUID) (*mo
 // GetEmployeeByUserID получает сотрудника по User ID
 func (r *EmployeeRepository) GetEmployeeByUserID(ctx context.Context, userID uuid.UUID) (*models.Employee, error) {
 	query := `
-		SELECT employee_id, user_id, tags, created_at, updated_at
+		SELECT employee_id, user_id, tags, created_at, updated_at, archived_at
 		FROM employees
 		WHERE user_id = $1
 	`
@@ -93,6 +102,7 @@ func (r *EmployeeRepository) GetEmployeeByUserID(ctx context.Context, userID uui
 		&employee.Tags,
 		&employee.CreatedAt,
 		&employee.UpdatedAt,
+		&employee.ArchivedAt,
 	)
 
 	if err != nil {
@@ -105,6 +115,26 @@ func (r *EmployeeRepository) GetEmployeeByUserID(ctx context.Context, userID uui
 	return employee, nil
 }
 
+// SetEmployeeArchived архивирует профиль сотрудника (archivedAt != nil) или восстанавливает его (archivedAt == nil)
+func (r *EmployeeRepository) SetEmployeeArchived(ctx context.Context, employeeID uuid.UUID, archivedAt *time.Time) error {
+	query := `
+		UPDATE employees
+		SET archived_at = $2, updated_at = NOW()
+		WHERE employee_id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, employeeID, archivedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set employee archived: %w", pgerr.Wrap(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	return nil
+}
+
 // UpdateEmployee обновляет данные сотрудника
 func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, employee *models.Employee) error {
 	query := `
@@ -120,7 +150,7 @@ func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, employee *model
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update employee: %w", err)
+		return fmt.Errorf("failed to update employee: %w", pgerr.Wrap(err))
 	}
 
 	rowsAffected := result.RowsAffected()
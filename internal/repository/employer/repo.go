@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
 	"jobot/internal/service/models"
 
 	"github.com/google/uuid"
@@ -17,12 +20,17 @@ var (
 	ErrEmployerAlreadyExists = errors.New("employer already exists")
 )
 
+// Ограничения таблицы employers
+const (
+	userIDUniqueConstraint = "employers_user_id_key" // у пользователя может быть только один профиль работодателя
+)
+
 type EmployerRepository struct {
-	db *pgxpool.Pool
+	db *transaction.DB
 }
 
 func NewEmployerRepository(db *pgxpool.Pool) *EmployerRepository {
-	return &EmployerRepository{db: db}
+	return &EmployerRepository{db: transaction.NewDB(db)}
 }
 
 // CreateEmployer создает нового работодателя в БД
@@ -45,11 +53,11 @@ func (r *EmployerRepository) CreateEmployer(ctx context.Context, employer *model
 	)
 
 	if err != nil {
-		if err.Error() == "duplicate key value violates unique constraint" {
+		if pgerr.IsUniqueViolation(err, userIDUniqueConstraint) {
 			return ErrEmployerAlreadyExists
 		}
 
-		return fmt.Errorf("failed to create employer: %w", err)
+		return fmt.Errorf("failed to create employer: %w", pgerr.Wrap(err))
 	}
 
 	return nil
@@ -58,7 +66,7 @@ func (r *EmployerRepository) CreateEmployer(ctx context.Context, employer *model
 // GetEmployer получает работодателя по ID
 func (r *EmployerRepository) GetEmployer(ctx context.Context, id uuid.UUID) (*models.Employer, error) {
 	query := `
-		SELECT employer_id, user_id, company_name, company_description, company_website, company_location, company_size, created_at, updated_at
+		SELECT employer_id, user_id, company_name, company_description, company_website, company_location, company_size, created_at, updated_at, archived_at
 		FROM employers
 		WHERE employer_id = $1
 	`
@@ -74,6 +82,7 @@ func (r *EmployerRepository) GetEmployer(ctx context.Context, id uuid.UUID) (*mo
 		&employer.CompanySize,
 		&employer.CreatedAt,
 		&employer.UpdatedAt,
+		&employer.ArchivedAt,
 	)
 
 	if err != nil {
@@ -89,7 +98,7 @@ func (r *EmployerRepository) GetEmployer(ctx context.Context, id uuid.UUID) (*mo
 // GetEmployerByUserID получает работодателя по User ID
 func (r *EmployerRepository) GetEmployerByUserID(ctx context.Context, userID uuid.UUID) (*models.Employer, error) {
 	query := `
-		SELECT employer_id, user_id, company_name, company_description, company_website, company_location, company_size, created_at, updated_at
+		SELECT employer_id, user_id, company_name, company_description, company_website, company_location, company_size, created_at, updated_at, archived_at
 		FROM employers
 		WHERE user_id = $1
 	`
@@ -105,6 +114,7 @@ func (r *EmployerRepository) GetEmployerByUserID(ctx context.Context, userID uui
 		&employer.CompanySize,
 		&employer.CreatedAt,
 		&employer.UpdatedAt,
+		&employer.ArchivedAt,
 	)
 
 	if err != nil {
@@ -117,6 +127,55 @@ func (r *EmployerRepository) GetEmployerByUserID(ctx context.Context, userID uui
 	return employer, nil
 }
 
+// HasCandidate проверяет, что сотрудник employeeID лайкнул активную вакансию работодателя employerID
+// или откликнулся на нее
+func (r *EmployerRepository) HasCandidate(ctx context.Context, employerID, employeeID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM vacancies v
+			WHERE v.employer_id = $1 AND v.archived_at IS NULL
+				AND (
+					EXISTS (
+						SELECT 1 FROM reactions r
+						WHERE r.vacancy_id = v.vacansie_id AND r.employee_id = $2 AND r.reaction = 'like'
+					)
+					OR EXISTS (
+						SELECT 1 FROM applications a
+						WHERE a.vacancy_id = v.vacansie_id AND a.employee_id = $2
+					)
+				)
+		)
+	`
+
+	var ok bool
+	if err := r.db.QueryRow(ctx, query, employerID, employeeID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("failed to check employer candidate: %w", err)
+	}
+
+	return ok, nil
+}
+
+// SetEmployerArchived архивирует профиль работодателя (archivedAt != nil) или восстанавливает его (archivedAt == nil)
+func (r *EmployerRepository) SetEmployerArchived(ctx context.Context, employerID uuid.UUID, archivedAt *time.Time) error {
+	query := `
+		UPDATE employers
+		SET archived_at = $2, updated_at = NOW()
+		WHERE employer_id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, employerID, archivedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set employer archived: %w", pgerr.Wrap(err))
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrEmployerNotFound
+	}
+
+	return nil
+}
+
 // UpdateEmployer обновляет данные работодателя
 func (r *EmployerRepository) UpdateEmployer(ctx context.Context, employer *models.Employer) error {
 	query := `
@@ -136,7 +195,7 @@ func (r *EmployerRepository) UpdateEmployer(ctx context.Context, employer *model
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update employer: %w", err)
+		return fmt.Errorf("failed to update employer: %w", pgerr.Wrap(err))
 	}
 
 	rowsAffected := result.RowsAffected()
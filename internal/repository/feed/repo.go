@@ -0,0 +1,179 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	"jobot/internal/repository/transaction"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// recencyHalfLifeSeconds - возраст вакансии, при котором бонус за свежесть уменьшается вдвое (7 дней).
+// Бонус всегда меньше единицы, поэтому свежесть упорядочивает только вакансии с одинаковым числом совпавших тегов.
+const recencyHalfLifeSeconds = 7 * 24 * 60 * 60
+
+// employeeVacancyCandidates - активные вакансии, пересекающиеся по тегам с сотрудником $1, без уже оцененных им.
+// Если у сотрудника нет тегов, подходят все вакансии.
+const employeeVacancyCandidates = `
+	SELECT v.vacansie_id, v.employer_id, v.tags, v.title, v.description, v.location, v.salary, v.created_at, v.updated_at,
+		ARRAY(SELECT unnest(v.tags) INTERSECT SELECT unnest(e.tags)) AS matched_tags
+	FROM employees e
+	JOIN vacancies v ON cardinality(e.tags) = 0 OR v.tags && e.tags
+	WHERE e.employee_id = $1
+		AND v.archived_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM reactions r
+			WHERE r.employee_id = e.employee_id AND r.vacancy_id = v.vacansie_id
+		)
+`
+
+// vacancyEmployeeCandidates - активные сотрудники, пересекающиеся по тегам с вакансией $1.
+// Если у вакансии нет тегов, подходят все активные сотрудники. Архивные профили сотрудников не подходят.
+const vacancyEmployeeCandidates = `
+	SELECT e.employee_id, e.user_id, e.tags, e.created_at, e.updated_at,
+		ARRAY(SELECT unnest(e.tags) INTERSECT SELECT unnest(v.tags)) AS matched_tags,
+		res.resume_id
+	FROM vacancies v
+	JOIN employees e ON cardinality(v.tags) = 0 OR e.tags && v.tags
+	JOIN users u ON u.id = e.user_id AND u.is_active
+	LEFT JOIN resumes res ON res.employee_id = e.employee_id
+	WHERE v.vacansie_id = $1
+		AND e.archived_at IS NULL
+`
+
+type FeedRepository struct {
+	db *transaction.DB
+}
+
+func NewFeedRepository(db *pgxpool.Pool) *FeedRepository {
+	return &FeedRepository{db: transaction.NewDB(db)}
+}
+
+// GetEmployeeVacancyFeed получает страницу вакансий, рекомендованных сотруднику.
+// Вакансии ранжируются по количеству совпавших тегов, при равенстве - по свежести.
+func (r *FeedRepository) GetEmployeeVacancyFeed(ctx context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.VacancyFeed, error) {
+	query := `
+		SELECT vacansie_id, employer_id, tags, title, description, location, salary, created_at, updated_at,
+			matched_tags,
+			(cardinality(matched_tags) + 1.0 / (1 + EXTRACT(EPOCH FROM NOW() - created_at) / $4))::float8 AS score,
+			COUNT(*) OVER() AS total
+		FROM (` + employeeVacancyCandidates + `) AS candidates
+		ORDER BY score DESC, created_at DESC, vacansie_id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, employeeID, pagination.Limit(), pagination.Offset(), recencyHalfLifeSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee vacancy feed: %w", err)
+	}
+	defer rows.Close()
+
+	var total int
+	items := make([]models.VacancyFeedItem, 0)
+	for rows.Next() {
+		var item models.VacancyFeedItem
+		err := rows.Scan(
+			&item.VacansieID,
+			&item.EmployerID,
+			&item.Tags,
+			&item.Title,
+			&item.Description,
+			&item.Location,
+			&item.Salary,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+			&item.MatchedTags,
+			&item.Score,
+			&total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vacancy feed item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating vacancy feed: %w", err)
+	}
+
+	// Страница за пределами ленты не содержит строк, поэтому общее количество считаем отдельно
+	if len(items) == 0 && pagination.Offset() > 0 {
+		countQuery := `SELECT COUNT(*) FROM (` + employeeVacancyCandidates + `) AS candidates`
+
+		err = r.db.QueryRow(ctx, countQuery, employeeID).Scan(&total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count employee vacancy feed: %w", err)
+		}
+	}
+
+	return &models.VacancyFeed{
+		Items:      items,
+		EmployeeID: employeeID,
+		Pagination: pagination,
+		Total:      total,
+	}, nil
+}
+
+// GetVacancyCandidates получает страницу сотрудников, подходящих под вакансию.
+// Кандидаты ранжируются по количеству совпавших тегов, затем кандидаты с резюме выше остальных.
+func (r *FeedRepository) GetVacancyCandidates(ctx context.Context, vacancyID uuid.UUID, pagination models.Pagination) (*models.CandidateList, error) {
+	query := `
+		SELECT employee_id, user_id, tags, created_at, updated_at,
+			matched_tags,
+			resume_id,
+			COUNT(*) OVER() AS total
+		FROM (` + vacancyEmployeeCandidates + `) AS candidates
+		ORDER BY cardinality(matched_tags) DESC, resume_id IS NOT NULL DESC, updated_at DESC, employee_id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, vacancyID, pagination.Limit(), pagination.Offset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vacancy candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var total int
+	candidates := make([]models.Candidate, 0)
+	for rows.Next() {
+		var candidate models.Candidate
+		err := rows.Scan(
+			&candidate.EmployeeID,
+			&candidate.UserID,
+			&candidate.Tags,
+			&candidate.CreatedAt,
+			&candidate.UpdatedAt,
+			&candidate.MatchedTags,
+			&candidate.ResumeID,
+			&total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan candidate: %w", err)
+		}
+		candidate.HasResume = candidate.ResumeID != nil
+		candidates = append(candidates, candidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating candidates: %w", err)
+	}
+
+	if len(candidates) == 0 && pagination.Offset() > 0 {
+		countQuery := `SELECT COUNT(*) FROM (` + vacancyEmployeeCandidates + `) AS candidates`
+
+		err = r.db.QueryRow(ctx, countQuery, vacancyID).Scan(&total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count vacancy candidates: %w", err)
+		}
+	}
+
+	return &models.CandidateList{
+		Candidates: candidates,
+		VacancyID:  vacancyID,
+		Pagination: pagination,
+		Total:      total,
+	}, nil
+}
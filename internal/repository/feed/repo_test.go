@@ -0,0 +1,220 @@
+package feed_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/repository/feed"
+	"jobot/internal/service/models"
+	"jobot/migrations"
+	"jobot/pkg/migrator"
+)
+
+// testDatabaseURLEnv - строка подключения к тестовой базе; без нее тесты с Postgres пропускаются
+const testDatabaseURLEnv = "JOBOT_TEST_DATABASE_URL"
+
+// newTestDB применяет миграции в отдельной схеме и возвращает пул, работающий в ней
+func newTestDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	url := os.Getenv(testDatabaseURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseURLEnv)
+	}
+
+	ctx := context.Background()
+	schema := fmt.Sprintf("feed_test_%d", time.Now().UnixNano())
+
+	admin, err := pgxpool.New(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(admin.Close)
+
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+pgx.Identifier{schema}.Sanitize())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+pgx.Identifier{schema}.Sanitize()+" CASCADE")
+	})
+
+	config, err := pgxpool.ParseConfig(url)
+	require.NoError(t, err)
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	m, err := migrator.New(pool, migrations.FS)
+	require.NoError(t, err)
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	return pool
+}
+
+// fixture создает записи в тестовой схеме
+type fixture struct {
+	t    *testing.T
+	pool *pgxpool.Pool
+	now  time.Time
+}
+
+func (f *fixture) exec(sql string, args ...any) {
+	f.t.Helper()
+
+	_, err := f.pool.Exec(context.Background(), sql, args...)
+	require.NoError(f.t, err)
+}
+
+func (f *fixture) user(role string, active bool) uuid.UUID {
+	id := uuid.New()
+	f.exec(`INSERT INTO users (id, tg_chat_id, is_active, role) VALUES ($1, $2, $3, $4)`, id, id.String(), active, role)
+
+	return id
+}
+
+func (f *fixture) employee(tags []string, active, archived bool) uuid.UUID {
+	id := uuid.New()
+
+	var archivedAt *time.Time
+	if archived {
+		archivedAt = &f.now
+	}
+
+	f.exec(`INSERT INTO employees (employee_id, user_id, tags, archived_at) VALUES ($1, $2, $3, $4)`,
+		id, f.user(models.UserRoleEmployee, active), tags, archivedAt)
+
+	return id
+}
+
+func (f *fixture) employer() uuid.UUID {
+	id := uuid.New()
+	f.exec(`
+		INSERT INTO employers (employer_id, user_id, company_name, company_description, company_location, company_size)
+		VALUES ($1, $2, 'Acme', 'Acme', 'Moscow', '10')
+	`, id, f.user(models.UserRoleEmployer, true))
+
+	return id
+}
+
+func (f *fixture) vacancy(employerID uuid.UUID, tags []string, age time.Duration, archived bool) uuid.UUID {
+	id := uuid.New()
+	createdAt := f.now.Add(-age)
+
+	var archivedAt *time.Time
+	if archived {
+		archivedAt = &f.now
+	}
+
+	f.exec(`
+		INSERT INTO vacancies (vacansie_id, employer_id, tags, title, description, location, salary, created_at, updated_at, archived_at)
+		VALUES ($1, $2, $3, 'Developer', 'Description', 'Moscow', '100', $4, $4, $5)
+	`, id, employerID, tags, createdAt, archivedAt)
+
+	return id
+}
+
+func (f *fixture) resume(employeeID uuid.UUID) {
+	f.exec(`INSERT INTO resumes (employee_id, tg_file_id) VALUES ($1, 'file')`, employeeID)
+}
+
+func (f *fixture) react(employeeID, vacancyID uuid.UUID, reaction string) {
+	f.exec(`INSERT INTO reactions (employee_id, vacancy_id, reaction) VALUES ($1, $2, $3)`, employeeID, vacancyID, reaction)
+}
+
+func TestGetEmployeeVacancyFeed(t *testing.T) {
+	pool := newTestDB(t)
+	f := &fixture{t: t, pool: pool, now: time.Now()}
+	repo := NewFeedRepository(pool)
+	ctx := context.Background()
+
+	day := 24 * time.Hour
+	employeeID := f.employee([]string{"go", "sql"}, true, false)
+	employerID := f.employer()
+
+	bothTags := f.vacancy(employerID, []string{"go", "sql", "k8s"}, 3*day, false)
+	fresh := f.vacancy(employerID, []string{"go"}, 0, false)
+	old := f.vacancy(employerID, []string{"go"}, 30*day, false)
+	f.vacancy(employerID, []string{"python"}, 0, false)
+	f.vacancy(employerID, []string{"go", "sql"}, 0, true)
+	f.react(employeeID, f.vacancy(employerID, []string{"go", "sql"}, 0, false), models.ReactionTypeLike)
+	f.react(employeeID, f.vacancy(employerID, []string{"go", "sql"}, 0, false), models.ReactionTypeDislike)
+
+	ids := func(feed *models.VacancyFeed) []uuid.UUID {
+		result := make([]uuid.UUID, 0, len(feed.Items))
+		for _, item := range feed.Items {
+			result = append(result, item.VacansieID)
+		}
+
+		return result
+	}
+
+	// Совпавшие теги важнее свежести, свежесть упорядочивает вакансии с равным числом совпадений
+	feed, err := repo.GetEmployeeVacancyFeed(ctx, employeeID, models.Pagination{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{bothTags, fresh, old}, ids(feed))
+	assert.Equal(t, 3, feed.Total)
+	assert.ElementsMatch(t, []string{"go", "sql"}, feed.Items[0].MatchedTags)
+	assert.Greater(t, feed.Items[0].Score, feed.Items[1].Score)
+	assert.Greater(t, feed.Items[1].Score, feed.Items[2].Score)
+
+	feed, err = repo.GetEmployeeVacancyFeed(ctx, employeeID, models.Pagination{Page: 2, PerPage: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{old}, ids(feed))
+	assert.Equal(t, 3, feed.Total)
+
+	// За пределами ленты страница пустая, но общее количество известно
+	feed, err = repo.GetEmployeeVacancyFeed(ctx, employeeID, models.Pagination{Page: 5, PerPage: 2})
+	require.NoError(t, err)
+	assert.Empty(t, feed.Items)
+	assert.Equal(t, 3, feed.Total)
+}
+
+func TestGetVacancyCandidates(t *testing.T) {
+	pool := newTestDB(t)
+	f := &fixture{t: t, pool: pool, now: time.Now()}
+	repo := NewFeedRepository(pool)
+	ctx := context.Background()
+
+	vacancyID := f.vacancy(f.employer(), []string{"go", "sql"}, 0, false)
+
+	withResume := f.employee([]string{"go", "sql"}, true, false)
+	f.resume(withResume)
+	withoutResume := f.employee([]string{"go", "sql", "k8s"}, true, false)
+	oneTag := f.employee([]string{"go"}, true, false)
+	f.resume(oneTag)
+	f.employee([]string{"python"}, true, false)
+	f.employee([]string{"go", "sql"}, false, false)
+	f.employee([]string{"go", "sql"}, true, true)
+
+	ids := func(list *models.CandidateList) []uuid.UUID {
+		result := make([]uuid.UUID, 0, len(list.Candidates))
+		for _, candidate := range list.Candidates {
+			result = append(result, candidate.EmployeeID)
+		}
+
+		return result
+	}
+
+	list, err := repo.GetVacancyCandidates(ctx, vacancyID, models.Pagination{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{withResume, withoutResume, oneTag}, ids(list))
+	assert.Equal(t, 3, list.Total)
+	assert.True(t, list.Candidates[0].HasResume)
+	assert.False(t, list.Candidates[1].HasResume)
+
+	list, err = repo.GetVacancyCandidates(ctx, vacancyID, models.Pagination{Page: 3, PerPage: 2})
+	require.NoError(t, err)
+	assert.Empty(t, list.Candidates)
+	assert.Equal(t, 3, list.Total)
+}
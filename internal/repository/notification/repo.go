@@ -0,0 +1,345 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrNotificationNotFound            = errors.New("notification not found")
+	ErrNotificationPreferencesNotFound = errors.New("notification preferences not found")
+)
+
+// Цели ON CONFLICT для вставки уведомлений. Должны совпадать с частичными уникальными индексами
+// idx_notifications_new_vacancy и idx_notifications_candidate_liked из миграции 017.
+const (
+	NewVacancyConflictTarget     = "(user_id, vacancy_id) WHERE type = 'new_vacancy'"
+	CandidateLikedConflictTarget = "(vacancy_id, employee_id) WHERE type = 'candidate_liked'"
+)
+
+type NotificationRepository struct {
+	db *transaction.DB
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{db: transaction.NewDB(db)}
+}
+
+// EnqueueNewVacancyNotifications ставит в очередь уведомления о вакансии активным сотрудникам,
+// чьи теги пересекаются с тегами вакансии и у кого не выключены уведомления о новых вакансиях.
+// Повторный вызов для той же вакансии не создает дубликатов. Возвращает число новых уведомлений.
+func (r *NotificationRepository) EnqueueNewVacancyNotifications(ctx context.Context, vacancyID uuid.UUID, now time.Time) (int64, error) {
+	query := `
+		INSERT INTO notifications (notification_id, user_id, type, vacancy_id, status, next_attempt_at, created_at, updated_at)
+		SELECT gen_random_uuid(), u.id, $2, v.vacansie_id, $3, $4, $4, $4
+		FROM vacancies v
+		JOIN employees e ON e.tags && v.tags AND e.archived_at IS NULL
+		JOIN users u ON u.id = e.user_id AND u.is_active
+		LEFT JOIN notification_preferences p ON p.user_id = u.id
+		WHERE v.vacansie_id = $1 AND v.archived_at IS NULL AND COALESCE(p.new_vacancies, TRUE)
+		ON CONFLICT ` + NewVacancyConflictTarget + ` DO NOTHING
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		vacancyID,
+		models.NotificationTypeNewVacancy,
+		models.NotificationStatusPending,
+		now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue new vacancy notifications: %w", pgerr.Wrap(err))
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// EnqueueCandidateLikedNotification ставит в очередь уведомление работодателю вакансии о том, что кандидат ее лайкнул.
+// Получатель определяется по вакансии: работодатель -> пользователь. Уведомление не создается, если вакансия
+// или работодатель архивированы, пользователь неактивен или выключил такие уведомления, а также если
+// о лайке этого кандидата на эту вакансию уже уведомляли. Возвращает true, если уведомление создано.
+func (r *NotificationRepository) EnqueueCandidateLikedNotification(ctx context.Context, employeeID, vacancyID uuid.UUID, now time.Time) (bool, error) {
+	query := `
+		INSERT INTO notifications (notification_id, user_id, type, vacancy_id, employee_id, status, next_attempt_at, created_at, updated_at)
+		SELECT gen_random_uuid(), u.id, $3, v.vacansie_id, $2, $4, $5, $5, $5
+		FROM vacancies v
+		JOIN employers er ON er.employer_id = v.employer_id AND er.archived_at IS NULL
+		JOIN users u ON u.id = er.user_id AND u.is_active
+		LEFT JOIN notification_preferences p ON p.user_id = u.id
+		WHERE v.vacansie_id = $1 AND v.archived_at IS NULL AND COALESCE(p.candidate_likes, TRUE)
+		ON CONFLICT ` + CandidateLikedConflictTarget + ` DO NOTHING
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		vacancyID,
+		employeeID,
+		models.NotificationTypeCandidateLiked,
+		models.NotificationStatusPending,
+		now,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue candidate liked notification: %w", pgerr.Wrap(err))
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// ClaimDueNotifications забирает до limit ожидающих уведомлений, срок доставки которых наступил к now,
+// и откладывает их до leaseUntil: другие обработчики их не получат, а если доставка прервется,
+// уведомления вернутся в работу после leaseUntil. Уведомления загружаются с настройками, вакансией,
+// кандидатом и временем последнего отправленного получателю уведомления того же типа.
+func (r *NotificationRepository) ClaimDueNotifications(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Notification, error) {
+	query := `
+		WITH due AS (
+			SELECT notification_id
+			FROM notifications
+			WHERE status = $1 AND next_attempt_at <= $2
+			ORDER BY next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE notifications n
+			SET next_attempt_at = $3
+			FROM due
+			WHERE n.notification_id = due.notification_id
+			RETURNING n.*
+		)
+		SELECT c.notification_id, c.user_id, u.tg_chat_id, c.type, c.vacancy_id, c.employee_id, c.status, c.attempts,
+			c.last_error, c.next_attempt_at, c.sent_at, c.created_at, c.updated_at,
+			COALESCE(p.new_vacancies, TRUE), COALESCE(p.candidate_likes, TRUE),
+			p.quiet_hours_start, p.quiet_hours_end, COALESCE(p.timezone, 'UTC'),
+			v.vacansie_id, v.employer_id, v.tags, v.title, v.description, v.location, v.salary, v.created_at, v.updated_at,
+			e.employee_id, COALESCE(eu.tg_user_name, ''), e.tags, rs.resume_id IS NOT NULL,
+			(
+				SELECT MAX(s.sent_at)
+				FROM notifications s
+				WHERE s.user_id = c.user_id AND s.type = c.type AND s.status = $5
+			)
+		FROM claimed c
+		JOIN users u ON u.id = c.user_id
+		LEFT JOIN notification_preferences p ON p.user_id = c.user_id
+		LEFT JOIN vacancies v ON v.vacansie_id = c.vacancy_id AND v.archived_at IS NULL
+		LEFT JOIN reactions r ON r.employee_id = c.employee_id AND r.vacancy_id = c.vacancy_id AND r.reaction = $6
+		LEFT JOIN employees e ON e.employee_id = r.employee_id AND e.archived_at IS NULL
+		LEFT JOIN users eu ON eu.id = e.user_id
+		LEFT JOIN resumes rs ON rs.employee_id = e.employee_id
+		ORDER BY c.next_attempt_at
+	`
+
+	rows, err := r.db.Query(ctx, query,
+		models.NotificationStatusPending,
+		now,
+		leaseUntil,
+		limit,
+		models.NotificationStatusSent,
+		models.ReactionTypeLike,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		notification, err := scanClaimedNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, *notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// UpdateNotificationDelivery сохраняет результат попытки доставки
+func (r *NotificationRepository) UpdateNotificationDelivery(ctx context.Context, notification *models.Notification) error {
+	query := `
+		UPDATE notifications
+		SET status = $2, attempts = $3, last_error = $4, next_attempt_at = $5, sent_at = $6, updated_at = $7
+		WHERE notification_id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		notification.ID,
+		notification.Status,
+		notification.Attempts,
+		notification.LastError,
+		notification.NextAttemptAt,
+		notification.SentAt,
+		notification.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update notification delivery: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+// GetNotificationPreferences получает настройки уведомлений пользователя
+func (r *NotificationRepository) GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	query := `
+		SELECT user_id, new_vacancies, candidate_likes, quiet_hours_start, quiet_hours_end, timezone, created_at, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1
+	`
+
+	preferences := &models.NotificationPreferences{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&preferences.UserID,
+		&preferences.NewVacancies,
+		&preferences.CandidateLikes,
+		&preferences.QuietHoursStart,
+		&preferences.QuietHoursEnd,
+		&preferences.Timezone,
+		&preferences.CreatedAt,
+		&preferences.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotificationPreferencesNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// SaveNotificationPreferences сохраняет настройки уведомлений пользователя, заменяя прежние
+func (r *NotificationRepository) SaveNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, new_vacancies, candidate_likes, quiet_hours_start, quiet_hours_end, timezone, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			new_vacancies = EXCLUDED.new_vacancies,
+			candidate_likes = EXCLUDED.candidate_likes,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			timezone = EXCLUDED.timezone,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		preferences.UserID,
+		preferences.NewVacancies,
+		preferences.CandidateLikes,
+		preferences.QuietHoursStart,
+		preferences.QuietHoursEnd,
+		preferences.Timezone,
+		preferences.CreatedAt,
+		preferences.UpdatedAt,
+	).Scan(&preferences.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", pgerr.Wrap(err))
+	}
+
+	return nil
+}
+
+func scanClaimedNotification(row pgx.Row) (*models.Notification, error) {
+	var (
+		notification = &models.Notification{}
+		preferences  = &notification.Preferences
+
+		vacancyID   *uuid.UUID
+		employerID  *uuid.UUID
+		tags        []string
+		title       *string
+		description *string
+		location    *string
+		salary      *string
+		createdAt   *time.Time
+		updatedAt   *time.Time
+
+		candidateID        *uuid.UUID
+		candidateUserName  string
+		candidateTags      []string
+		candidateHasResume bool
+	)
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.TgChatID,
+		&notification.Type,
+		&notification.VacancyID,
+		&notification.EmployeeID,
+		&notification.Status,
+		&notification.Attempts,
+		&notification.LastError,
+		&notification.NextAttemptAt,
+		&notification.SentAt,
+		&notification.CreatedAt,
+		&notification.UpdatedAt,
+		&preferences.NewVacancies,
+		&preferences.CandidateLikes,
+		&preferences.QuietHoursStart,
+		&preferences.QuietHoursEnd,
+		&preferences.Timezone,
+		&vacancyID,
+		&employerID,
+		&tags,
+		&title,
+		&description,
+		&location,
+		&salary,
+		&createdAt,
+		&updatedAt,
+		&candidateID,
+		&candidateUserName,
+		&candidateTags,
+		&candidateHasResume,
+		&notification.LastSentAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences.UserID = notification.UserID
+
+	// Кандидат убрал лайк или сменил роль
+	if candidateID != nil {
+		notification.Candidate = &models.NotificationCandidate{
+			EmployeeID: *candidateID,
+			TgUserName: candidateUserName,
+			Tags:       candidateTags,
+			HasResume:  candidateHasResume,
+		}
+	}
+
+	// Вакансия удалена или архивирована
+	if vacancyID == nil {
+		return notification, nil
+	}
+
+	notification.Vacancy = &models.Vacancy{
+		VacansieID:  *vacancyID,
+		EmployerID:  *employerID,
+		Tags:        tags,
+		Title:       *title,
+		Description: *description,
+		Location:    *location,
+		Salary:      *salary,
+		CreatedAt:   *createdAt,
+		UpdatedAt:   *updatedAt,
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,23 @@
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/repository/notification"
+	"jobot/migrations"
+)
+
+// Без уникального индекса с точно такими же колонками и условием Postgres отклоняет ON CONFLICT
+func TestConflictTargetsMatchMigrationIndexes(t *testing.T) {
+	t.Parallel()
+
+	content, err := migrations.FS.ReadFile("017_add_candidate_liked_notifications.sql")
+	require.NoError(t, err)
+
+	migration := string(content)
+	assert.Contains(t, migration, "idx_notifications_new_vacancy ON notifications"+NewVacancyConflictTarget+";")
+	assert.Contains(t, migration, "idx_notifications_candidate_liked ON notifications"+CandidateLikedConflictTarget+";")
+}
@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"jobot/internal/repository/transaction"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrOutboxEventNotFound = errors.New("outbox event not found")
+
+// AddEvent записывает событие в outbox в транзакции tx изменения, которое оно описывает:
+// событие сохраняется тогда и только тогда, когда фиксируется изменение.
+// Тип агрегата берется из типа события до точки, payload кодируется в JSON.
+func AddEvent(ctx context.Context, tx pgx.Tx, eventType string, aggregateID uuid.UUID, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event payload: %w", eventType, err)
+	}
+
+	aggregateType, _, _ := strings.Cut(eventType, ".")
+	now := time.Now()
+
+	query := `
+		INSERT INTO outbox_events (event_id, type, aggregate_type, aggregate_id, payload, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+	`
+
+	_, err = tx.Exec(ctx, query, uuid.New(), eventType, aggregateType, aggregateID, data, now)
+	if err != nil {
+		return fmt.Errorf("failed to add %s event to outbox: %w", eventType, err)
+	}
+
+	return nil
+}
+
+type OutboxRepository struct {
+	db *transaction.DB
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: transaction.NewDB(db)}
+}
+
+// ClaimEvents забирает до limit неотправленных событий, срок отправки которых наступил к now,
+// и откладывает их до leaseUntil: другие обработчики их не получат, а если отправка прервется,
+// события вернутся в работу после leaseUntil. События возвращаются в порядке создания.
+func (r *OutboxRepository) ClaimEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.OutboxEvent, error) {
+	query := `
+		WITH due AS (
+			SELECT event_id
+			FROM outbox_events
+			WHERE dispatched_at IS NULL AND next_attempt_at <= $1
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE outbox_events e
+		SET next_attempt_at = $2
+		FROM due
+		WHERE e.event_id = due.event_id
+		RETURNING e.event_id, e.type, e.aggregate_type, e.aggregate_id, e.payload, e.created_at,
+			e.delivered_sinks, e.attempts, e.last_error, e.next_attempt_at, e.dispatched_at
+	`
+
+	rows, err := r.db.Query(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.OutboxEvent{}
+	for rows.Next() {
+		var event models.OutboxEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.AggregateType,
+			&event.AggregateID,
+			&event.Payload,
+			&event.CreatedAt,
+			&event.DeliveredSinks,
+			&event.Attempts,
+			&event.LastError,
+			&event.NextAttemptAt,
+			&event.DispatchedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	slices.SortFunc(events, func(a, b models.OutboxEvent) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return events, nil
+}
+
+// UpdateEventDispatch сохраняет результат попытки отправки события
+func (r *OutboxRepository) UpdateEventDispatch(ctx context.Context, event *models.OutboxEvent) error {
+	query := `
+		UPDATE outbox_events
+		SET delivered_sinks = $2, attempts = $3, last_error = $4, next_attempt_at = $5, dispatched_at = $6
+		WHERE event_id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		event.ID,
+		event.DeliveredSinks,
+		event.Attempts,
+		event.LastError,
+		event.NextAttemptAt,
+		event.DispatchedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox event dispatch: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrOutboxEventNotFound
+	}
+
+	return nil
+}
+
+// DeleteDispatchedEvents удаляет события, отправленные раньше before. Возвращает число удаленных событий.
+func (r *OutboxRepository) DeleteDispatchedEvents(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM outbox_events WHERE dispatched_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete dispatched outbox events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,106 @@
+// Package pgerr классифицирует ошибки PostgreSQL по SQLSTATE, чтобы репозитории
+// превращали нарушения ограничений в типизированные ошибки, а не сравнивали текст ошибки.
+package pgerr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE коды нарушений ограничений целостности
+const (
+	UniqueViolationCode     = "23505"
+	ForeignKeyViolationCode = "23503"
+	CheckViolationCode      = "23514"
+)
+
+var (
+	ErrUniqueViolation     = errors.New("unique constraint violation")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrCheckViolation      = errors.New("check constraint violation")
+)
+
+// ConstraintError - нарушение ограничения целостности.
+// errors.Is сопоставляет его с Kind (ErrUniqueViolation, ErrForeignKeyViolation или ErrCheckViolation),
+// errors.As - с исходной *pgconn.PgError.
+type ConstraintError struct {
+	Kind       error
+	Constraint string
+	Table      string
+	pgErr      *pgconn.PgError
+}
+
+// Error не включает Detail из PostgreSQL, так как он содержит значения ключей
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Kind, e.Constraint)
+}
+
+func (e *ConstraintError) Unwrap() []error {
+	return []error{e.Kind, e.pgErr}
+}
+
+// Classify возвращает *ConstraintError, если err - нарушение unique, foreign key или check ограничения, иначе nil
+func Classify(err error) *ConstraintError {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	var kind error
+	switch pgErr.Code {
+	case UniqueViolationCode:
+		kind = ErrUniqueViolation
+	case ForeignKeyViolationCode:
+		kind = ErrForeignKeyViolation
+	case CheckViolationCode:
+		kind = ErrCheckViolation
+	default:
+		return nil
+	}
+
+	return &ConstraintError{
+		Kind:       kind,
+		Constraint: pgErr.ConstraintName,
+		Table:      pgErr.TableName,
+		pgErr:      pgErr,
+	}
+}
+
+// Wrap заменяет нарушение ограничения на *ConstraintError, остальные ошибки возвращает без изменений
+func Wrap(err error) error {
+	if constraintErr := Classify(err); constraintErr != nil {
+		return constraintErr
+	}
+
+	return err
+}
+
+// IsUniqueViolation сообщает, является ли err нарушением уникальности.
+// Если переданы имена ограничений, учитываются только они.
+func IsUniqueViolation(err error, constraints ...string) bool {
+	return isViolation(err, ErrUniqueViolation, constraints)
+}
+
+// IsForeignKeyViolation сообщает, является ли err нарушением внешнего ключа.
+// Если переданы имена ограничений, учитываются только они.
+func IsForeignKeyViolation(err error, constraints ...string) bool {
+	return isViolation(err, ErrForeignKeyViolation, constraints)
+}
+
+// IsCheckViolation сообщает, является ли err нарушением check ограничения.
+// Если переданы имена ограничений, учитываются только они.
+func IsCheckViolation(err error, constraints ...string) bool {
+	return isViolation(err, ErrCheckViolation, constraints)
+}
+
+func isViolation(err error, kind error, constraints []string) bool {
+	constraintErr := Classify(err)
+	if constraintErr == nil || constraintErr.Kind != kind {
+		return false
+	}
+
+	return len(constraints) == 0 || slices.Contains(constraints, constraintErr.Constraint)
+}
@@ -0,0 +1,54 @@
+package pgerr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/repository/pgerr"
+)
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("exec: %w", &pgconn.PgError{
+		Code:           UniqueViolationCode,
+		ConstraintName: "users_tg_chat_id_key",
+		TableName:      "users",
+		Detail:         "Key (tg_chat_id)=(123) already exists.",
+	})
+
+	constraintErr := Classify(err)
+	require.NotNil(t, constraintErr)
+	assert.Equal(t, "users_tg_chat_id_key", constraintErr.Constraint)
+	assert.Equal(t, "users", constraintErr.Table)
+	assert.NotContains(t, constraintErr.Error(), "123")
+
+	wrapped := Wrap(err)
+	assert.ErrorIs(t, wrapped, ErrUniqueViolation)
+
+	var pgErr *pgconn.PgError
+	assert.ErrorAs(t, wrapped, &pgErr)
+
+	assert.True(t, IsUniqueViolation(err))
+	assert.True(t, IsUniqueViolation(err, "users_tg_chat_id_key"))
+	assert.False(t, IsUniqueViolation(err, "users_pkey"))
+	assert.False(t, IsForeignKeyViolation(err))
+}
+
+func TestClassifyOtherErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, Classify(errors.New("duplicate key value violates unique constraint")))
+	assert.Nil(t, Classify(&pgconn.PgError{Code: "40001"}))
+
+	plain := errors.New("connection refused")
+	assert.Equal(t, plain, Wrap(plain))
+
+	assert.True(t, IsForeignKeyViolation(&pgconn.PgError{Code: ForeignKeyViolationCode, ConstraintName: "reactions_employee_id_fkey"}, "reactions_employee_id_fkey"))
+	assert.True(t, IsCheckViolation(&pgconn.PgError{Code: CheckViolationCode, ConstraintName: "users_role_check"}))
+}
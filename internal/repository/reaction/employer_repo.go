@@ -0,0 +1,240 @@
+package reaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"jobot/internal/repository/outbox"
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrEmployerReactionNotFound      = errors.New("employer reaction not found")
+	ErrEmployerReactionAlreadyExists = errors.New("employer reaction already exists")
+)
+
+// Ограничения таблицы employer_reactions
+const (
+	employerReactionUniqueConstraint = "employer_reactions_employee_id_vacancy_id_key" // одно решение работодателя по кандидату на вакансию
+)
+
+type EmployerReactionRepository struct {
+	db *transaction.DB
+}
+
+func NewEmployerReactionRepository(db *pgxpool.Pool) *EmployerReactionRepository {
+	return &EmployerReactionRepository{db: transaction.NewDB(db)}
+}
+
+// CreateEmployerReaction создает решение работодателя по кандидату вместе с событием employer_reaction.created
+func (r *EmployerReactionRepository) CreateEmployerReaction(ctx context.Context, reaction *models.EmployerReaction) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO employer_reactions (id, employer_id, employee_id, vacancy_id, decision, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = tx.Exec(ctx, query,
+		reaction.ID,
+		reaction.EmployerID,
+		reaction.EmployeeID,
+		reaction.VacancyID,
+		reaction.Decision,
+		reaction.CreatedAt,
+		reaction.UpdatedAt,
+	)
+
+	if err != nil {
+		if pgerr.IsUniqueViolation(err, employerReactionUniqueConstraint) {
+			return ErrEmployerReactionAlreadyExists
+		}
+
+		return fmt.Errorf("failed to create employer reaction: %w", pgerr.Wrap(err))
+	}
+
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventEmployerReactionCreated, reaction.ID, reaction); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmployerReaction получает решение работодателя по ID
+func (r *EmployerReactionRepository) GetEmployerReaction(ctx context.Context, id uuid.UUID) (*models.EmployerReaction, error) {
+	query := `
+		SELECT er.id, er.employer_id, er.employee_id, er.vacancy_id, er.decision, er.created_at, er.updated_at,
+			EXISTS (
+				SELECT 1 FROM matches m
+				WHERE m.employee_id = er.employee_id AND m.vacancy_id = er.vacancy_id
+			) AS matched
+		FROM employer_reactions er
+		WHERE er.id = $1
+	`
+
+	reaction := &models.EmployerReaction{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&reaction.ID,
+		&reaction.EmployerID,
+		&reaction.EmployeeID,
+		&reaction.VacancyID,
+		&reaction.Decision,
+		&reaction.CreatedAt,
+		&reaction.UpdatedAt,
+		&reaction.Matched,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmployerReactionNotFound
+		}
+		return nil, fmt.Errorf("failed to get employer reaction by id: %w", err)
+	}
+
+	return reaction, nil
+}
+
+// GetEmployerReactionsByEmployer получает решения работодателя, опционально отфильтрованные по решению и вакансии
+func (r *EmployerReactionRepository) GetEmployerReactionsByEmployer(ctx context.Context, employerID uuid.UUID, filter *models.EmployerReactionFilter) (*models.EmployerReactionList, error) {
+	query := `
+		SELECT er.id, er.employer_id, er.employee_id, er.vacancy_id, er.decision, er.created_at, er.updated_at,
+			EXISTS (
+				SELECT 1 FROM matches m
+				WHERE m.employee_id = er.employee_id AND m.vacancy_id = er.vacancy_id
+			) AS matched
+		FROM employer_reactions er
+		WHERE er.employer_id = $1
+			AND ($2::varchar IS NULL OR er.decision = $2)
+			AND ($3::uuid IS NULL OR er.vacancy_id = $3)
+		ORDER BY er.updated_at DESC
+	`
+
+	var (
+		decision  *string
+		vacancyID *uuid.UUID
+	)
+	if filter != nil {
+		decision = filter.Decision
+		vacancyID = filter.VacancyID
+	}
+
+	rows, err := r.db.Query(ctx, query, employerID, decision, vacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employer reactions by employer: %w", err)
+	}
+	defer rows.Close()
+
+	reactions := make([]models.EmployerReaction, 0)
+	for rows.Next() {
+		var reaction models.EmployerReaction
+		err := rows.Scan(
+			&reaction.ID,
+			&reaction.EmployerID,
+			&reaction.EmployeeID,
+			&reaction.VacancyID,
+			&reaction.Decision,
+			&reaction.CreatedAt,
+			&reaction.UpdatedAt,
+			&reaction.Matched,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employer reaction: %w", err)
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating employer reactions: %w", err)
+	}
+
+	return &models.EmployerReactionList{
+		Reactions:  reactions,
+		EmployerID: employerID,
+	}, nil
+}
+
+// UpdateEmployerReaction обновляет решение работодателя вместе с событием employer_reaction.updated
+func (r *EmployerReactionRepository) UpdateEmployerReaction(ctx context.Context, reaction *models.EmployerReaction) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE employer_reactions
+		SET decision = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		reaction.ID,
+		reaction.Decision,
+		reaction.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update employer reaction: %w", pgerr.Wrap(err))
+	}
+
+	rowsAffected := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrEmployerReactionNotFound
+	}
+
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventEmployerReactionUpdated, reaction.ID, reaction); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEmployerReaction удаляет решение работодателя вместе с событием employer_reaction.deleted
+func (r *EmployerReactionRepository) DeleteEmployerReaction(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `DELETE FROM employer_reactions WHERE id = $1 RETURNING employer_id, employee_id, vacancy_id`
+
+	var employerID, employeeID, vacancyID uuid.UUID
+	err = tx.QueryRow(ctx, query, id).Scan(&employerID, &employeeID, &vacancyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrEmployerReactionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete employer reaction: %w", err)
+	}
+
+	payload := map[string]uuid.UUID{"id": id, "employer_id": employerID, "employee_id": employeeID, "vacancy_id": vacancyID}
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventEmployerReactionDeleted, id, payload); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package reaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// mutualInterest - условие взаимного интереса: сотрудник лайкнул вакансию, работодатель принял сотрудника
+const mutualInterest = `
+	SELECT r.employee_id, r.vacancy_id, er.employer_id
+	FROM reactions r
+	JOIN employer_reactions er ON er.employee_id = r.employee_id AND er.vacancy_id = r.vacancy_id
+	WHERE r.employee_id = $1 AND r.vacancy_id = $2
+		AND r.reaction = 'like' AND er.decision = 'accept'
+`
+
+type MatchRepository struct {
+	db *transaction.DB
+}
+
+func NewMatchRepository(db *pgxpool.Pool) *MatchRepository {
+	return &MatchRepository{db: transaction.NewDB(db)}
+}
+
+// SyncMatch приводит матч пары сотрудник-вакансия в соответствие с реакциями обеих сторон:
+// создает матч, если обе стороны положительны, и удаляет его в противном случае.
+// Возвращает true, если после синхронизации матч существует.
+func (r *MatchRepository) SyncMatch(ctx context.Context, employeeID, vacancyID uuid.UUID) (bool, error) {
+	insertQuery := `
+		INSERT INTO matches (id, employee_id, vacancy_id, employer_id, created_at)
+		SELECT $3, employee_id, vacancy_id, employer_id, $4
+		FROM (` + mutualInterest + `) AS mutual
+		ON CONFLICT (employee_id, vacancy_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(ctx, insertQuery, employeeID, vacancyID, uuid.New(), time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to create match: %w", pgerr.Wrap(err))
+	}
+
+	deleteQuery := `
+		DELETE FROM matches
+		WHERE employee_id = $1 AND vacancy_id = $2
+			AND NOT EXISTS (` + mutualInterest + `)
+	`
+
+	_, err = r.db.Exec(ctx, deleteQuery, employeeID, vacancyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete match: %w", err)
+	}
+
+	var matched bool
+	err = r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM matches WHERE employee_id = $1 AND vacancy_id = $2)`, employeeID, vacancyID).Scan(&matched)
+	if err != nil {
+		return false, fmt.Errorf("failed to check match: %w", err)
+	}
+
+	return matched, nil
+}
+
+// GetMatchesByEmployee получает страницу матчей сотрудника
+func (r *MatchRepository) GetMatchesByEmployee(ctx context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.MatchList, error) {
+	return r.getMatches(ctx, "employee_id", employeeID, pagination)
+}
+
+// GetMatchesByEmployer получает страницу матчей по вакансиям работодателя
+func (r *MatchRepository) GetMatchesByEmployer(ctx context.Context, employerID uuid.UUID, pagination models.Pagination) (*models.MatchList, error) {
+	return r.getMatches(ctx, "employer_id", employerID, pagination)
+}
+
+// getMatches получает страницу матчей по одной из сторон, column - employee_id или employer_id
+func (r *MatchRepository) getMatches(ctx context.Context, column string, id uuid.UUID, pagination models.Pagination) (*models.MatchList, error) {
+	query := fmt.Sprintf(`
+		SELECT id, employee_id, vacancy_id, employer_id, created_at
+		FROM matches
+		WHERE %s = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`, column)
+
+	rows, err := r.db.Query(ctx, query, id, pagination.Limit(), pagination.Offset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches: %w", err)
+	}
+	defer rows.Close()
+
+	matches := make([]models.Match, 0)
+	for rows.Next() {
+		var match models.Match
+		err := rows.Scan(
+			&match.ID,
+			&match.EmployeeID,
+			&match.VacancyID,
+			&match.EmployerID,
+			&match.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan match: %w", err)
+		}
+		matches = append(matches, match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matches: %w", err)
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM matches WHERE %s = $1`, column)
+
+	err = r.db.QueryRow(ctx, countQuery, id).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count matches: %w", err)
+	}
+
+	return &models.MatchList{
+		Matches:    matches,
+		Pagination: pagination,
+		Total:      total,
+	}, nil
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 
+	"jobot/internal/repository/outbox"
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
 	"jobot/internal/service/models"
 
 	"github.com/google/uuid"
@@ -17,34 +20,55 @@ var (
 	ErrReactionAlreadyExists = errors.New("reaction already exists")
 )
 
+// Ограничения таблицы reactions
+const (
+	reactionUniqueConstraint = "reactions_employee_id_vacancy_id_key" // одна реакция сотрудника на вакансию
+)
+
 type ReactionRepository struct {
-	db *pgxpool.Pool
+	db *transaction.DB
 }
 
 func NewReactionRepository(db *pgxpool.Pool) *ReactionRepository {
-	return &ReactionRepository{db: db}
+	return &ReactionRepository{db: transaction.NewDB(db)}
 }
 
-// CreateReaction создает новую реакцию в БД
+// CreateReaction создает новую реакцию в БД вместе с событием reaction.created
 func (r *ReactionRepository) CreateReaction(ctx context.Context, reaction *models.Reaction) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO reactions (id, employee_id, vacancy_id, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO reactions (id, employee_id, vacancy_id, reaction, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		reaction.ID,
 		reaction.EmployeeID,
 		reaction.VacancyID,
+		reaction.Type,
 		reaction.CreatedAt,
+		reaction.UpdatedAt,
 	)
 
 	if err != nil {
-		if err.Error() == "duplicate key value violates unique constraint" {
+		if pgerr.IsUniqueViolation(err, reactionUniqueConstraint) {
 			return ErrReactionAlreadyExists
 		}
 
-		return fmt.Errorf("failed to create reaction: %w", err)
+		return fmt.Errorf("failed to create reaction: %w", pgerr.Wrap(err))
+	}
+
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventReactionCreated, reaction.ID, reaction); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -53,7 +77,7 @@ func (r *ReactionRepository) CreateReaction(ctx context.Context, reaction *model
 // GetReaction получает реакцию по ID
 func (r *ReactionRepository) GetReaction(ctx context.Context, id uuid.UUID) (*models.Reaction, error) {
 	query := `
-		SELECT id, employee_id, vacancy_id, created_at
+		SELECT id, employee_id, vacancy_id, reaction, created_at, updated_at
 		FROM reactions
 		WHERE id = $1
 	`
@@ -63,7 +87,9 @@ func (r *ReactionRepository) GetReaction(ctx context.Context, id uuid.UUID) (*mo
 		&reaction.ID,
 		&reaction.EmployeeID,
 		&reaction.VacancyID,
+		&reaction.Type,
 		&reaction.CreatedAt,
+		&reaction.UpdatedAt,
 	)
 
 	if err != nil {
@@ -76,16 +102,22 @@ func (r *ReactionRepository) GetReaction(ctx context.Context, id uuid.UUID) (*mo
 	return reaction, nil
 }
 
-// GetReactionsByEmployee получает реакции сотрудника
-func (r *ReactionRepository) GetReactionsByEmployee(ctx context.Context, employeeID uuid.UUID) (*models.EmployeeReactionList, error) {
+// GetReactionsByEmployee получает реакции сотрудника, опционально отфильтрованные по типу
+func (r *ReactionRepository) GetReactionsByEmployee(ctx context.Context, employeeID uuid.UUID, filter *models.ReactionFilter) (*models.EmployeeReactionList, error) {
 	query := `
-		SELECT id, employee_id, vacancy_id, created_at
+		SELECT id, employee_id, vacancy_id, reaction, created_at, updated_at
 		FROM reactions
 		WHERE employee_id = $1
+			AND ($2::varchar IS NULL OR reaction = $2)
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, employeeID)
+	var reactionType *string
+	if filter != nil {
+		reactionType = filter.Type
+	}
+
+	rows, err := r.db.Query(ctx, query, employeeID, reactionType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reactions by employee: %w", err)
 	}
@@ -98,7 +130,9 @@ func (r *ReactionRepository) GetReactionsByEmployee(ctx context.Context, employe
 			&reaction.ID,
 			&reaction.EmployeeID,
 			&reaction.VacancyID,
+			&reaction.Type,
 			&reaction.CreatedAt,
+			&reaction.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan reaction: %w", err)
@@ -116,13 +150,104 @@ func (r *ReactionRepository) GetReactionsByEmployee(ctx context.Context, employe
 	}, nil
 }
 
-// DeleteReaction удаляет реакцию
-func (r *ReactionRepository) DeleteReaction(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM reactions WHERE id = $1`
+// GetReactionByEmployeeAndVacancy получает реакцию сотрудника на вакансию
+func (r *ReactionRepository) GetReactionByEmployeeAndVacancy(ctx context.Context, employeeID, vacancyID uuid.UUID) (*models.Reaction, error) {
+	query := `
+		SELECT id, employee_id, vacancy_id, reaction, created_at, updated_at
+		FROM reactions
+		WHERE employee_id = $1 AND vacancy_id = $2
+	`
+
+	reaction := &models.Reaction{}
+	err := r.db.QueryRow(ctx, query, employeeID, vacancyID).Scan(
+		&reaction.ID,
+		&reaction.EmployeeID,
+		&reaction.VacancyID,
+		&reaction.Type,
+		&reaction.CreatedAt,
+		&reaction.UpdatedAt,
+	)
 
-	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete reaction: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReactionNotFound
+		}
+		return nil, fmt.Errorf("failed to get reaction by employee and vacancy: %w", err)
+	}
+
+	return reaction, nil
+}
+
+// GetReactionsByVacancy получает реакции сотрудников на вакансию, опционально отфильтрованные по типу
+func (r *ReactionRepository) GetReactionsByVacancy(ctx context.Context, vacancyID uuid.UUID, filter *models.ReactionFilter) (*models.VacancyReactionList, error) {
+	query := `
+		SELECT id, employee_id, vacancy_id, reaction, created_at, updated_at
+		FROM reactions
+		WHERE vacancy_id = $1
+			AND ($2::varchar IS NULL OR reaction = $2)
+		ORDER BY created_at DESC
+	`
+
+	var reactionType *string
+	if filter != nil {
+		reactionType = filter.Type
+	}
+
+	rows, err := r.db.Query(ctx, query, vacancyID, reactionType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions by vacancy: %w", err)
+	}
+	defer rows.Close()
+
+	reactions := make([]models.Reaction, 0)
+	for rows.Next() {
+		var reaction models.Reaction
+		err := rows.Scan(
+			&reaction.ID,
+			&reaction.EmployeeID,
+			&reaction.VacancyID,
+			&reaction.Type,
+			&reaction.CreatedAt,
+			&reaction.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reaction: %w", err)
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reactions: %w", err)
+	}
+
+	return &models.VacancyReactionList{
+		Reactions: reactions,
+		VacancyID: vacancyID,
+	}, nil
+}
+
+// UpdateReaction обновляет тип реакции вместе с событием reaction.updated
+func (r *ReactionRepository) UpdateReaction(ctx context.Context, reaction *models.Reaction) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE reactions
+		SET reaction = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := tx.Exec(ctx, query,
+		reaction.ID,
+		reaction.Type,
+		reaction.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update reaction: %w", pgerr.Wrap(err))
 	}
 
 	rowsAffected := result.RowsAffected()
@@ -130,5 +255,44 @@ func (r *ReactionRepository) DeleteReaction(ctx context.Context, id uuid.UUID) e
 		return ErrReactionNotFound
 	}
 
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventReactionUpdated, reaction.ID, reaction); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteReaction удаляет реакцию вместе с событием reaction.deleted
+func (r *ReactionRepository) DeleteReaction(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `DELETE FROM reactions WHERE id = $1 RETURNING employee_id, vacancy_id`
+
+	var employeeID, vacancyID uuid.UUID
+	err = tx.QueryRow(ctx, query, id).Scan(&employeeID, &vacancyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrReactionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete reaction: %w", err)
+	}
+
+	payload := map[string]uuid.UUID{"id": id, "employee_id": employeeID, "vacancy_id": vacancyID}
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventReactionDeleted, id, payload); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
@@ -3,13 +3,21 @@ package repository
 import (
 	"context"
 	"jobot/internal/service/models"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// TxManager выполняет fn в транзакции: вызовы репозиториев с контекстом fn участвуют в ней
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type UserRepository interface {
 	CreateUser(ctx context.Context, userService *models.User) error
 	GetUser(ctx context.Context, id uuid.UUID) (*models.User, error)
+	GetUserByTgChatID(ctx context.Context, tgChatID string) (*models.User, error)
+	GetUserByTgUserName(ctx context.Context, tgUserName string) (*models.User, error)
 	UpdateUser(ctx context.Context, userService *models.User) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
@@ -18,6 +26,7 @@ type EmployeeRepository interface {
 	CreateEmployee(ctx context.Context, employeeService *models.Employee) error
 	GetEmployee(ctx context.Context, id uuid.UUID) (*models.Employee, error)
 	GetEmployeeByUserID(ctx context.Context, userID uuid.UUID) (*models.Employee, error)
+	SetEmployeeArchived(ctx context.Context, employeeID uuid.UUID, archivedAt *time.Time) error
 	UpdateEmployee(ctx context.Context, employeeService *models.Employee) error
 	DeleteEmployee(ctx context.Context, id uuid.UUID) error
 }
@@ -26,6 +35,8 @@ type EmployerRepository interface {
 	CreateEmployer(ctx context.Context, employerService *models.Employer) error
 	GetEmployer(ctx context.Context, id uuid.UUID) (*models.Employer, error)
 	GetEmployerByUserID(ctx context.Context, userID uuid.UUID) (*models.Employer, error)
+	HasCandidate(ctx context.Context, employerID, employeeID uuid.UUID) (bool, error)
+	SetEmployerArchived(ctx context.Context, employerID uuid.UUID, archivedAt *time.Time) error
 	UpdateEmployer(ctx context.Context, employerService *models.Employer) error
 	DeleteEmployer(ctx context.Context, id uuid.UUID) error
 }
@@ -41,8 +52,10 @@ type ResumeRepository interface {
 type VacancyRepository interface {
 	CreateVacancy(ctx context.Context, vacancyService *models.Vacancy) error
 	GetVacancy(ctx context.Context, id uuid.UUID) (*models.Vacancy, error)
-	GetVacancyList(ctx context.Context) (*models.VacancyList, error)
+	GetVacancyList(ctx context.Context, filter *models.VacancyFilter, pagination models.Pagination) (*models.VacancyList, error)
+	SearchVacancies(ctx context.Context, query string, filter *models.VacancyFilter, pagination models.Pagination) (*models.VacancySearchResult, error)
 	GetVacanciesByEmployer(ctx context.Context, employerID uuid.UUID) (*models.EmployerVacancyList, error)
+	ArchiveVacanciesByEmployer(ctx context.Context, employerID uuid.UUID, archivedAt time.Time) (int64, error)
 	UpdateVacancy(ctx context.Context, vacancyService *models.Vacancy) error
 	DeleteVacancy(ctx context.Context, id uuid.UUID) error
 }
@@ -50,6 +63,70 @@ type VacancyRepository interface {
 type ReactionRepository interface {
 	CreateReaction(ctx context.Context, reactionService *models.Reaction) error
 	GetReaction(ctx context.Context, id uuid.UUID) (*models.Reaction, error)
-	GetReactionsByEmployee(ctx context.Context, employeeID uuid.UUID) (*models.EmployeeReactionList, error)
+	GetReactionByEmployeeAndVacancy(ctx context.Context, employeeID, vacancyID uuid.UUID) (*models.Reaction, error)
+	GetReactionsByEmployee(ctx context.Context, employeeID uuid.UUID, filter *models.ReactionFilter) (*models.EmployeeReactionList, error)
+	GetReactionsByVacancy(ctx context.Context, vacancyID uuid.UUID, filter *models.ReactionFilter) (*models.VacancyReactionList, error)
+	UpdateReaction(ctx context.Context, reactionService *models.Reaction) error
 	DeleteReaction(ctx context.Context, id uuid.UUID) error
 }
+
+type EmployerReactionRepository interface {
+	CreateEmployerReaction(ctx context.Context, reactionService *models.EmployerReaction) error
+	GetEmployerReaction(ctx context.Context, id uuid.UUID) (*models.EmployerReaction, error)
+	GetEmployerReactionsByEmployer(ctx context.Context, employerID uuid.UUID, filter *models.EmployerReactionFilter) (*models.EmployerReactionList, error)
+	UpdateEmployerReaction(ctx context.Context, reactionService *models.EmployerReaction) error
+	DeleteEmployerReaction(ctx context.Context, id uuid.UUID) error
+}
+
+type MatchRepository interface {
+	SyncMatch(ctx context.Context, employeeID, vacancyID uuid.UUID) (bool, error)
+	GetMatchesByEmployee(ctx context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.MatchList, error)
+	GetMatchesByEmployer(ctx context.Context, employerID uuid.UUID, pagination models.Pagination) (*models.MatchList, error)
+}
+
+type FeedRepository interface {
+	GetEmployeeVacancyFeed(ctx context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.VacancyFeed, error)
+	GetVacancyCandidates(ctx context.Context, vacancyID uuid.UUID, pagination models.Pagination) (*models.CandidateList, error)
+}
+
+type ApplicationRepository interface {
+	CreateApplication(ctx context.Context, applicationService *models.Application, transition *models.ApplicationTransition) error
+	GetApplication(ctx context.Context, id uuid.UUID) (*models.Application, error)
+	GetApplicationHistory(ctx context.Context, id uuid.UUID) ([]models.ApplicationTransition, error)
+	GetApplicationsByEmployee(ctx context.Context, employeeID uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error)
+	GetApplicationsByEmployer(ctx context.Context, employerID uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error)
+	RejectOpenApplicationsByEmployee(ctx context.Context, employeeID uuid.UUID, comment string) (int64, error)
+	RejectOpenApplicationsByEmployer(ctx context.Context, employerID uuid.UUID, comment string) (int64, error)
+	UpdateApplicationStatus(ctx context.Context, applicationService *models.Application, transition *models.ApplicationTransition) error
+}
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, apiKeyService *models.APIKey) error
+	GetAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	RotateAPIKey(ctx context.Context, apiKeyService *models.APIKey) error
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+type ConversationRepository interface {
+	GetConversation(ctx context.Context, tgChatID string) (*models.Conversation, error)
+	SaveConversation(ctx context.Context, conversation *models.Conversation) error
+	DeleteConversation(ctx context.Context, tgChatID string) error
+}
+
+type NotificationRepository interface {
+	EnqueueNewVacancyNotifications(ctx context.Context, vacancyID uuid.UUID, now time.Time) (int64, error)
+	EnqueueCandidateLikedNotification(ctx context.Context, employeeID, vacancyID uuid.UUID, now time.Time) (bool, error)
+	ClaimDueNotifications(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Notification, error)
+	UpdateNotificationDelivery(ctx context.Context, notification *models.Notification) error
+	GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	SaveNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) error
+}
+
+type OutboxRepository interface {
+	ClaimEvents(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.OutboxEvent, error)
+	UpdateEventDispatch(ctx context.Context, event *models.OutboxEvent) error
+	DeleteDispatchedEvents(ctx context.Context, before time.Time) (int64, error)
+}
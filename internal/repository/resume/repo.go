@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
 	"jobot/internal/service/models"
 
 	"github.com/google/uuid"
@@ -17,12 +19,17 @@ var (
 	ErrResumeAlreadyExists = errors.New("resume already exists")
 )
 
+// Ограничения таблицы resumes
+const (
+	employeeIDUniqueConstraint = "resumes_employee_id_key" // у сотрудника может быть только одно резюме
+)
+
 type ResumeRepository struct {
-	db *pgxpool.Pool
+	db *transaction.DB
 }
 
 func NewResumeRepository(db *pgxpool.Pool) *ResumeRepository {
-	return &ResumeRepository{db: db}
+	return &ResumeRepository{db: transaction.NewDB(db)}
 }
 
 // CreateResume создает новое резюме в БД
@@ -41,11 +48,11 @@ func (r *ResumeRepository) CreateResume(ctx context.Context, resume *models.Resu
 	)
 
 	if err != nil {
-		if err.Error() == "duplicate key value violates unique constraint" {
+		if pgerr.IsUniqueViolation(err, employeeIDUniqueConstraint) {
 			return ErrResumeAlreadyExists
 		}
 
-		return fmt.Errorf("failed to create resume: %w", err)
+		return fmt.Errorf("failed to create resume: %w", pgerr.Wrap(err))
 	}
 
 	return nil
@@ -120,7 +127,7 @@ func (r *ResumeRepository) UpdateResume(ctx context.Context, resume *models.Resu
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update resume: %w", err)
+		return fmt.Errorf("failed to update resume: %w", pgerr.Wrap(err))
 	}
 
 	rowsAffected := result.RowsAffected()
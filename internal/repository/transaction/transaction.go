@@ -0,0 +1,96 @@
+// Package transaction позволяет выполнять вызовы нескольких репозиториев в одной транзакции.
+// Manager кладет транзакцию в контекст, а DB, через который репозитории выполняют запросы,
+// берет ее из контекста, поэтому сервису достаточно передать репозиториям контекст fn.
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type txKey struct{}
+
+// querier - общее у пула и транзакции
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Pool - пул соединений, через который выполняются запросы вне транзакции (*pgxpool.Pool)
+type Pool interface {
+	querier
+}
+
+// DB выполняет запросы в транзакции из контекста (см. Manager.WithinTransaction), а без нее - через пул
+type DB struct {
+	pool Pool
+}
+
+func NewDB(pool Pool) *DB {
+	return &DB{pool: pool}
+}
+
+func (db *DB) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return db.querier(ctx).Exec(ctx, sql, arguments...)
+}
+
+func (db *DB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return db.querier(ctx).Query(ctx, sql, args...)
+}
+
+func (db *DB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return db.querier(ctx).QueryRow(ctx, sql, args...)
+}
+
+// Begin начинает транзакцию, а внутри транзакции из контекста - savepoint,
+// поэтому репозиторий может использовать свою транзакцию и в составе внешней
+func (db *DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return db.querier(ctx).Begin(ctx)
+}
+
+func (db *DB) querier(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return db.pool
+}
+
+// Manager выполняет функции в транзакции
+type Manager struct {
+	pool Pool
+}
+
+func NewManager(pool Pool) *Manager {
+	return &Manager{pool: pool}
+}
+
+// WithinTransaction выполняет fn в транзакции и фиксирует ее, если fn вернула nil, иначе откатывает.
+// Запросы репозиториев с контекстом fn выполняются в этой транзакции.
+// Вложенный вызов (контекст уже содержит транзакцию) выполняет fn в текущей транзакции.
+func (m *Manager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
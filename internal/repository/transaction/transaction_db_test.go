@@ -0,0 +1,104 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/repository/transaction"
+)
+
+// testDatabaseURLEnv - строка подключения к тестовой базе; без нее тесты с Postgres пропускаются
+const testDatabaseURLEnv = "JOBOT_TEST_DATABASE_URL"
+
+// newTestTable создает таблицу для одного теста и возвращает пул и ее имя
+func newTestTable(t *testing.T) (*pgxpool.Pool, string) {
+	t.Helper()
+
+	url := os.Getenv(testDatabaseURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", testDatabaseURLEnv)
+	}
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	table := pgx.Identifier{fmt.Sprintf("transaction_test_%d", time.Now().UnixNano())}.Sanitize()
+
+	_, err = pool.Exec(ctx, "CREATE TABLE "+table+" (value TEXT NOT NULL)")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), "DROP TABLE "+table)
+	})
+
+	return pool, table
+}
+
+func selectValues(t *testing.T, pool *pgxpool.Pool, table string) []string {
+	t.Helper()
+
+	rows, err := pool.Query(context.Background(), "SELECT value FROM "+table+" ORDER BY value")
+	require.NoError(t, err)
+
+	values, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	require.NoError(t, err)
+
+	return values
+}
+
+func TestWithinTransactionPostgres(t *testing.T) {
+	pool, table := newTestTable(t)
+	manager, db := NewManager(pool), NewDB(pool)
+	ctx := context.Background()
+	insert := "INSERT INTO " + table + " (value) VALUES ($1)"
+	errFailed := errors.New("failed")
+
+	err := manager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := db.Exec(ctx, insert, "rolled back"); err != nil {
+			return err
+		}
+
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+	assert.Empty(t, selectValues(t, pool, table))
+
+	err = manager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := db.Exec(ctx, insert, "outer"); err != nil {
+			return err
+		}
+
+		// Откат savepoint репозитория не затрагивает внешнюю транзакцию
+		savepoint, err := db.Begin(ctx)
+		if err != nil {
+			return err
+		}
+
+		if _, err := savepoint.Exec(ctx, insert, "savepoint"); err != nil {
+			return err
+		}
+
+		if err := savepoint.Rollback(ctx); err != nil {
+			return err
+		}
+
+		return manager.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := db.Exec(ctx, insert, "nested")
+			return err
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nested", "outer"}, selectValues(t, pool, table))
+}
@@ -0,0 +1,185 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/repository/transaction"
+)
+
+// journal записывает обращения к пулу и транзакциям в порядке вызова
+type journal struct {
+	entries []string
+	txs     int
+}
+
+func (j *journal) add(format string, args ...any) {
+	j.entries = append(j.entries, fmt.Sprintf(format, args...))
+}
+
+type poolStub struct {
+	Pool
+	journal *journal
+}
+
+func (p *poolStub) Exec(_ context.Context, sql string, _ ...any) (pgconn.CommandTag, error) {
+	p.journal.add("pool: %s", sql)
+
+	return pgconn.CommandTag{}, nil
+}
+
+func (p *poolStub) Begin(_ context.Context) (pgx.Tx, error) {
+	p.journal.txs++
+
+	return &txStub{journal: p.journal, name: fmt.Sprintf("tx%d", p.journal.txs)}, nil
+}
+
+// txStub ведет себя как pgx.Tx: Begin внутри транзакции создает savepoint, Rollback после Commit ничего не делает
+type txStub struct {
+	pgx.Tx
+	journal *journal
+	name    string
+	closed  bool
+}
+
+func (tx *txStub) Exec(_ context.Context, sql string, _ ...any) (pgconn.CommandTag, error) {
+	tx.journal.add("%s: %s", tx.name, sql)
+
+	return pgconn.CommandTag{}, nil
+}
+
+func (tx *txStub) Begin(_ context.Context) (pgx.Tx, error) {
+	tx.journal.add("%s: savepoint", tx.name)
+
+	return &txStub{journal: tx.journal, name: tx.name + "/sp"}, nil
+}
+
+func (tx *txStub) Commit(_ context.Context) error {
+	tx.closed = true
+	tx.journal.add("%s: commit", tx.name)
+
+	return nil
+}
+
+func (tx *txStub) Rollback(_ context.Context) error {
+	if tx.closed {
+		return pgx.ErrTxClosed
+	}
+
+	tx.closed = true
+	tx.journal.add("%s: rollback", tx.name)
+
+	return nil
+}
+
+func TestWithinTransaction(t *testing.T) {
+	t.Parallel()
+
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		fn      func(ctx context.Context, manager *Manager, db *DB) error
+		err     error
+		entries []string
+	}{
+		{
+			name: "commit on nil",
+			fn: func(ctx context.Context, _ *Manager, db *DB) error {
+				_, err := db.Exec(ctx, "insert")
+				return err
+			},
+			entries: []string{"tx1: insert", "tx1: commit"},
+		},
+		{
+			name: "rollback on error",
+			fn: func(ctx context.Context, _ *Manager, db *DB) error {
+				if _, err := db.Exec(ctx, "insert"); err != nil {
+					return err
+				}
+
+				return errFailed
+			},
+			err:     errFailed,
+			entries: []string{"tx1: insert", "tx1: rollback"},
+		},
+		{
+			name: "nested call joins outer transaction",
+			fn: func(ctx context.Context, manager *Manager, db *DB) error {
+				return manager.WithinTransaction(ctx, func(ctx context.Context) error {
+					_, err := db.Exec(ctx, "insert")
+					return err
+				})
+			},
+			entries: []string{"tx1: insert", "tx1: commit"},
+		},
+		{
+			name: "nested error rolls back outer transaction",
+			fn: func(ctx context.Context, manager *Manager, _ *DB) error {
+				return manager.WithinTransaction(ctx, func(context.Context) error {
+					return errFailed
+				})
+			},
+			err:     errFailed,
+			entries: []string{"tx1: rollback"},
+		},
+		{
+			name: "repository transaction becomes savepoint",
+			fn: func(ctx context.Context, _ *Manager, db *DB) error {
+				tx, err := db.Begin(ctx)
+				if err != nil {
+					return err
+				}
+				defer tx.Rollback(ctx)
+
+				if _, err := tx.Exec(ctx, "insert"); err != nil {
+					return err
+				}
+
+				return tx.Commit(ctx)
+			},
+			entries: []string{"tx1: savepoint", "tx1/sp: insert", "tx1/sp: commit", "tx1: commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			log := &journal{}
+			pool := &poolStub{journal: log}
+			manager, db := NewManager(pool), NewDB(pool)
+
+			err := manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+				return tt.fn(ctx, manager, db)
+			})
+
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.entries, log.entries)
+			assert.Equal(t, 1, log.txs)
+		})
+	}
+}
+
+func TestDBWithoutTransaction(t *testing.T) {
+	t.Parallel()
+
+	log := &journal{}
+	db := NewDB(&poolStub{journal: log})
+
+	_, err := db.Exec(context.Background(), "insert")
+	require.NoError(t, err)
+
+	tx, err := db.Begin(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(context.Background()))
+
+	assert.Equal(t, []string{"pool: insert", "tx1: commit"}, log.entries)
+}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"time"
 
+	"jobot/internal/repository/outbox"
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
 	"jobot/internal/service/models"
 
 	"github.com/google/uuid"
@@ -15,25 +18,37 @@ import (
 
 var (
 	ErrUserNotFound      = errors.New("user not found")
-	ErrUserAlreadyExists = errors.New("user with this email already exists")
+	ErrUserAlreadyExists = errors.New("user with this Telegram chat ID already exists")
+	ErrUserNameAmbiguous = errors.New("several users have this Telegram username")
+)
+
+// Ограничения таблицы users
+const (
+	tgChatIDUniqueConstraint = "users_tg_chat_id_key" // уникальность tg_chat_id
 )
 
 type UserRepository struct {
-	db *pgxpool.Pool
+	db *transaction.DB
 }
 
 func NewUserRepository(db *pgxpool.Pool) *UserRepository {
-	return &UserRepository{db: db}
+	return &UserRepository{db: transaction.NewDB(db)}
 }
 
-// CreateUser создает нового пользователя в БД
+// CreateUser создает нового пользователя в БД вместе с событием user.created
 func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		INSERT INTO users (id, tg_user_name, tg_chat_id, is_active, is_premium, role, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		user.ID,
 		user.TgUserName,
 		user.TgChatID,
@@ -45,12 +60,19 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) erro
 	)
 
 	if err != nil {
-		// Проверяем на дублирование tg_chat_id (unique constraint)
-		if err.Error() == "duplicate key value violates unique constraint" {
+		if pgerr.IsUniqueViolation(err, tgChatIDUniqueConstraint) {
 			return ErrUserAlreadyExists
 		}
 
-		return fmt.Errorf("failed to create user: %w", err)
+		return fmt.Errorf("failed to create user: %w", pgerr.Wrap(err))
+	}
+
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventUserCreated, user.ID, user); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -86,8 +108,95 @@ func (r *UserRepository) GetUser(ctx context.Context, id uuid.UUID) (*models.Use
 	return user, nil
 }
 
-// UpdateUser обновляет данные пользователя
+// GetUserByTgChatID получает пользователя по Telegram chat ID
+func (r *UserRepository) GetUserByTgChatID(ctx context.Context, tgChatID string) (*models.User, error) {
+	query := `
+		SELECT id, tg_user_name, tg_chat_id, is_active, is_premium, role, created_at, updated_at
+		FROM users
+		WHERE tg_chat_id = $1
+	`
+
+	user := &models.User{}
+	err := r.db.QueryRow(ctx, query, tgChatID).Scan(
+		&user.ID,
+		&user.TgUserName,
+		&user.TgChatID,
+		&user.IsActive,
+		&user.IsPremium,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by tg chat id: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetUserByTgUserName получает пользователя по Telegram username без учета регистра.
+// Username не уникален в БД: освобожденное в Telegram имя может остаться у старой записи,
+// а пользователь может сам записать себе чужое имя. Поэтому, если имя есть у нескольких
+// пользователей, возвращается ErrUserNameAmbiguous, а не одна из записей.
+func (r *UserRepository) GetUserByTgUserName(ctx context.Context, tgUserName string) (*models.User, error) {
+	query := `
+		SELECT id, tg_user_name, tg_chat_id, is_active, is_premium, role, created_at, updated_at
+		FROM users
+		WHERE lower(tg_user_name) = lower($1)
+		LIMIT 2
+	`
+
+	rows, err := r.db.Query(ctx, query, tgUserName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by tg user name: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(
+			&user.ID,
+			&user.TgUserName,
+			&user.TgChatID,
+			&user.IsActive,
+			&user.IsPremium,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get user by tg user name: %w", err)
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+		return &users[0], nil
+	default:
+		return nil, ErrUserNameAmbiguous
+	}
+}
+
+// UpdateUser обновляет данные пользователя вместе с событием user.updated
 func (r *UserRepository) UpdateUser(ctx context.Context, user *models.User) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE users
 		SET tg_user_name = $2, tg_chat_id = $3, is_active = $4, is_premium = $5, role = $6, updated_at = $7
@@ -96,7 +205,7 @@ func (r *UserRepository) UpdateUser(ctx context.Context, user *models.User) erro
 
 	user.UpdatedAt = time.Now()
 
-	result, err := r.db.Exec(ctx, query,
+	result, err := tx.Exec(ctx, query,
 		user.ID,
 		user.TgUserName,
 		user.TgChatID,
@@ -107,7 +216,11 @@ func (r *UserRepository) UpdateUser(ctx context.Context, user *models.User) erro
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+		if pgerr.IsUniqueViolation(err, tgChatIDUniqueConstraint) {
+			return ErrUserAlreadyExists
+		}
+
+		return fmt.Errorf("failed to update user: %w", pgerr.Wrap(err))
 	}
 
 	rowsAffected := result.RowsAffected()
@@ -115,18 +228,33 @@ func (r *UserRepository) UpdateUser(ctx context.Context, user *models.User) erro
 		return ErrUserNotFound
 	}
 
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventUserUpdated, user.ID, user); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
-// DeleteUser удаляет пользователя (soft delete через is_active или hard delete)
+// DeleteUser удаляет пользователя (soft delete через is_active или hard delete) вместе с событием user.deleted.
+// О каскадно удаленных профилях, вакансиях и реакциях отдельных событий нет.
 func (r *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	// Hard delete
 	query := `DELETE FROM users WHERE id = $1`
 
 	// Для soft delete можно использовать:
 	// query := `UPDATE users SET is_active = false, updated_at = $2 WHERE id = $1`
 
-	result, err := r.db.Exec(ctx, query, id)
+	result, err := tx.Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -136,5 +264,13 @@ func (r *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 		return ErrUserNotFound
 	}
 
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventUserDeleted, id, map[string]uuid.UUID{"id": id}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
@@ -4,7 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
+	"strings"
+	"time"
 
+	"jobot/internal/repository/outbox"
+	"jobot/internal/repository/pgerr"
+	"jobot/internal/repository/transaction"
 	"jobot/internal/service/models"
 
 	"github.com/google/uuid"
@@ -17,22 +23,45 @@ var (
 	ErrVacancyAlreadyExists = errors.New("vacancy already exists")
 )
 
+// Ограничения таблицы vacancies
+const (
+	vacancyPrimaryKeyConstraint = "vacancies_pkey" // уникальность vacansie_id
+)
+
+// ts_headline выделяет совпадения управляющими символами, а не тегами: текст вакансии сначала экранируется
+// (см. HighlightHTML), и только потом маркеры заменяются на <b></b>
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+
+	titleHeadlineOptions   = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", HighlightAll=true"
+	snippetHeadlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \""
+)
+
+var highlightTags = strings.NewReplacer(highlightStart, "<b>", highlightStop, "</b>")
+
 type VacancyRepository struct {
-	db *pgxpool.Pool
+	db *transaction.DB
 }
 
 func NewVacancyRepository(db *pgxpool.Pool) *VacancyRepository {
-	return &VacancyRepository{db: db}
+	return &VacancyRepository{db: transaction.NewDB(db)}
 }
 
-// CreateVacancy создает новую вакансию в БД
+// CreateVacancy создает новую вакансию в БД вместе с событием vacancy.created
 func (r *VacancyRepository) CreateVacancy(ctx context.Context, vacancy *models.Vacancy) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		INSERT INTO vacancies (vacansie_id, employer_id, tags, title, description, location, salary, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		vacancy.VacansieID,
 		vacancy.EmployerID,
 		vacancy.Tags,
@@ -45,22 +74,30 @@ func (r *VacancyRepository) CreateVacancy(ctx context.Context, vacancy *models.V
 	)
 
 	if err != nil {
-		if err.Error() == "duplicate key value violates unique constraint" {
+		if pgerr.IsUniqueViolation(err, vacancyPrimaryKeyConstraint) {
 			return ErrVacancyAlreadyExists
 		}
 
-		return fmt.Errorf("failed to create vacancy: %w", err)
+		return fmt.Errorf("failed to create vacancy: %w", pgerr.Wrap(err))
+	}
+
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventVacancyCreated, vacancy.VacansieID, vacancy); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
 
-// GetVacancy получает вакансию по ID
+// GetVacancy получает вакансию по ID, архивные вакансии не находятся
 func (r *VacancyRepository) GetVacancy(ctx context.Context, id uuid.UUID) (*models.Vacancy, error) {
 	query := `
 		SELECT vacansie_id, employer_id, tags, title, description, location, salary, created_at, updated_at
 		FROM vacancies
-		WHERE vacansie_id = $1
+		WHERE vacansie_id = $1 AND archived_at IS NULL
 	`
 
 	vacancy := &models.Vacancy{}
@@ -86,20 +123,28 @@ func (r *VacancyRepository) GetVacancy(ctx context.Context, id uuid.UUID) (*mode
 	return vacancy, nil
 }
 
-// GetVacancyList получает список всех вакансий
-func (r *VacancyRepository) GetVacancyList(ctx context.Context) (*models.VacancyList, error) {
-	query := `
-		SELECT vacansie_id, employer_id, tags, title, description, location, salary, created_at, updated_at
+// GetVacancyList получает страницу вакансий с учетом фильтров и сортировки
+func (r *VacancyRepository) GetVacancyList(ctx context.Context, filter *models.VacancyFilter, pagination models.Pagination) (*models.VacancyList, error) {
+	where, args := buildVacancyFilter(filter)
+
+	args = append(args, pagination.Limit(), pagination.Offset())
+
+	query := fmt.Sprintf(`
+		SELECT vacansie_id, employer_id, tags, title, description, location, salary, created_at, updated_at,
+			COUNT(*) OVER() AS total
 		FROM vacancies
-		ORDER BY created_at DESC
-	`
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, buildVacancyOrder(filter), len(args)-1, len(args))
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vacancy list: %w", err)
 	}
 	defer rows.Close()
 
+	var total int
 	vacancies := make([]models.Vacancy, 0)
 	for rows.Next() {
 		var vacancy models.Vacancy
@@ -113,6 +158,7 @@ func (r *VacancyRepository) GetVacancyList(ctx context.Context) (*models.Vacancy
 			&vacancy.Salary,
 			&vacancy.CreatedAt,
 			&vacancy.UpdatedAt,
+			&total,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan vacancy: %w", err)
@@ -124,7 +170,201 @@ func (r *VacancyRepository) GetVacancyList(ctx context.Context) (*models.Vacancy
 		return nil, fmt.Errorf("error iterating vacancies: %w", err)
 	}
 
-	return &models.VacancyList{Vacansies: vacancies}, nil
+	// Страница за пределами выборки не содержит строк, поэтому общее количество считаем отдельно
+	if len(vacancies) == 0 && pagination.Offset() > 0 {
+		countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM vacancies %s`, where)
+
+		err = r.db.QueryRow(ctx, countQuery, args[:len(args)-2]...).Scan(&total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count vacancies: %w", err)
+		}
+	}
+
+	return &models.VacancyList{
+		Vacansies:  vacancies,
+		Pagination: pagination,
+		Total:      total,
+	}, nil
+}
+
+// buildVacancyFilter собирает WHERE условие и аргументы запроса по фильтру
+func buildVacancyFilter(filter *models.VacancyFilter) (string, []any) {
+	conditions, args := buildVacancyConditions(filter, nil)
+
+	return whereClause(conditions), args
+}
+
+// buildVacancyConditions дополняет аргументы запроса и возвращает условия фильтра.
+// Архивные вакансии исключаются всегда.
+func buildVacancyConditions(filter *models.VacancyFilter, args []any) ([]string, []any) {
+	conditions := []string{"archived_at IS NULL"}
+
+	if filter == nil {
+		return conditions, args
+	}
+
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		// Оба оператора используют GIN индекс idx_vacancies_tags
+		if filter.TagsMatch == models.TagsMatchAll {
+			conditions = append(conditions, fmt.Sprintf("tags @> $%d", len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("tags && $%d", len(args)))
+		}
+	}
+
+	if filter.Location != nil {
+		args = append(args, "%"+EscapeLike(*filter.Location)+"%")
+		conditions = append(conditions, fmt.Sprintf(`location ILIKE $%d ESCAPE '\'`, len(args)))
+	}
+
+	if filter.EmployerID != nil {
+		args = append(args, *filter.EmployerID)
+		conditions = append(conditions, fmt.Sprintf("employer_id = $%d", len(args)))
+	}
+
+	if filter.CreatedFrom != nil {
+		args = append(args, *filter.CreatedFrom)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	if filter.CreatedTo != nil {
+		args = append(args, *filter.CreatedTo)
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", len(args)))
+	}
+
+	return conditions, args
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE за один проход
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike экранирует в s символы \, % и _, чтобы пользовательский ввод в шаблоне LIKE ... ESCAPE '\'
+// совпадал только буквально
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// buildVacancyOrder собирает ORDER BY по белому списку полей сортировки
+func buildVacancyOrder(filter *models.VacancyFilter) string {
+	sortBy := models.VacancySortByCreatedAt
+	sortOrder := models.SortOrderDesc
+
+	if filter != nil {
+		switch filter.SortBy {
+		case models.VacancySortByCreatedAt, models.VacancySortByUpdatedAt, models.VacancySortByTitle:
+			sortBy = filter.SortBy
+		}
+
+		if filter.SortOrder == models.SortOrderAsc {
+			sortOrder = models.SortOrderAsc
+		}
+	}
+
+	// vacansie_id добавлен для стабильного порядка между страницами
+	return fmt.Sprintf("%s %s, vacansie_id %s", sortBy, sortOrder, sortOrder)
+}
+
+// HighlightHTML превращает результат ts_headline в безопасный HTML: экранирует текст вакансии
+// и заменяет маркеры совпадений на <b></b>
+func HighlightHTML(headline string) string {
+	return highlightTags.Replace(html.EscapeString(headline))
+}
+
+// SearchVacancies выполняет полнотекстовый поиск по названию и описанию вакансий.
+// Результаты упорядочены по релевантности, фрагменты с совпадениями экранированы и выделены тегами <b></b>.
+func (r *VacancyRepository) SearchVacancies(ctx context.Context, searchQuery string, filter *models.VacancyFilter, pagination models.Pagination) (*models.VacancySearchResult, error) {
+	args := []any{searchQuery}
+	conditions, args := buildVacancyConditions(filter, args)
+	conditions = append([]string{"search_vector @@ query"}, conditions...)
+
+	args = append(args, pagination.Limit(), pagination.Offset())
+
+	// ts_headline дорогой, поэтому считаем его только для строк выбранной страницы
+	query := fmt.Sprintf(`
+		SELECT v.vacansie_id, v.employer_id, v.tags, v.title, v.description, v.location, v.salary, v.created_at, v.updated_at,
+			page.rank,
+			ts_headline('russian', v.title, page.query, '%s'),
+			ts_headline('russian', v.description, page.query, '%s'),
+			page.total
+		FROM (
+			SELECT vacansie_id, query,
+				ts_rank_cd(search_vector, query) AS rank,
+				COUNT(*) OVER() AS total
+			FROM vacancies, websearch_to_tsquery('russian', $1) AS query
+			%s
+			ORDER BY rank DESC, created_at DESC, vacansie_id
+			LIMIT $%d OFFSET $%d
+		) AS page
+		JOIN vacancies v ON v.vacansie_id = page.vacansie_id
+		ORDER BY page.rank DESC, v.created_at DESC, v.vacansie_id
+	`, titleHeadlineOptions, snippetHeadlineOptions, whereClause(conditions), len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search vacancies: %w", err)
+	}
+	defer rows.Close()
+
+	var total int
+	hits := make([]models.VacancySearchHit, 0)
+	for rows.Next() {
+		var hit models.VacancySearchHit
+		err := rows.Scan(
+			&hit.VacansieID,
+			&hit.EmployerID,
+			&hit.Tags,
+			&hit.Title,
+			&hit.Description,
+			&hit.Location,
+			&hit.Salary,
+			&hit.CreatedAt,
+			&hit.UpdatedAt,
+			&hit.Rank,
+			&hit.TitleHighlight,
+			&hit.Snippet,
+			&total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vacancy search hit: %w", err)
+		}
+
+		hit.TitleHighlight = HighlightHTML(hit.TitleHighlight)
+		hit.Snippet = HighlightHTML(hit.Snippet)
+		hits = append(hits, hit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating vacancy search hits: %w", err)
+	}
+
+	if len(hits) == 0 && pagination.Offset() > 0 {
+		countQuery := fmt.Sprintf(`
+			SELECT COUNT(*)
+			FROM vacancies, websearch_to_tsquery('russian', $1) AS query
+			%s
+		`, whereClause(conditions))
+
+		err = r.db.QueryRow(ctx, countQuery, args[:len(args)-2]...).Scan(&total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count vacancy search hits: %w", err)
+		}
+	}
+
+	return &models.VacancySearchResult{
+		Hits:       hits,
+		Query:      searchQuery,
+		Pagination: pagination,
+		Total:      total,
+	}, nil
 }
 
 // GetVacanciesByEmployer получает вакансии работодателя
@@ -132,7 +372,7 @@ func (r *VacancyRepository) GetVacanciesByEmployer(ctx context.Context, employer
 	query := `
 		SELECT vacansie_id, employer_id, tags, title, description, location, salary, created_at, updated_at
 		FROM vacancies
-		WHERE employer_id = $1
+		WHERE employer_id = $1 AND archived_at IS NULL
 		ORDER BY created_at DESC
 	`
 
@@ -172,15 +412,62 @@ func (r *VacancyRepository) GetVacanciesByEmployer(ctx context.Context, employer
 	}, nil
 }
 
-// UpdateVacancy обновляет данные вакансии
+// ArchiveVacanciesByEmployer архивирует все активные вакансии работодателя с событием vacancy.archived
+// для каждой и возвращает их количество
+func (r *VacancyRepository) ArchiveVacanciesByEmployer(ctx context.Context, employerID uuid.UUID, archivedAt time.Time) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE vacancies
+		SET archived_at = $2, updated_at = $2
+		WHERE employer_id = $1 AND archived_at IS NULL
+		RETURNING vacansie_id
+	`
+
+	rows, err := tx.Query(ctx, query, employerID, archivedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive employer vacancies: %w", pgerr.Wrap(err))
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive employer vacancies: %w", pgerr.Wrap(err))
+	}
+
+	for _, id := range ids {
+		payload := map[string]any{"vacansie_id": id, "employer_id": employerID, "archived_at": archivedAt}
+		if err := outbox.AddEvent(ctx, tx, models.OutboxEventVacancyArchived, id, payload); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int64(len(ids)), nil
+}
+
+// UpdateVacancy обновляет данные вакансии вместе с событием vacancy.updated.
+// Архивная вакансия не редактируется: для нее возвращается ErrVacancyNotFound.
 func (r *VacancyRepository) UpdateVacancy(ctx context.Context, vacancy *models.Vacancy) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE vacancies
 		SET tags = $2, title = $3, description = $4, location = $5, salary = $6, updated_at = $7
-		WHERE vacansie_id = $1
+		WHERE vacansie_id = $1 AND archived_at IS NULL
 	`
 
-	result, err := r.db.Exec(ctx, query,
+	result, err := tx.Exec(ctx, query,
 		vacancy.VacansieID,
 		vacancy.Tags,
 		vacancy.Title,
@@ -191,7 +478,7 @@ func (r *VacancyRepository) UpdateVacancy(ctx context.Context, vacancy *models.V
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update vacancy: %w", err)
+		return fmt.Errorf("failed to update vacancy: %w", pgerr.Wrap(err))
 	}
 
 	rowsAffected := result.RowsAffected()
@@ -199,21 +486,43 @@ func (r *VacancyRepository) UpdateVacancy(ctx context.Context, vacancy *models.V
 		return ErrVacancyNotFound
 	}
 
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventVacancyUpdated, vacancy.VacansieID, vacancy); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
-// DeleteVacancy удаляет вакансию
+// DeleteVacancy удаляет вакансию вместе с событием vacancy.deleted
 func (r *VacancyRepository) DeleteVacancy(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM vacancies WHERE vacansie_id = $1`
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `DELETE FROM vacancies WHERE vacansie_id = $1 RETURNING employer_id`
 
-	result, err := r.db.Exec(ctx, query, id)
+	var employerID uuid.UUID
+	err = tx.QueryRow(ctx, query, id).Scan(&employerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrVacancyNotFound
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete vacancy: %w", err)
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return ErrVacancyNotFound
+	payload := map[string]uuid.UUID{"vacansie_id": id, "employer_id": employerID}
+	if err = outbox.AddEvent(ctx, tx, models.OutboxEventVacancyDeleted, id, payload); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
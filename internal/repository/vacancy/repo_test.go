@@ -0,0 +1,27 @@
+package vacancy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "jobot/internal/repository/vacancy"
+)
+
+func TestEscapeLike(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Moscow", EscapeLike("Moscow"))
+	assert.Equal(t, `100\% remote`, EscapeLike("100% remote"))
+	assert.Equal(t, `new\_york`, EscapeLike("new_york"))
+	assert.Equal(t, `C:\\work`, EscapeLike(`C:\work`))
+	assert.Equal(t, `\\\%`, EscapeLike(`\%`))
+}
+
+func TestHighlightHTML(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "<b>Go</b> developer", HighlightHTML("\x02Go\x03 developer"))
+	assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt; <b>Go</b>", HighlightHTML("<script>alert(1)</script> \x02Go\x03"))
+	assert.Equal(t, "R&amp;D &lt;b&gt;fake&lt;/b&gt; … <b>Go</b>", HighlightHTML("R&D <b>fake</b> … \x02Go\x03"))
+}
@@ -0,0 +1,365 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"jobot/internal/repository"
+	employerRepo "jobot/internal/repository/employer"
+	"jobot/internal/service/auth"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAuthenticationRequired = errors.New("authentication required")
+	ErrRoleNotAllowed         = errors.New("action is not allowed for user role")
+	ErrForbidden              = errors.New("access to resource is denied")
+	ErrOnBehalfOfUserRequired = errors.New("api key without admin scope must act on behalf of a user")
+)
+
+// AccessService проверяет права пользователя из контекста запроса (см. auth.UserFromContext).
+// Запрос по API ключу с правом admin без X-On-Behalf-Of-User выполняется с правами admin.
+// Роль admin проходит все проверки, остальные пользователи могут менять только свои записи:
+// профиль сотрудника и его резюме, реакции и заявки принадлежат пользователю с ролью employee,
+// профиль работодателя, его вакансии и решения по кандидатам - пользователю с ролью employer.
+type AccessService struct {
+	employeeRepository         repository.EmployeeRepository
+	employerRepository         repository.EmployerRepository
+	resumeRepository           repository.ResumeRepository
+	vacancyRepository          repository.VacancyRepository
+	reactionRepository         repository.ReactionRepository
+	employerReactionRepository repository.EmployerReactionRepository
+	applicationRepository      repository.ApplicationRepository
+}
+
+func NewAccessService(
+	employeeRepository repository.EmployeeRepository,
+	employerRepository repository.EmployerRepository,
+	resumeRepository repository.ResumeRepository,
+	vacancyRepository repository.VacancyRepository,
+	reactionRepository repository.ReactionRepository,
+	employerReactionRepository repository.EmployerReactionRepository,
+	applicationRepository repository.ApplicationRepository,
+) *AccessService {
+	return &AccessService{
+		employeeRepository:         employeeRepository,
+		employerRepository:         employerRepository,
+		resumeRepository:           resumeRepository,
+		vacancyRepository:          vacancyRepository,
+		reactionRepository:         reactionRepository,
+		employerReactionRepository: employerReactionRepository,
+		applicationRepository:      applicationRepository,
+	}
+}
+
+// RequireRole проверяет, что у пользователя одна из ролей roles
+func (s *AccessService) RequireRole(ctx context.Context, roles ...string) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin(user) {
+		return nil
+	}
+
+	for _, role := range roles {
+		if user.Role == role {
+			return nil
+		}
+	}
+
+	return ErrRoleNotAllowed
+}
+
+// CheckUser проверяет, что запись пользователя принадлежит текущему пользователю
+func (s *AccessService) CheckUser(ctx context.Context, userID uuid.UUID) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin(user) || user.ID == userID {
+		return nil
+	}
+
+	return ErrForbidden
+}
+
+// CheckTelegramUser проверяет, что запись пользователя с tgChatID принадлежит текущему пользователю.
+// Используется, когда пользователя в БД еще может не быть (онбординг).
+func (s *AccessService) CheckTelegramUser(ctx context.Context, tgChatID string) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin(user) || user.TgChatID == tgChatID {
+		return nil
+	}
+
+	return ErrForbidden
+}
+
+// CheckNewProfile проверяет, что текущий пользователь создает профиль с ролью role для себя
+func (s *AccessService) CheckNewProfile(ctx context.Context, userID uuid.UUID, role string) error {
+	if err := s.RequireRole(ctx, role); err != nil {
+		return err
+	}
+
+	return s.CheckUser(ctx, userID)
+}
+
+// CheckRoleChange проверяет, что текущий пользователь может назначить роль role.
+// Назначить роль admin может только администратор.
+func (s *AccessService) CheckRoleChange(ctx context.Context, role string) error {
+	if role != models.UserRoleAdmin {
+		return nil
+	}
+
+	return s.RequireRole(ctx, models.UserRoleAdmin)
+}
+
+// CheckEmployee проверяет, что профиль сотрудника принадлежит текущему пользователю с ролью employee
+func (s *AccessService) CheckEmployee(ctx context.Context, employeeID uuid.UUID) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin(user) {
+		return nil
+	}
+
+	if user.Role != models.UserRoleEmployee {
+		return ErrRoleNotAllowed
+	}
+
+	employee, err := s.employeeRepository.GetEmployee(ctx, employeeID)
+	if err != nil {
+		return fmt.Errorf("failed to get employee: %w", err)
+	}
+
+	if employee.UserID != user.ID {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// CheckEmployer проверяет, что профиль работодателя принадлежит текущему пользователю с ролью employer
+func (s *AccessService) CheckEmployer(ctx context.Context, employerID uuid.UUID) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin(user) {
+		return nil
+	}
+
+	if user.Role != models.UserRoleEmployer {
+		return ErrRoleNotAllowed
+	}
+
+	employer, err := s.employerRepository.GetEmployer(ctx, employerID)
+	if err != nil {
+		return fmt.Errorf("failed to get employer: %w", err)
+	}
+
+	if employer.UserID != user.ID {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// CheckResume проверяет, что резюме принадлежит сотруднику текущего пользователя
+func (s *AccessService) CheckResume(ctx context.Context, resumeID uuid.UUID) error {
+	if ok, err := adminOrError(ctx); ok || err != nil {
+		return err
+	}
+
+	resume, err := s.resumeRepository.GetResume(ctx, resumeID)
+	if err != nil {
+		return fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	return s.CheckEmployee(ctx, resume.EmployeeID)
+}
+
+// CheckResumeRead проверяет, что текущий пользователь может читать резюме (см. CheckCandidateResume)
+func (s *AccessService) CheckResumeRead(ctx context.Context, resumeID uuid.UUID) error {
+	if ok, err := adminOrError(ctx); ok || err != nil {
+		return err
+	}
+
+	resume, err := s.resumeRepository.GetResume(ctx, resumeID)
+	if err != nil {
+		return fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	return s.CheckCandidateResume(ctx, resume.EmployeeID)
+}
+
+// CheckCandidateResume проверяет, что текущий пользователь может читать резюме сотрудника employeeID:
+// это сам сотрудник или работодатель, у которого есть этот кандидат (см. EmployerHasCandidate)
+func (s *AccessService) CheckCandidateResume(ctx context.Context, employeeID uuid.UUID) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch user.Role {
+	case models.UserRoleAdmin:
+		return nil
+	case models.UserRoleEmployee:
+		return s.CheckEmployee(ctx, employeeID)
+	case models.UserRoleEmployer:
+		employer, err := s.employerRepository.GetEmployerByUserID(ctx, user.ID)
+		if errors.Is(err, employerRepo.ErrEmployerNotFound) {
+			return ErrForbidden
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get employer: %w", err)
+		}
+
+		ok, err := s.EmployerHasCandidate(ctx, employer.EmployerID, employeeID)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return ErrForbidden
+		}
+
+		return nil
+	}
+
+	return ErrRoleNotAllowed
+}
+
+// EmployerHasCandidate проверяет, что сотрудник employeeID лайкнул активную вакансию работодателя employerID
+// или откликнулся на нее. Только таким работодателям доступно резюме кандидата, в том числе через бота.
+func (s *AccessService) EmployerHasCandidate(ctx context.Context, employerID, employeeID uuid.UUID) (bool, error) {
+	ok, err := s.employerRepository.HasCandidate(ctx, employerID, employeeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check employer candidate: %w", err)
+	}
+
+	return ok, nil
+}
+
+// CheckVacancy проверяет, что вакансия принадлежит работодателю текущего пользователя
+func (s *AccessService) CheckVacancy(ctx context.Context, vacancyID uuid.UUID) error {
+	if ok, err := adminOrError(ctx); ok || err != nil {
+		return err
+	}
+
+	vacancy, err := s.vacancyRepository.GetVacancy(ctx, vacancyID)
+	if err != nil {
+		return fmt.Errorf("failed to get vacancy: %w", err)
+	}
+
+	return s.CheckEmployer(ctx, vacancy.EmployerID)
+}
+
+// CheckReaction проверяет, что реакция на вакансию принадлежит сотруднику текущего пользователя
+func (s *AccessService) CheckReaction(ctx context.Context, reactionID uuid.UUID) error {
+	if ok, err := adminOrError(ctx); ok || err != nil {
+		return err
+	}
+
+	reaction, err := s.reactionRepository.GetReaction(ctx, reactionID)
+	if err != nil {
+		return fmt.Errorf("failed to get reaction: %w", err)
+	}
+
+	return s.CheckEmployee(ctx, reaction.EmployeeID)
+}
+
+// CheckEmployerReaction проверяет, что решение по кандидату принадлежит работодателю текущего пользователя
+func (s *AccessService) CheckEmployerReaction(ctx context.Context, reactionID uuid.UUID) error {
+	if ok, err := adminOrError(ctx); ok || err != nil {
+		return err
+	}
+
+	reaction, err := s.employerReactionRepository.GetEmployerReaction(ctx, reactionID)
+	if err != nil {
+		return fmt.Errorf("failed to get employer reaction: %w", err)
+	}
+
+	return s.CheckEmployer(ctx, reaction.EmployerID)
+}
+
+// CheckApplication проверяет, что текущий пользователь - сторона заявки:
+// сотрудник, который откликнулся, или работодатель, которому принадлежит вакансия
+func (s *AccessService) CheckApplication(ctx context.Context, applicationID uuid.UUID) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin(user) {
+		return nil
+	}
+
+	application, err := s.applicationRepository.GetApplication(ctx, applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+
+	if user.Role == models.UserRoleEmployer {
+		return s.CheckVacancy(ctx, application.VacancyID)
+	}
+
+	return s.CheckEmployee(ctx, application.EmployeeID)
+}
+
+// CheckApplicationActor проверяет, что текущий пользователь действует от имени actorID.
+// Принадлежность заявки стороне проверяет сервис заявок.
+func (s *AccessService) CheckApplicationActor(ctx context.Context, actor string, actorID uuid.UUID) error {
+	switch actor {
+	case models.ApplicationActorEmployee:
+		return s.CheckEmployee(ctx, actorID)
+	case models.ApplicationActorEmployer:
+		return s.CheckEmployer(ctx, actorID)
+	default:
+		// Неизвестную сторону отклонит сервис заявок, но действовать от ее имени может только админ
+		return s.RequireRole(ctx, models.UserRoleAdmin)
+	}
+}
+
+func currentUser(ctx context.Context) (*models.User, error) {
+	if user, ok := auth.UserFromContext(ctx); ok {
+		return user, nil
+	}
+
+	key, ok := auth.APIKeyFromContext(ctx)
+	if !ok {
+		return nil, ErrAuthenticationRequired
+	}
+
+	if !key.HasScope(models.APIKeyScopeAdmin) {
+		return nil, ErrOnBehalfOfUserRequired
+	}
+
+	return &models.User{Role: models.UserRoleAdmin}, nil
+}
+
+// adminOrError возвращает true для администратора и ошибку, если пользователя нет в контексте
+func adminOrError(ctx context.Context) (bool, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return isAdmin(user), nil
+}
+
+func isAdmin(user *models.User) bool {
+	return user.Role == models.UserRoleAdmin
+}
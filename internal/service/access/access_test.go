@@ -0,0 +1,138 @@
+package access_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"jobot/internal/repository"
+	employerRepo "jobot/internal/repository/employer"
+	. "jobot/internal/service/access"
+	"jobot/internal/service/auth"
+	"jobot/internal/service/models"
+)
+
+type employeeRepositoryStub struct {
+	repository.EmployeeRepository
+	employees map[uuid.UUID]*models.Employee
+}
+
+func (r *employeeRepositoryStub) GetEmployee(_ context.Context, id uuid.UUID) (*models.Employee, error) {
+	return r.employees[id], nil
+}
+
+type employerRepositoryStub struct {
+	repository.EmployerRepository
+	employers  map[uuid.UUID]*models.Employer
+	candidates map[uuid.UUID][]uuid.UUID
+}
+
+func (r *employerRepositoryStub) HasCandidate(_ context.Context, employerID, employeeID uuid.UUID) (bool, error) {
+	return slices.Contains(r.candidates[employerID], employeeID), nil
+}
+
+func (r *employerRepositoryStub) GetEmployerByUserID(_ context.Context, userID uuid.UUID) (*models.Employer, error) {
+	employer, ok := r.employers[userID]
+	if !ok {
+		return nil, employerRepo.ErrEmployerNotFound
+	}
+
+	return employer, nil
+}
+
+func userContext(role string) (context.Context, *models.User) {
+	user := &models.User{ID: uuid.New(), Role: role, IsActive: true}
+
+	return auth.ContextWithUser(context.Background(), user), user
+}
+
+func TestCheckUser(t *testing.T) {
+	t.Parallel()
+
+	service := NewAccessService(nil, nil, nil, nil, nil, nil, nil)
+
+	assert.ErrorIs(t, service.CheckUser(context.Background(), uuid.New()), ErrAuthenticationRequired)
+
+	ctx, user := userContext(models.UserRoleEmployee)
+	assert.NoError(t, service.CheckUser(ctx, user.ID))
+	assert.ErrorIs(t, service.CheckUser(ctx, uuid.New()), ErrForbidden)
+
+	adminCtx, _ := userContext(models.UserRoleAdmin)
+	assert.NoError(t, service.CheckUser(adminCtx, user.ID))
+}
+
+func TestCheckEmployee(t *testing.T) {
+	t.Parallel()
+
+	ctx, user := userContext(models.UserRoleEmployee)
+	own := &models.Employee{EmployeeID: uuid.New(), UserID: user.ID}
+	other := &models.Employee{EmployeeID: uuid.New(), UserID: uuid.New()}
+
+	employees := &employeeRepositoryStub{employees: map[uuid.UUID]*models.Employee{
+		own.EmployeeID:   own,
+		other.EmployeeID: other,
+	}}
+	service := NewAccessService(employees, nil, nil, nil, nil, nil, nil)
+
+	assert.NoError(t, service.CheckEmployee(ctx, own.EmployeeID))
+	assert.ErrorIs(t, service.CheckEmployee(ctx, other.EmployeeID), ErrForbidden)
+
+	employerCtx, _ := userContext(models.UserRoleEmployer)
+	assert.ErrorIs(t, service.CheckEmployee(employerCtx, own.EmployeeID), ErrRoleNotAllowed)
+
+	adminCtx, _ := userContext(models.UserRoleAdmin)
+	assert.NoError(t, service.CheckEmployee(adminCtx, other.EmployeeID))
+}
+
+func TestCheckRoleChange(t *testing.T) {
+	t.Parallel()
+
+	service := NewAccessService(nil, nil, nil, nil, nil, nil, nil)
+
+	ctx, _ := userContext(models.UserRoleEmployer)
+	assert.NoError(t, service.CheckRoleChange(ctx, models.UserRoleEmployee))
+	assert.ErrorIs(t, service.CheckRoleChange(ctx, models.UserRoleAdmin), ErrRoleNotAllowed)
+
+	adminCtx, _ := userContext(models.UserRoleAdmin)
+	assert.NoError(t, service.CheckRoleChange(adminCtx, models.UserRoleAdmin))
+}
+
+func TestCheckCandidateResume(t *testing.T) {
+	t.Parallel()
+
+	employeeCtx, employeeUser := userContext(models.UserRoleEmployee)
+	employerCtx, employerUser := userContext(models.UserRoleEmployer)
+	otherEmployerCtx, otherEmployerUser := userContext(models.UserRoleEmployer)
+
+	own := &models.Employee{EmployeeID: uuid.New(), UserID: employeeUser.ID}
+	candidate, stranger := uuid.New(), uuid.New()
+	employer := &models.Employer{EmployerID: uuid.New(), UserID: employerUser.ID}
+
+	service := NewAccessService(
+		&employeeRepositoryStub{employees: map[uuid.UUID]*models.Employee{own.EmployeeID: own}},
+		&employerRepositoryStub{
+			employers: map[uuid.UUID]*models.Employer{
+				employerUser.ID:      employer,
+				otherEmployerUser.ID: {EmployerID: uuid.New(), UserID: otherEmployerUser.ID},
+			},
+			candidates: map[uuid.UUID][]uuid.UUID{employer.EmployerID: {candidate}},
+		},
+		nil, nil, nil, nil, nil,
+	)
+
+	assert.NoError(t, service.CheckCandidateResume(employeeCtx, own.EmployeeID))
+
+	assert.NoError(t, service.CheckCandidateResume(employerCtx, candidate))
+	assert.ErrorIs(t, service.CheckCandidateResume(employerCtx, stranger), ErrForbidden)
+	assert.ErrorIs(t, service.CheckCandidateResume(otherEmployerCtx, candidate), ErrForbidden)
+
+	// Работодатель без профиля
+	noProfileCtx, _ := userContext(models.UserRoleEmployer)
+	assert.ErrorIs(t, service.CheckCandidateResume(noProfileCtx, candidate), ErrForbidden)
+
+	adminCtx, _ := userContext(models.UserRoleAdmin)
+	assert.NoError(t, service.CheckCandidateResume(adminCtx, stranger))
+}
@@ -0,0 +1,250 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"jobot/internal/repository"
+	apiKeyRepo "jobot/internal/repository/apikey"
+	userRepo "jobot/internal/repository/user"
+	"jobot/internal/service/auth"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAPIKey         = errors.New("invalid api key")
+	ErrAPIKeyRevoked         = errors.New("api key is revoked")
+	ErrInvalidScope          = errors.New("invalid api key scope")
+	ErrScopeRequired         = errors.New("api key does not have the required scope")
+	ErrInvalidOnBehalfOfUser = errors.New("invalid on behalf of user")
+)
+
+// Формат ключа: jbk_<prefix>_<secret>, prefix хранится открыто и используется для поиска ключа,
+// в БД хранится только SHA-256 всего ключа. Секрет случайный, поэтому медленный хеш не нужен.
+const (
+	keyMarker       = "jbk"
+	keyPrefixBytes  = 4
+	keySecretBytes  = 32
+	generateRetries = 3
+)
+
+type APIKeyService struct {
+	apiKeyRepository repository.APIKeyRepository
+	userRepository   repository.UserRepository
+	now              func() time.Time
+}
+
+func NewAPIKeyService(apiKeyRepository repository.APIKeyRepository, userRepository repository.UserRepository) *APIKeyService {
+	return &APIKeyService{apiKeyRepository: apiKeyRepository, userRepository: userRepository, now: time.Now}
+}
+
+// CreateAPIKey создает ключ с именем и правами из key и возвращает его значение.
+// Автором ключа записывается пользователь из контекста запроса.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, key *models.APIKey) (*models.IssuedAPIKey, error) {
+	if err := validateScopes(key.Scopes); err != nil {
+		return nil, err
+	}
+
+	key.ID = uuid.New()
+	if user, ok := auth.UserFromContext(ctx); ok {
+		key.CreatedBy = &user.ID
+	}
+
+	now := s.now()
+	key.CreatedAt = now
+	key.UpdatedAt = now
+
+	for attempt := 1; ; attempt++ {
+		rawKey, err := generate(key)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.apiKeyRepository.CreateAPIKey(ctx, key)
+		if errors.Is(err, apiKeyRepo.ErrAPIKeyAlreadyExists) && attempt < generateRetries {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create api key: %w", err)
+		}
+
+		return &models.IssuedAPIKey{APIKey: key, Key: rawKey}, nil
+	}
+}
+
+// GetAPIKeys возвращает все ключи без их значений
+func (s *APIKeyService) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	keys, err := s.apiKeyRepository.GetAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RotateAPIKey выпускает новое значение действующего ключа, старое значение сразу перестает работать
+func (s *APIKeyService) RotateAPIKey(ctx context.Context, id uuid.UUID) (*models.IssuedAPIKey, error) {
+	key, err := s.apiKeyRepository.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key by ID: %w", err)
+	}
+
+	if key.RevokedAt != nil {
+		return nil, ErrAPIKeyRevoked
+	}
+
+	key.UpdatedAt = s.now()
+
+	for attempt := 1; ; attempt++ {
+		rawKey, err := generate(key)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.apiKeyRepository.RotateAPIKey(ctx, key)
+		if errors.Is(err, apiKeyRepo.ErrAPIKeyAlreadyExists) && attempt < generateRetries {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to rotate api key: %w", err)
+		}
+
+		return &models.IssuedAPIKey{APIKey: key, Key: rawKey}, nil
+	}
+}
+
+// RevokeAPIKey отзывает ключ
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	if err := s.apiKeyRepository.RevokeAPIKey(ctx, id, s.now()); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate проверяет значение ключа из заголовка Authorization: ApiKey.
+// onBehalfOf - значение заголовка X-On-Behalf-Of-User, он разрешен только ключам с правом on_behalf_of_user.
+// Возвращает ключ и пользователя, от имени которого выполняется запрос (nil без заголовка).
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey string, onBehalfOf string) (*models.APIKey, *models.User, error) {
+	prefix, ok := parsePrefix(rawKey)
+	if !ok {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.apiKeyRepository.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, apiKeyRepo.ErrAPIKeyNotFound) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+
+		return nil, nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hash(rawKey)), []byte(key.Hash)) != 1 {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	if key.RevokedAt != nil {
+		return nil, nil, ErrAPIKeyRevoked
+	}
+
+	if err := s.apiKeyRepository.TouchAPIKey(ctx, key.ID, s.now()); err != nil {
+		return nil, nil, err
+	}
+
+	if onBehalfOf == "" {
+		return key, nil, nil
+	}
+
+	user, err := s.onBehalfOfUser(ctx, key, onBehalfOf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, user, nil
+}
+
+func (s *APIKeyService) onBehalfOfUser(ctx context.Context, key *models.APIKey, onBehalfOf string) (*models.User, error) {
+	if !key.HasScope(models.APIKeyScopeOnBehalf) {
+		return nil, ErrScopeRequired
+	}
+
+	userID, err := uuid.Parse(onBehalfOf)
+	if err != nil {
+		return nil, fmt.Errorf("%w: must be a user ID", ErrInvalidOnBehalfOfUser)
+	}
+
+	user, err := s.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, userRepo.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: user not found", ErrInvalidOnBehalfOfUser)
+		}
+
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+
+	if !user.IsActive {
+		return nil, fmt.Errorf("%w: user is inactive", ErrInvalidOnBehalfOfUser)
+	}
+
+	// Иначе ключ без права admin получил бы права администратора, в том числе выпуск ключей с правом admin
+	if user.Role == models.UserRoleAdmin && !key.HasScope(models.APIKeyScopeAdmin) {
+		return nil, fmt.Errorf("%w: acting on behalf of an admin requires the admin scope", ErrScopeRequired)
+	}
+
+	return user, nil
+}
+
+// generate выпускает новое значение ключа и записывает в key его префикс и хеш
+func generate(key *models.APIKey) (string, error) {
+	prefix := make([]byte, keyPrefixBytes)
+	secret := make([]byte, keySecretBytes)
+
+	if _, err := rand.Read(prefix); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	key.Prefix = hex.EncodeToString(prefix)
+	rawKey := keyMarker + "_" + key.Prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	key.Hash = hash(rawKey)
+
+	return rawKey, nil
+}
+
+func parsePrefix(rawKey string) (string, bool) {
+	parts := strings.SplitN(rawKey, "_", 3)
+	if len(parts) != 3 || parts[0] != keyMarker || len(parts[1]) != 2*keyPrefixBytes || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+func hash(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func validateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if scope != models.APIKeyScopeAdmin && scope != models.APIKeyScopeOnBehalf {
+			return fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+
+	return nil
+}
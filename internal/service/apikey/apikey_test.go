@@ -0,0 +1,120 @@
+package apikey_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	apiKeyRepo "jobot/internal/repository/apikey"
+	. "jobot/internal/service/apikey"
+	"jobot/internal/service/models"
+)
+
+type apiKeyRepositoryStub struct {
+	repository.APIKeyRepository
+	keys map[string]*models.APIKey
+}
+
+func (r *apiKeyRepositoryStub) CreateAPIKey(_ context.Context, key *models.APIKey) error {
+	stored := *key
+	r.keys[key.Prefix] = &stored
+
+	return nil
+}
+
+func (r *apiKeyRepositoryStub) GetAPIKeyByPrefix(_ context.Context, prefix string) (*models.APIKey, error) {
+	key, ok := r.keys[prefix]
+	if !ok {
+		return nil, apiKeyRepo.ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (r *apiKeyRepositoryStub) TouchAPIKey(context.Context, uuid.UUID, time.Time) error {
+	return nil
+}
+
+type userRepositoryStub struct {
+	repository.UserRepository
+	user *models.User
+}
+
+func (r *userRepositoryStub) GetUser(context.Context, uuid.UUID) (*models.User, error) {
+	return r.user, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	user := &models.User{ID: uuid.New(), Role: models.UserRoleEmployee, IsActive: true}
+	keys := &apiKeyRepositoryStub{keys: map[string]*models.APIKey{}}
+	service := NewAPIKeyService(keys, &userRepositoryStub{user: user})
+
+	issued, err := service.CreateAPIKey(ctx, &models.APIKey{Name: "bot", Scopes: []string{models.APIKeyScopeOnBehalf}})
+	require.NoError(t, err)
+
+	key, onBehalfOf, err := service.Authenticate(ctx, issued.Key, "")
+	require.NoError(t, err)
+	assert.Equal(t, issued.APIKey.ID, key.ID)
+	assert.Nil(t, onBehalfOf)
+
+	_, onBehalfOf, err = service.Authenticate(ctx, issued.Key, user.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, onBehalfOf.ID)
+
+	_, _, err = service.Authenticate(ctx, issued.Key+"x", "")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	_, _, err = service.Authenticate(ctx, "not-a-key", "")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	now := time.Now()
+	keys.keys[issued.APIKey.Prefix].RevokedAt = &now
+	_, _, err = service.Authenticate(ctx, issued.Key, "")
+	assert.ErrorIs(t, err, ErrAPIKeyRevoked)
+}
+
+func TestAuthenticateOnBehalfRequiresScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	user := &models.User{ID: uuid.New(), Role: models.UserRoleEmployee, IsActive: true}
+	service := NewAPIKeyService(&apiKeyRepositoryStub{keys: map[string]*models.APIKey{}}, &userRepositoryStub{user: user})
+
+	issued, err := service.CreateAPIKey(ctx, &models.APIKey{Name: "reporting", Scopes: []string{models.APIKeyScopeAdmin}})
+	require.NoError(t, err)
+
+	_, _, err = service.Authenticate(ctx, issued.Key, user.ID.String())
+	assert.ErrorIs(t, err, ErrScopeRequired)
+
+	_, err = service.CreateAPIKey(ctx, &models.APIKey{Name: "bad", Scopes: []string{"root"}})
+	assert.ErrorIs(t, err, ErrInvalidScope)
+}
+
+func TestAuthenticateOnBehalfOfAdminRequiresAdminScope(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	admin := &models.User{ID: uuid.New(), Role: models.UserRoleAdmin, IsActive: true}
+	service := NewAPIKeyService(&apiKeyRepositoryStub{keys: map[string]*models.APIKey{}}, &userRepositoryStub{user: admin})
+
+	onBehalf, err := service.CreateAPIKey(ctx, &models.APIKey{Name: "bot", Scopes: []string{models.APIKeyScopeOnBehalf}})
+	require.NoError(t, err)
+
+	_, _, err = service.Authenticate(ctx, onBehalf.Key, admin.ID.String())
+	assert.ErrorIs(t, err, ErrScopeRequired)
+
+	full, err := service.CreateAPIKey(ctx, &models.APIKey{Name: "ops", Scopes: []string{models.APIKeyScopeAdmin, models.APIKeyScopeOnBehalf}})
+	require.NoError(t, err)
+
+	_, user, err := service.Authenticate(ctx, full.Key, admin.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, admin.ID, user.ID)
+}
@@ -0,0 +1,262 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"jobot/internal/repository"
+	resumeRepo "jobot/internal/repository/resume"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidApplicationStatus = errors.New("invalid application status")
+	ErrInvalidActor             = errors.New("invalid application actor")
+	ErrTransitionNotAllowed     = errors.New("application status transition is not allowed")
+	ErrApplicationNotOwned      = errors.New("application does not belong to actor")
+	ErrResumeNotOwned           = errors.New("resume does not belong to employee")
+)
+
+// transitions - конечный автомат статусов заявки: из какого статуса в какой можно перейти и какой стороне.
+// hired и rejected - финальные статусы. Сотрудник может отозвать заявку (rejected) на любом этапе
+// и принять предложение (offer → hired), остальные этапы двигает работодатель.
+var transitions = map[string]map[string][]string{
+	models.ApplicationStatusApplied: {
+		models.ApplicationStatusViewed:   {models.ApplicationActorEmployer},
+		models.ApplicationStatusRejected: {models.ApplicationActorEmployer, models.ApplicationActorEmployee},
+	},
+	models.ApplicationStatusViewed: {
+		models.ApplicationStatusInterview: {models.ApplicationActorEmployer},
+		models.ApplicationStatusRejected:  {models.ApplicationActorEmployer, models.ApplicationActorEmployee},
+	},
+	models.ApplicationStatusInterview: {
+		models.ApplicationStatusOffer:    {models.ApplicationActorEmployer},
+		models.ApplicationStatusRejected: {models.ApplicationActorEmployer, models.ApplicationActorEmployee},
+	},
+	models.ApplicationStatusOffer: {
+		models.ApplicationStatusHired:    {models.ApplicationActorEmployee},
+		models.ApplicationStatusRejected: {models.ApplicationActorEmployer, models.ApplicationActorEmployee},
+	},
+}
+
+type ApplicationService struct {
+	applicationRepository repository.ApplicationRepository
+	employeeRepository    repository.EmployeeRepository
+	vacancyRepository     repository.VacancyRepository
+	resumeRepository      repository.ResumeRepository
+}
+
+func NewApplicationService(
+	applicationRepository repository.ApplicationRepository,
+	employeeRepository repository.EmployeeRepository,
+	vacancyRepository repository.VacancyRepository,
+	resumeRepository repository.ResumeRepository,
+) *ApplicationService {
+	return &ApplicationService{
+		applicationRepository: applicationRepository,
+		employeeRepository:    employeeRepository,
+		vacancyRepository:     vacancyRepository,
+		resumeRepository:      resumeRepository,
+	}
+}
+
+func (s *ApplicationService) CreateApplication(ctx context.Context, application *models.Application) (*models.Application, error) {
+	if _, err := s.employeeRepository.GetEmployee(ctx, application.EmployeeID); err != nil {
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+
+	if _, err := s.vacancyRepository.GetVacancy(ctx, application.VacancyID); err != nil {
+		return nil, fmt.Errorf("failed to get vacancy: %w", err)
+	}
+
+	if application.ResumeID != nil {
+		resume, err := s.resumeRepository.GetResume(ctx, *application.ResumeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resume: %w", err)
+		}
+
+		if resume.EmployeeID != application.EmployeeID {
+			return nil, ErrResumeNotOwned
+		}
+	} else {
+		// Если резюме не указано, прикладываем текущее резюме сотрудника, если оно есть
+		resume, err := s.resumeRepository.GetResumeByEmployeeID(ctx, application.EmployeeID)
+		if err != nil && !errors.Is(err, resumeRepo.ErrResumeNotFound) {
+			return nil, fmt.Errorf("failed to get employee resume: %w", err)
+		}
+
+		if resume != nil {
+			application.ResumeID = &resume.ResumeID
+		}
+	}
+
+	application.ID = uuid.New()
+	application.Status = models.ApplicationStatusApplied
+	now := time.Now()
+	application.CreatedAt = now
+	application.UpdatedAt = now
+
+	transition := &models.ApplicationTransition{
+		ID:            uuid.New(),
+		ApplicationID: application.ID,
+		ToStatus:      models.ApplicationStatusApplied,
+		Actor:         models.ApplicationActorEmployee,
+		CreatedAt:     now,
+	}
+
+	if err := s.applicationRepository.CreateApplication(ctx, application, transition); err != nil {
+		return nil, fmt.Errorf("failed to create application: %w", err)
+	}
+
+	application.History = []models.ApplicationTransition{*transition}
+
+	return application, nil
+}
+
+func (s *ApplicationService) GetApplication(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	application, err := s.applicationRepository.GetApplication(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application by ID: %w", err)
+	}
+
+	application.History, err = s.applicationRepository.GetApplicationHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application history: %w", err)
+	}
+
+	return application, nil
+}
+
+func (s *ApplicationService) GetEmployeeApplications(ctx context.Context, employeeID uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	applicationList, err := s.applicationRepository.GetApplicationsByEmployee(ctx, employeeID, filter, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications by employee ID: %w", err)
+	}
+
+	return applicationList, nil
+}
+
+func (s *ApplicationService) GetEmployerApplications(ctx context.Context, employerID uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	applicationList, err := s.applicationRepository.GetApplicationsByEmployer(ctx, employerID, filter, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications by employer ID: %w", err)
+	}
+
+	return applicationList, nil
+}
+
+// TransitApplication переводит заявку в новый статус, если переход разрешен автоматом transitions
+// и выполняющая его сторона является участником заявки
+func (s *ApplicationService) TransitApplication(ctx context.Context, req *models.ApplicationTransitionRequest, id uuid.UUID) (*models.Application, error) {
+	if !isValidStatus(req.Status) {
+		return nil, ErrInvalidApplicationStatus
+	}
+
+	if req.Actor != models.ApplicationActorEmployee && req.Actor != models.ApplicationActorEmployer {
+		return nil, ErrInvalidActor
+	}
+
+	application, err := s.applicationRepository.GetApplication(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	if err = s.checkActor(ctx, application, req.Actor, req.ActorID); err != nil {
+		return nil, err
+	}
+
+	if !IsTransitionAllowed(application.Status, req.Status, req.Actor) {
+		return nil, fmt.Errorf("%w: %s → %s by %s", ErrTransitionNotAllowed, application.Status, req.Status, req.Actor)
+	}
+
+	fromStatus := application.Status
+	now := time.Now()
+
+	application.Status = req.Status
+	application.UpdatedAt = now
+
+	transition := &models.ApplicationTransition{
+		ID:            uuid.New(),
+		ApplicationID: application.ID,
+		FromStatus:    &fromStatus,
+		ToStatus:      req.Status,
+		Actor:         req.Actor,
+		Comment:       req.Comment,
+		CreatedAt:     now,
+	}
+
+	err = s.applicationRepository.UpdateApplicationStatus(ctx, application, transition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update application status: %w", err)
+	}
+
+	application.History, err = s.applicationRepository.GetApplicationHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application history: %w", err)
+	}
+
+	return application, nil
+}
+
+// checkActor проверяет, что сторона, меняющая статус, является участником заявки
+func (s *ApplicationService) checkActor(ctx context.Context, application *models.Application, actor string, actorID uuid.UUID) error {
+	if actor == models.ApplicationActorEmployee {
+		if application.EmployeeID != actorID {
+			return ErrApplicationNotOwned
+		}
+
+		return nil
+	}
+
+	vacancy, err := s.vacancyRepository.GetVacancy(ctx, application.VacancyID)
+	if err != nil {
+		return fmt.Errorf("failed to get vacancy: %w", err)
+	}
+
+	if vacancy.EmployerID != actorID {
+		return ErrApplicationNotOwned
+	}
+
+	return nil
+}
+
+// IsTransitionAllowed сообщает, может ли сторона actor перевести заявку из статуса from в статус to
+func IsTransitionAllowed(from, to, actor string) bool {
+	actors, ok := transitions[from][to]
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(actors, actor)
+}
+
+func validateFilter(filter *models.ApplicationFilter) error {
+	if filter != nil && filter.Status != nil && !isValidStatus(*filter.Status) {
+		return ErrInvalidApplicationStatus
+	}
+
+	return nil
+}
+
+func isValidStatus(status string) bool {
+	switch status {
+	case models.ApplicationStatusApplied, models.ApplicationStatusViewed, models.ApplicationStatusInterview,
+		models.ApplicationStatusOffer, models.ApplicationStatusHired, models.ApplicationStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,36 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "jobot/internal/service/application"
+	"jobot/internal/service/models"
+)
+
+func TestIsTransitionAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from, to, actor string
+		allowed         bool
+	}{
+		{models.ApplicationStatusApplied, models.ApplicationStatusViewed, models.ApplicationActorEmployer, true},
+		{models.ApplicationStatusApplied, models.ApplicationStatusViewed, models.ApplicationActorEmployee, false},
+		{models.ApplicationStatusApplied, models.ApplicationStatusInterview, models.ApplicationActorEmployer, false},
+		{models.ApplicationStatusViewed, models.ApplicationStatusInterview, models.ApplicationActorEmployer, true},
+		{models.ApplicationStatusInterview, models.ApplicationStatusOffer, models.ApplicationActorEmployer, true},
+		{models.ApplicationStatusOffer, models.ApplicationStatusHired, models.ApplicationActorEmployee, true},
+		{models.ApplicationStatusOffer, models.ApplicationStatusHired, models.ApplicationActorEmployer, false},
+		{models.ApplicationStatusInterview, models.ApplicationStatusRejected, models.ApplicationActorEmployee, true},
+		{models.ApplicationStatusOffer, models.ApplicationStatusRejected, models.ApplicationActorEmployer, true},
+		{models.ApplicationStatusHired, models.ApplicationStatusRejected, models.ApplicationActorEmployer, false},
+		{models.ApplicationStatusRejected, models.ApplicationStatusApplied, models.ApplicationActorEmployee, false},
+		{models.ApplicationStatusViewed, models.ApplicationStatusViewed, models.ApplicationActorEmployer, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, IsTransitionAllowed(tt.from, tt.to, tt.actor), "%s → %s by %s", tt.from, tt.to, tt.actor)
+	}
+}
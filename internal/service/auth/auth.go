@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"jobot/internal/repository"
+	userRepo "jobot/internal/repository/user"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserInactive = errors.New("user is inactive")
+	ErrInvalidRole  = errors.New("invalid user role")
+)
+
+// TelegramConfig - параметры проверки данных входа через Telegram
+// MaxAge - сколько времени после auth_date данные входа считаются действительными
+type TelegramConfig struct {
+	BotToken string
+	MaxAge   time.Duration
+}
+
+type AuthService struct {
+	userRepository repository.UserRepository
+	tokens         *TokenManager
+	telegram       TelegramConfig
+	now            func() time.Time
+}
+
+func NewAuthService(userRepository repository.UserRepository, tokens *TokenManager, telegram TelegramConfig) *AuthService {
+	return &AuthService{userRepository: userRepository, tokens: tokens, telegram: telegram, now: time.Now}
+}
+
+// LoginTelegramWidget проверяет данные Telegram Login Widget и выдает токены.
+// role используется только при создании нового пользователя, по умолчанию employee.
+func (s *AuthService) LoginTelegramWidget(ctx context.Context, data map[string]string, role string) (*models.AuthResult, error) {
+	identity, err := VerifyLoginWidget(data, s.telegram.BotToken, s.telegram.MaxAge, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.login(ctx, identity, role)
+}
+
+// LoginTelegramWebApp проверяет initData Telegram Mini App и выдает токены.
+// role используется только при создании нового пользователя, по умолчанию employee.
+func (s *AuthService) LoginTelegramWebApp(ctx context.Context, initData string, role string) (*models.AuthResult, error) {
+	identity, err := VerifyWebAppInitData(initData, s.telegram.BotToken, s.telegram.MaxAge, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.login(ctx, identity, role)
+}
+
+// RefreshTokens выдает новую пару токенов по действующему refresh токену
+func (s *AuthService) RefreshTokens(ctx context.Context, refreshToken string) (*models.AuthResult, error) {
+	claims, err := s.tokens.Parse(refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.activeUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.tokens.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResult{User: user, Tokens: tokens}, nil
+}
+
+// Authenticate проверяет access токен и возвращает активного пользователя
+func (s *AuthService) Authenticate(ctx context.Context, accessToken string) (*models.User, error) {
+	claims, err := s.tokens.Parse(accessToken, TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.activeUser(ctx, claims)
+}
+
+// login находит пользователя по tg_chat_id или создает его и выдает токены
+func (s *AuthService) login(ctx context.Context, identity *models.TelegramIdentity, role string) (*models.AuthResult, error) {
+	if role == "" {
+		role = models.UserRoleEmployee
+	}
+	if role != models.UserRoleEmployee && role != models.UserRoleEmployer {
+		return nil, ErrInvalidRole
+	}
+
+	tgChatID := strconv.FormatInt(identity.ID, 10)
+	created := false
+
+	user, err := s.userRepository.GetUserByTgChatID(ctx, tgChatID)
+	if errors.Is(err, userRepo.ErrUserNotFound) {
+		user, created, err = s.createUser(ctx, identity, tgChatID, role)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by tg chat ID: %w", err)
+	}
+
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	if identity.UserName != "" && identity.UserName != user.TgUserName {
+		user.TgUserName = identity.UserName
+		if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+	}
+
+	tokens, err := s.tokens.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResult{User: user, Tokens: tokens, Created: created}, nil
+}
+
+// createUser создает пользователя при первом входе.
+// Если пользователя параллельно создал другой запрос, возвращает его с created = false.
+func (s *AuthService) createUser(ctx context.Context, identity *models.TelegramIdentity, tgChatID, role string) (*models.User, bool, error) {
+	now := s.now()
+	user := &models.User{
+		ID:         uuid.New(),
+		TgUserName: identity.UserName,
+		TgChatID:   tgChatID,
+		IsActive:   true,
+		Role:       role,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	err := s.userRepository.CreateUser(ctx, user)
+	if errors.Is(err, userRepo.ErrUserAlreadyExists) {
+		user, err = s.userRepository.GetUserByTgChatID(ctx, tgChatID)
+
+		return user, false, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return user, true, nil
+}
+
+func (s *AuthService) activeUser(ctx context.Context, claims *Claims) (*models.User, error) {
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, userRepo.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: user not found", ErrInvalidToken)
+		}
+
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	return user, nil
+}
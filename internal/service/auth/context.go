@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"context"
+
+	"jobot/internal/service/models"
+)
+
+type userContextKey struct{}
+
+// ContextWithUser возвращает контекст с аутентифицированным пользователем
+func ContextWithUser(ctx context.Context, user *models.User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext возвращает аутентифицированного пользователя из контекста
+func UserFromContext(ctx context.Context) (*models.User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(*models.User)
+
+	return user, ok && user != nil
+}
+
+type apiKeyContextKey struct{}
+
+// ContextWithAPIKey возвращает контекст с API ключом, которым аутентифицирован запрос
+func ContextWithAPIKey(ctx context.Context, key *models.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+// APIKeyFromContext возвращает API ключ запроса из контекста
+func APIKeyFromContext(ctx context.Context) (*models.APIKey, bool) {
+	key, ok := ctx.Value(apiKeyContextKey{}).(*models.APIKey)
+
+	return key, ok && key != nil
+}
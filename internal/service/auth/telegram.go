@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobot/internal/service/models"
+)
+
+var (
+	ErrInvalidTelegramData      = errors.New("invalid telegram auth data")
+	ErrInvalidTelegramSignature = errors.New("invalid telegram auth data signature")
+	ErrTelegramAuthExpired      = errors.New("telegram auth data is expired")
+)
+
+// webAppSecretKey - ключ, которым Telegram подписывает секрет для initData Mini App
+const webAppSecretKey = "WebAppData"
+
+// VerifyLoginWidget проверяет данные Telegram Login Widget.
+// data - все поля, которые прислал виджет, включая hash.
+// Секрет подписи - SHA256 от токена бота (https://core.telegram.org/widgets/login#checking-authorization).
+func VerifyLoginWidget(data map[string]string, botToken string, maxAge time.Duration, now time.Time) (*models.TelegramIdentity, error) {
+	secret := sha256.Sum256([]byte(botToken))
+
+	if err := verifySignature(data, secret[:]); err != nil {
+		return nil, err
+	}
+
+	authDate, err := checkAuthDate(data["auth_date"], maxAge, now)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseInt(data["id"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid id", ErrInvalidTelegramData)
+	}
+
+	return &models.TelegramIdentity{
+		ID:        id,
+		UserName:  data["username"],
+		FirstName: data["first_name"],
+		LastName:  data["last_name"],
+		AuthDate:  authDate,
+	}, nil
+}
+
+// VerifyWebAppInitData проверяет строку initData Telegram Mini App.
+// Секрет подписи - HMAC-SHA256 токена бота с ключом "WebAppData"
+// (https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app).
+func VerifyWebAppInitData(initData string, botToken string, maxAge time.Duration, now time.Time) (*models.TelegramIdentity, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTelegramData, err)
+	}
+
+	data := make(map[string]string, len(values))
+	for key := range values {
+		data[key] = values.Get(key)
+	}
+
+	mac := hmac.New(sha256.New, []byte(webAppSecretKey))
+	mac.Write([]byte(botToken))
+
+	if err := verifySignature(data, mac.Sum(nil)); err != nil {
+		return nil, err
+	}
+
+	authDate, err := checkAuthDate(data["auth_date"], maxAge, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		ID        int64  `json:"id"`
+		UserName  string `json:"username"`
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	}
+	if err := json.Unmarshal([]byte(data["user"]), &user); err != nil || user.ID == 0 {
+		return nil, fmt.Errorf("%w: invalid user", ErrInvalidTelegramData)
+	}
+
+	return &models.TelegramIdentity{
+		ID:        user.ID,
+		UserName:  user.UserName,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		AuthDate:  authDate,
+	}, nil
+}
+
+// verifySignature сравнивает hash из data с HMAC-SHA256 строки проверки данных
+func verifySignature(data map[string]string, secret []byte) error {
+	hash, ok := data["hash"]
+	if !ok || hash == "" {
+		return fmt.Errorf("%w: missing hash", ErrInvalidTelegramData)
+	}
+
+	expected, err := hex.DecodeString(hash)
+	if err != nil {
+		return ErrInvalidTelegramSignature
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(dataCheckString(data)))
+
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidTelegramSignature
+	}
+
+	return nil
+}
+
+// dataCheckString собирает строку проверки данных: пары key=value всех полей,
+// кроме hash, отсортированные по ключу и разделенные переводом строки
+func dataCheckString(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if key == "hash" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+data[key])
+	}
+
+	return strings.Join(pairs, "\n")
+}
+
+func checkAuthDate(value string, maxAge time.Duration, now time.Time) (time.Time, error) {
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid auth_date", ErrInvalidTelegramData)
+	}
+
+	authDate := time.Unix(unix, 0)
+	if maxAge > 0 && now.Sub(authDate) > maxAge {
+		return time.Time{}, ErrTelegramAuthExpired
+	}
+
+	return authDate, nil
+}
@@ -0,0 +1,109 @@
+package auth_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/internal/service/auth"
+)
+
+const testBotToken = "123456:test-bot-token"
+
+// sign подписывает поля так же, как это делает Telegram
+func sign(data map[string]string, secret []byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+data[key])
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join(pairs, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyLoginWidget(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	secret := sha256.Sum256([]byte(testBotToken))
+
+	signed := func(authDate time.Time) map[string]string {
+		data := map[string]string{
+			"id":         "42",
+			"first_name": "Ivan",
+			"username":   "ivan",
+			"auth_date":  strconv.FormatInt(authDate.Unix(), 10),
+		}
+		data["hash"] = sign(data, secret[:])
+
+		return data
+	}
+
+	identity, err := VerifyLoginWidget(signed(now.Add(-time.Minute)), testBotToken, time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), identity.ID)
+	assert.Equal(t, "ivan", identity.UserName)
+	assert.Equal(t, "Ivan", identity.FirstName)
+
+	tampered := signed(now)
+	tampered["id"] = "43"
+	_, err = VerifyLoginWidget(tampered, testBotToken, time.Hour, now)
+	assert.ErrorIs(t, err, ErrInvalidTelegramSignature)
+
+	_, err = VerifyLoginWidget(signed(now), "654321:other-token", time.Hour, now)
+	assert.ErrorIs(t, err, ErrInvalidTelegramSignature)
+
+	_, err = VerifyLoginWidget(signed(now.Add(-2*time.Hour)), testBotToken, time.Hour, now)
+	assert.ErrorIs(t, err, ErrTelegramAuthExpired)
+
+	_, err = VerifyLoginWidget(map[string]string{"id": "42"}, testBotToken, time.Hour, now)
+	assert.ErrorIs(t, err, ErrInvalidTelegramData)
+}
+
+func TestVerifyWebAppInitData(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+
+	mac := hmac.New(sha256.New, []byte("WebAppData"))
+	mac.Write([]byte(testBotToken))
+	secret := mac.Sum(nil)
+
+	data := map[string]string{
+		"query_id":  "AAH",
+		"user":      `{"id":42,"first_name":"Ivan","username":"ivan"}`,
+		"auth_date": strconv.FormatInt(now.Unix(), 10),
+	}
+	data["hash"] = sign(data, secret)
+
+	values := url.Values{}
+	for key, value := range data {
+		values.Set(key, value)
+	}
+
+	identity, err := VerifyWebAppInitData(values.Encode(), testBotToken, time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), identity.ID)
+	assert.Equal(t, "ivan", identity.UserName)
+
+	values.Set("user", `{"id":43,"first_name":"Ivan","username":"ivan"}`)
+	_, err = VerifyWebAppInitData(values.Encode(), testBotToken, time.Hour, now)
+	assert.ErrorIs(t, err, ErrInvalidTelegramSignature)
+}
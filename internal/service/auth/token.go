@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"jobot/internal/service/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token is expired")
+)
+
+// Типы токенов, передаются в claim typ
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// TokenConfig - параметры выпуска JWT токенов
+type TokenConfig struct {
+	Secret     string
+	Issuer     string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// Claims - claims JWT токена, sub содержит ID пользователя
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+	Type string `json:"typ"`
+}
+
+// TokenManager выпускает и проверяет JWT токены, подписанные HS256
+type TokenManager struct {
+	cfg TokenConfig
+	now func() time.Time
+}
+
+func NewTokenManager(cfg TokenConfig) *TokenManager {
+	return &TokenManager{cfg: cfg, now: time.Now}
+}
+
+// Issue выпускает пару access и refresh токенов для пользователя
+func (m *TokenManager) Issue(user *models.User) (*models.AuthTokens, error) {
+	now := m.now()
+
+	accessToken, accessExpiresAt, err := m.sign(user, TokenTypeAccess, m.cfg.AccessTTL, now)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshExpiresAt, err := m.sign(user, TokenTypeRefresh, m.cfg.RefreshTTL, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthTokens{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// Parse проверяет подпись, срок действия и тип токена и возвращает его claims
+func (m *TokenManager) Parse(tokenString string, tokenType string) (*Claims, error) {
+	claims := &Claims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(m.cfg.Secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(m.cfg.Issuer),
+		jwt.WithTimeFunc(m.now),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if claims.Type != tokenType {
+		return nil, fmt.Errorf("%w: unexpected token type %q", ErrInvalidToken, claims.Type)
+	}
+
+	return claims, nil
+}
+
+// UserID возвращает ID пользователя из claim sub
+func (c *Claims) UserID() (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: invalid subject", ErrInvalidToken)
+	}
+
+	return id, nil
+}
+
+func (m *TokenManager) sign(user *models.User, tokenType string, ttl time.Duration, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(ttl)
+
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   user.ID.String(),
+			Issuer:    m.cfg.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: user.Role,
+		Type: tokenType,
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(m.cfg.Secret))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign %s token: %w", tokenType, err)
+	}
+
+	return signed, expiresAt, nil
+}
@@ -0,0 +1,137 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"jobot/internal/repository"
+	conversationRepo "jobot/internal/repository/conversation"
+	"jobot/internal/service/models"
+)
+
+var (
+	ErrConversationExpired = errors.New("conversation timed out")
+	ErrFirstStep           = errors.New("conversation is at the first step")
+)
+
+// DefaultTTL - таймаут диалога без ответа пользователя
+const DefaultTTL = 30 * time.Minute
+
+// ConversationService хранит состояние многошаговых диалогов бота (FSM) по tg_chat_id.
+// Сценарии и их шаги определяет транспорт, сервис отвечает за шаг, черновик и таймаут.
+// Каждое изменение продлевает диалог на ttl.
+type ConversationService struct {
+	conversationRepository repository.ConversationRepository
+	ttl                    time.Duration
+	now                    func() time.Time
+}
+
+func NewConversationService(conversationRepository repository.ConversationRepository, ttl time.Duration) *ConversationService {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &ConversationService{conversationRepository: conversationRepository, ttl: ttl, now: time.Now}
+}
+
+// StartConversation начинает диалог flow с первого шага.
+// Если у чата уже есть черновик того же сценария, диалог продолжается с сохраненного шага (resumed = true),
+// черновик другого сценария заменяется.
+func (s *ConversationService) StartConversation(ctx context.Context, tgChatID, flow string) (*models.Conversation, bool, error) {
+	conversation, err := s.conversationRepository.GetConversation(ctx, tgChatID)
+	switch {
+	case err == nil && conversation.Flow == flow:
+		if err := s.save(ctx, conversation); err != nil {
+			return nil, false, err
+		}
+
+		return conversation, true, nil
+	case err != nil && !errors.Is(err, conversationRepo.ErrConversationNotFound):
+		return nil, false, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	conversation = &models.Conversation{
+		TgChatID:  tgChatID,
+		Flow:      flow,
+		Draft:     map[string]string{},
+		CreatedAt: s.now(),
+	}
+
+	if err := s.save(ctx, conversation); err != nil {
+		return nil, false, err
+	}
+
+	return conversation, false, nil
+}
+
+// GetConversation возвращает активный диалог чата.
+// Если таймаут истек, возвращает ErrConversationExpired: черновик сохранен, его можно продолжить ResumeConversation.
+func (s *ConversationService) GetConversation(ctx context.Context, tgChatID string) (*models.Conversation, error) {
+	conversation, err := s.conversationRepository.GetConversation(ctx, tgChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if conversation.Expired(s.now()) {
+		return nil, ErrConversationExpired
+	}
+
+	return conversation, nil
+}
+
+// ResumeConversation продолжает диалог чата, в том числе прерванный по таймауту, с сохраненного шага
+func (s *ConversationService) ResumeConversation(ctx context.Context, tgChatID string) (*models.Conversation, error) {
+	conversation, err := s.conversationRepository.GetConversation(ctx, tgChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, conversation); err != nil {
+		return nil, err
+	}
+
+	return conversation, nil
+}
+
+// AnswerStep записывает ответ на текущий шаг в черновик и переходит к следующему шагу
+func (s *ConversationService) AnswerStep(ctx context.Context, conversation *models.Conversation, field, value string) error {
+	if conversation.Draft == nil {
+		conversation.Draft = map[string]string{}
+	}
+
+	conversation.Draft[field] = value
+	conversation.Step++
+
+	return s.save(ctx, conversation)
+}
+
+// StepBack возвращает диалог на предыдущий шаг, введенные значения остаются в черновике
+func (s *ConversationService) StepBack(ctx context.Context, conversation *models.Conversation) error {
+	if conversation.Step == 0 {
+		return ErrFirstStep
+	}
+
+	conversation.Step--
+
+	return s.save(ctx, conversation)
+}
+
+// DeleteConversation завершает или отменяет диалог чата вместе с черновиком
+func (s *ConversationService) DeleteConversation(ctx context.Context, tgChatID string) error {
+	return s.conversationRepository.DeleteConversation(ctx, tgChatID)
+}
+
+// save сохраняет диалог, продлевая его таймаут
+func (s *ConversationService) save(ctx context.Context, conversation *models.Conversation) error {
+	now := s.now()
+	conversation.ExpiresAt = now.Add(s.ttl)
+	conversation.UpdatedAt = now
+
+	if err := s.conversationRepository.SaveConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to save conversation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package conversation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	conversationRepo "jobot/internal/repository/conversation"
+	. "jobot/internal/service/conversation"
+	"jobot/internal/service/models"
+)
+
+type conversationRepositoryStub struct {
+	repository.ConversationRepository
+	conversations map[string]models.Conversation
+}
+
+func (r *conversationRepositoryStub) GetConversation(_ context.Context, tgChatID string) (*models.Conversation, error) {
+	conversation, ok := r.conversations[tgChatID]
+	if !ok {
+		return nil, conversationRepo.ErrConversationNotFound
+	}
+
+	conversation.Draft = copyDraft(conversation.Draft)
+
+	return &conversation, nil
+}
+
+func (r *conversationRepositoryStub) SaveConversation(_ context.Context, conversation *models.Conversation) error {
+	stored := *conversation
+	stored.Draft = copyDraft(conversation.Draft)
+	r.conversations[conversation.TgChatID] = stored
+
+	return nil
+}
+
+func (r *conversationRepositoryStub) DeleteConversation(_ context.Context, tgChatID string) error {
+	if _, ok := r.conversations[tgChatID]; !ok {
+		return conversationRepo.ErrConversationNotFound
+	}
+
+	delete(r.conversations, tgChatID)
+
+	return nil
+}
+
+func copyDraft(draft map[string]string) map[string]string {
+	copied := make(map[string]string, len(draft))
+	for field, value := range draft {
+		copied[field] = value
+	}
+
+	return copied
+}
+
+func TestConversationSteps(t *testing.T) {
+	repo := &conversationRepositoryStub{conversations: map[string]models.Conversation{}}
+	service := NewConversationService(repo, time.Hour)
+	ctx := context.Background()
+
+	conversation, resumed, err := service.StartConversation(ctx, "42", "vacancy")
+	require.NoError(t, err)
+	assert.False(t, resumed)
+	assert.Equal(t, 0, conversation.Step)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), conversation.ExpiresAt, time.Minute)
+
+	assert.ErrorIs(t, service.StepBack(ctx, conversation), ErrFirstStep)
+
+	require.NoError(t, service.AnswerStep(ctx, conversation, "title", "Go developer"))
+	require.NoError(t, service.AnswerStep(ctx, conversation, "description", "API"))
+	require.NoError(t, service.StepBack(ctx, conversation))
+
+	// Черновик переживает перезапуск: новый сервис читает его из хранилища
+	restarted := NewConversationService(repo, time.Hour)
+
+	conversation, resumed, err = restarted.StartConversation(ctx, "42", "vacancy")
+	require.NoError(t, err)
+	assert.True(t, resumed)
+	assert.Equal(t, 1, conversation.Step)
+	assert.Equal(t, map[string]string{"title": "Go developer", "description": "API"}, conversation.Draft)
+
+	// Другой сценарий заменяет черновик
+	conversation, resumed, err = restarted.StartConversation(ctx, "42", "employer")
+	require.NoError(t, err)
+	assert.False(t, resumed)
+	assert.Equal(t, 0, conversation.Step)
+	assert.Empty(t, conversation.Draft)
+
+	require.NoError(t, restarted.DeleteConversation(ctx, "42"))
+
+	_, err = restarted.GetConversation(ctx, "42")
+	assert.ErrorIs(t, err, conversationRepo.ErrConversationNotFound)
+}
+
+func TestConversationTimeout(t *testing.T) {
+	repo := &conversationRepositoryStub{conversations: map[string]models.Conversation{}}
+	service := NewConversationService(repo, time.Hour)
+	ctx := context.Background()
+
+	conversation, _, err := service.StartConversation(ctx, "42", "vacancy")
+	require.NoError(t, err)
+	require.NoError(t, service.AnswerStep(ctx, conversation, "title", "Go developer"))
+
+	stored := repo.conversations["42"]
+	stored.ExpiresAt = time.Now().Add(-time.Minute)
+	repo.conversations["42"] = stored
+
+	_, err = service.GetConversation(ctx, "42")
+	assert.ErrorIs(t, err, ErrConversationExpired)
+
+	conversation, err = service.ResumeConversation(ctx, "42")
+	require.NoError(t, err)
+	assert.Equal(t, 1, conversation.Step)
+	assert.Equal(t, "Go developer", conversation.Draft["title"])
+
+	conversation, err = service.GetConversation(ctx, "42")
+	require.NoError(t, err)
+	assert.False(t, conversation.Expired(time.Now()))
+}
@@ -0,0 +1,48 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	"jobot/internal/repository"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+type FeedService struct {
+	feedRepository     repository.FeedRepository
+	employeeRepository repository.EmployeeRepository
+	vacancyRepository  repository.VacancyRepository
+}
+
+func NewFeedService(feedRepository repository.FeedRepository, employeeRepository repository.EmployeeRepository, vacancyRepository repository.VacancyRepository) *FeedService {
+	return &FeedService{feedRepository: feedRepository, employeeRepository: employeeRepository, vacancyRepository: vacancyRepository}
+}
+
+func (s *FeedService) GetEmployeeFeed(ctx context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.VacancyFeed, error) {
+	// Пустая лента и несуществующий сотрудник должны различаться для клиента
+	if _, err := s.employeeRepository.GetEmployee(ctx, employeeID); err != nil {
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+
+	feed, err := s.feedRepository.GetEmployeeVacancyFeed(ctx, employeeID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+func (s *FeedService) GetVacancyCandidates(ctx context.Context, vacancyID uuid.UUID, pagination models.Pagination) (*models.CandidateList, error) {
+	if _, err := s.vacancyRepository.GetVacancy(ctx, vacancyID); err != nil {
+		return nil, fmt.Errorf("failed to get vacancy: %w", err)
+	}
+
+	candidates, err := s.feedRepository.GetVacancyCandidates(ctx, vacancyID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vacancy candidates: %w", err)
+	}
+
+	return candidates, nil
+}
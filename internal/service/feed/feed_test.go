@@ -0,0 +1,136 @@
+package feed_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	employeeRepo "jobot/internal/repository/employee"
+	vacancyRepo "jobot/internal/repository/vacancy"
+	. "jobot/internal/service/feed"
+	"jobot/internal/service/models"
+)
+
+type feedRepositoryStub struct {
+	repository.FeedRepository
+	pages []models.Pagination
+}
+
+func (r *feedRepositoryStub) GetEmployeeVacancyFeed(_ context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.VacancyFeed, error) {
+	r.pages = append(r.pages, pagination)
+
+	return &models.VacancyFeed{EmployeeID: employeeID, Pagination: pagination}, nil
+}
+
+func (r *feedRepositoryStub) GetVacancyCandidates(_ context.Context, vacancyID uuid.UUID, pagination models.Pagination) (*models.CandidateList, error) {
+	r.pages = append(r.pages, pagination)
+
+	return &models.CandidateList{VacancyID: vacancyID, Pagination: pagination}, nil
+}
+
+type employeeRepositoryStub struct {
+	repository.EmployeeRepository
+	employees map[uuid.UUID]*models.Employee
+}
+
+func (r *employeeRepositoryStub) GetEmployee(_ context.Context, id uuid.UUID) (*models.Employee, error) {
+	employee, ok := r.employees[id]
+	if !ok {
+		return nil, employeeRepo.ErrEmployeeNotFound
+	}
+
+	return employee, nil
+}
+
+type vacancyRepositoryStub struct {
+	repository.VacancyRepository
+	vacancies map[uuid.UUID]*models.Vacancy
+}
+
+func (r *vacancyRepositoryStub) GetVacancy(_ context.Context, id uuid.UUID) (*models.Vacancy, error) {
+	vacancy, ok := r.vacancies[id]
+	if !ok {
+		return nil, vacancyRepo.ErrVacancyNotFound
+	}
+
+	return vacancy, nil
+}
+
+func TestFeedService(t *testing.T) {
+	t.Parallel()
+
+	employeeID, vacancyID := uuid.New(), uuid.New()
+	page := models.Pagination{Page: 2, PerPage: 20}
+
+	tests := []struct {
+		name  string
+		get   func(ctx context.Context, service *FeedService, id uuid.UUID) error
+		id    uuid.UUID
+		err   error
+		pages []models.Pagination
+	}{
+		{
+			name: "employee feed",
+			get: func(ctx context.Context, service *FeedService, id uuid.UUID) error {
+				feed, err := service.GetEmployeeFeed(ctx, id, page)
+				if err == nil {
+					assert.Equal(t, id, feed.EmployeeID)
+				}
+
+				return err
+			},
+			id:    employeeID,
+			pages: []models.Pagination{page},
+		},
+		{
+			name: "feed of unknown employee",
+			get: func(ctx context.Context, service *FeedService, id uuid.UUID) error {
+				_, err := service.GetEmployeeFeed(ctx, id, page)
+				return err
+			},
+			id:  uuid.New(),
+			err: employeeRepo.ErrEmployeeNotFound,
+		},
+		{
+			name: "vacancy candidates",
+			get: func(ctx context.Context, service *FeedService, id uuid.UUID) error {
+				list, err := service.GetVacancyCandidates(ctx, id, page)
+				if err == nil {
+					assert.Equal(t, id, list.VacancyID)
+				}
+
+				return err
+			},
+			id:    vacancyID,
+			pages: []models.Pagination{page},
+		},
+		{
+			name: "candidates of unknown vacancy",
+			get: func(ctx context.Context, service *FeedService, id uuid.UUID) error {
+				_, err := service.GetVacancyCandidates(ctx, id, page)
+				return err
+			},
+			id:  uuid.New(),
+			err: vacancyRepo.ErrVacancyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			feeds := &feedRepositoryStub{}
+			employees := &employeeRepositoryStub{employees: map[uuid.UUID]*models.Employee{employeeID: {EmployeeID: employeeID}}}
+			vacancies := &vacancyRepositoryStub{vacancies: map[uuid.UUID]*models.Vacancy{vacancyID: {VacansieID: vacancyID}}}
+			service := NewFeedService(feeds, employees, vacancies)
+
+			err := tt.get(context.Background(), service, tt.id)
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.pages, feeds.pages)
+		})
+	}
+}
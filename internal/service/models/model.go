@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,13 +28,23 @@ type UserUpdateRequest struct {
 	Role       *string `json:"role"`
 }
 
+// RoleChangeRequest - смена роли пользователя
+// Employee или Employer нужны, только если у пользователя еще не было профиля новой роли.
+type RoleChangeRequest struct {
+	Role     string
+	Employee *Employee
+	Employer *Employer
+}
+
 // Employee - модель сотрудника
+// ArchivedAt задан, если пользователь сменил роль и профиль сотрудника архивирован
 type Employee struct {
-	EmployeeID uuid.UUID `json:"employee_id"`
-	UserID     uuid.UUID `json:"user_id"`
-	Tags       []string  `json:"tags"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	EmployeeID uuid.UUID  `json:"employee_id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Tags       []string   `json:"tags"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ArchivedAt *time.Time `json:"archived_at"`
 }
 
 // EmployeeUpdateRequest - модель для обновления сотрудника
@@ -42,16 +53,18 @@ type EmployeeUpdateRequest struct {
 }
 
 // Employer - модель работодателя
+// ArchivedAt задан, если пользователь сменил роль и профиль работодателя архивирован
 type Employer struct {
-	EmployerID         uuid.UUID `json:"employer_id"`
-	UserID             uuid.UUID `json:"user_id"`
-	CompanyName        string    `json:"company_name"`
-	CompanyDescription string    `json:"company_description"`
-	CompanyWebsite     string    `json:"company_website"`
-	CompanyLocation    string    `json:"company_location"`
-	CompanySize        string    `json:"company_size"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	EmployerID         uuid.UUID  `json:"employer_id"`
+	UserID             uuid.UUID  `json:"user_id"`
+	CompanyName        string     `json:"company_name"`
+	CompanyDescription string     `json:"company_description"`
+	CompanyWebsite     string     `json:"company_website"`
+	CompanyLocation    string     `json:"company_location"`
+	CompanySize        string     `json:"company_size"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	ArchivedAt         *time.Time `json:"archived_at"`
 }
 
 // EmployerUpdateRequest - модель для обновления работодателя
@@ -105,16 +118,138 @@ type EmployerVacancyList struct {
 	EmployerID uuid.UUID `json:"employer_id"`
 }
 
+// VacancyList - модель страницы списка вакансий
 type VacancyList struct {
-	Vacansies []Vacancy `json:"vacansies"`
+	Vacansies  []Vacancy  `json:"vacansies"`
+	Pagination Pagination `json:"pagination"`
+	Total      int        `json:"total"`
+}
+
+// VacancySearchHit - вакансия, найденная полнотекстовым поиском
+type VacancySearchHit struct {
+	Vacancy
+	Rank           float32 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+// VacancySearchResult - модель страницы результатов поиска вакансий
+type VacancySearchResult struct {
+	Hits       []VacancySearchHit `json:"hits"`
+	Query      string             `json:"query"`
+	Pagination Pagination         `json:"pagination"`
+	Total      int                `json:"total"`
+}
+
+// VacancyFeedItem - вакансия в ленте рекомендаций сотрудника
+type VacancyFeedItem struct {
+	Vacancy
+	MatchedTags []string `json:"matched_tags"`
+	Score       float64  `json:"score"`
+}
+
+// VacancyFeed - модель страницы ленты рекомендаций сотрудника
+type VacancyFeed struct {
+	Items      []VacancyFeedItem `json:"items"`
+	EmployeeID uuid.UUID         `json:"employee_id"`
+	Pagination Pagination        `json:"pagination"`
+	Total      int               `json:"total"`
+}
+
+// Candidate - сотрудник, подходящий под вакансию
+type Candidate struct {
+	Employee
+	MatchedTags []string   `json:"matched_tags"`
+	HasResume   bool       `json:"has_resume"`
+	ResumeID    *uuid.UUID `json:"resume_id,omitempty"`
+}
+
+// CandidateList - модель страницы кандидатов на вакансию
+type CandidateList struct {
+	Candidates []Candidate `json:"candidates"`
+	VacancyID  uuid.UUID   `json:"vacancy_id"`
+	Pagination Pagination  `json:"pagination"`
+	Total      int         `json:"total"`
+}
+
+// Поля сортировки списка вакансий
+const (
+	VacancySortByCreatedAt = "created_at"
+	VacancySortByUpdatedAt = "updated_at"
+	VacancySortByTitle     = "title"
+)
+
+// Направления сортировки
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// Режимы сопоставления тегов
+const (
+	TagsMatchAny = "any"
+	TagsMatchAll = "all"
+)
+
+// VacancyFilter - фильтры и сортировка списка вакансий
+type VacancyFilter struct {
+	Tags        []string   `json:"tags"`
+	TagsMatch   string     `json:"tags_match"`
+	Location    *string    `json:"location"`
+	EmployerID  *uuid.UUID `json:"employer_id"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+	SortBy      string     `json:"sort_by"`
+	SortOrder   string     `json:"sort_order"`
+}
+
+// Значения пагинации по умолчанию
+const (
+	DefaultPage    = 1
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+// Pagination - параметры постраничной выборки
+type Pagination struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+// Limit возвращает размер страницы для SQL запроса
+func (p Pagination) Limit() int {
+	return p.PerPage
 }
 
+// Offset возвращает смещение для SQL запроса
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// Типы реакций на вакансию
+const (
+	ReactionTypeLike    = "like"
+	ReactionTypeDislike = "dislike"
+)
+
 // Reaction - модель реакции
 type Reaction struct {
 	ID         uuid.UUID `json:"id"`
 	EmployeeID uuid.UUID `json:"employee_id"`
 	VacancyID  uuid.UUID `json:"vacancy_id"`
+	Type       string    `json:"type"`
 	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ReactionUpdateRequest - модель для обновления реакции
+type ReactionUpdateRequest struct {
+	Type *string `json:"type"`
+}
+
+// ReactionFilter - фильтр списка реакций сотрудника
+type ReactionFilter struct {
+	Type *string `json:"type"`
 }
 
 // EmployeeReactionList - модель списка реакций сотрудника
@@ -123,9 +258,331 @@ type EmployeeReactionList struct {
 	EmployeeID uuid.UUID  `json:"employee_id"`
 }
 
+// Решения работодателя по кандидату
+const (
+	EmployerDecisionAccept    = "accept"
+	EmployerDecisionReject    = "reject"
+	EmployerDecisionShortlist = "shortlist"
+)
+
+// EmployerReaction - модель решения работодателя по кандидату, лайкнувшему вакансию
+type EmployerReaction struct {
+	ID         uuid.UUID `json:"id"`
+	EmployerID uuid.UUID `json:"employer_id"`
+	EmployeeID uuid.UUID `json:"employee_id"`
+	VacancyID  uuid.UUID `json:"vacancy_id"`
+	Decision   string    `json:"decision"`
+	Matched    bool      `json:"matched"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// EmployerReactionUpdateRequest - модель для обновления решения работодателя
+type EmployerReactionUpdateRequest struct {
+	Decision *string `json:"decision"`
+}
+
+// EmployerReactionFilter - фильтр списка решений работодателя
+type EmployerReactionFilter struct {
+	Decision  *string    `json:"decision"`
+	VacancyID *uuid.UUID `json:"vacancy_id"`
+}
+
+// EmployerReactionList - модель списка решений работодателя
+type EmployerReactionList struct {
+	Reactions  []EmployerReaction `json:"reactions"`
+	EmployerID uuid.UUID          `json:"employer_id"`
+}
+
+// VacancyReactionList - модель списка реакций сотрудников на вакансию
+type VacancyReactionList struct {
+	Reactions []Reaction `json:"reactions"`
+	VacancyID uuid.UUID  `json:"vacancy_id"`
+}
+
+// Match - модель взаимного интереса сотрудника и работодателя
+type Match struct {
+	ID         uuid.UUID `json:"id"`
+	EmployeeID uuid.UUID `json:"employee_id"`
+	VacancyID  uuid.UUID `json:"vacancy_id"`
+	EmployerID uuid.UUID `json:"employer_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// MatchList - модель страницы списка матчей
+type MatchList struct {
+	Matches    []Match    `json:"matches"`
+	Pagination Pagination `json:"pagination"`
+	Total      int        `json:"total"`
+}
+
+// Статусы заявки на вакансию
+const (
+	ApplicationStatusApplied   = "applied"
+	ApplicationStatusViewed    = "viewed"
+	ApplicationStatusInterview = "interview"
+	ApplicationStatusOffer     = "offer"
+	ApplicationStatusHired     = "hired"
+	ApplicationStatusRejected  = "rejected"
+)
+
+// Стороны, которые могут менять статус заявки
+const (
+	ApplicationActorEmployee = "employee"
+	ApplicationActorEmployer = "employer"
+)
+
+// Application - модель заявки сотрудника на вакансию
+type Application struct {
+	ID         uuid.UUID               `json:"id"`
+	EmployeeID uuid.UUID               `json:"employee_id"`
+	VacancyID  uuid.UUID               `json:"vacancy_id"`
+	ResumeID   *uuid.UUID              `json:"resume_id"`
+	Status     string                  `json:"status"`
+	CreatedAt  time.Time               `json:"created_at"`
+	UpdatedAt  time.Time               `json:"updated_at"`
+	History    []ApplicationTransition `json:"history,omitempty"`
+}
+
+// ApplicationTransition - модель записи истории смены статуса заявки
+type ApplicationTransition struct {
+	ID            uuid.UUID `json:"id"`
+	ApplicationID uuid.UUID `json:"application_id"`
+	FromStatus    *string   `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	Actor         string    `json:"actor"`
+	Comment       *string   `json:"comment"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ApplicationTransitionRequest - модель запроса смены статуса заявки
+// ActorID - employee_id или employer_id в зависимости от Actor
+type ApplicationTransitionRequest struct {
+	Status  string    `json:"status"`
+	Actor   string    `json:"actor"`
+	ActorID uuid.UUID `json:"actor_id"`
+	Comment *string   `json:"comment"`
+}
+
+// ApplicationFilter - фильтр списка заявок
+type ApplicationFilter struct {
+	Status    *string    `json:"status"`
+	VacancyID *uuid.UUID `json:"vacancy_id"`
+}
+
+// ApplicationList - модель страницы списка заявок
+type ApplicationList struct {
+	Applications []Application `json:"applications"`
+	Pagination   Pagination    `json:"pagination"`
+	Total        int           `json:"total"`
+}
+
 // UserProfile - модель профиля пользователя
 type UserProfile struct {
 	User     *User     `json:"user"`
 	Employee *Employee `json:"employee,omitempty"`
 	Employer *Employer `json:"employer,omitempty"`
 }
+
+// Роли пользователя
+const (
+	UserRoleEmployee = "employee"
+	UserRoleEmployer = "employer"
+	UserRoleAdmin    = "admin"
+)
+
+// TelegramIdentity - данные пользователя Telegram, подпись которых проверена
+// ID - Telegram user ID, для личного чата с ботом совпадает с tg_chat_id
+type TelegramIdentity struct {
+	ID        int64     `json:"id"`
+	UserName  string    `json:"username"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	AuthDate  time.Time `json:"auth_date"`
+}
+
+// AuthTokens - пара JWT токенов, выданная пользователю
+type AuthTokens struct {
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// AuthResult - результат входа: пользователь и выданные ему токены
+// Created - пользователь был создан при этом входе
+type AuthResult struct {
+	User    *User       `json:"user"`
+	Tokens  *AuthTokens `json:"tokens"`
+	Created bool        `json:"created"`
+}
+
+// Права API ключей
+const (
+	APIKeyScopeAdmin    = "admin"             // запросы без X-On-Behalf-Of-User выполняются с правами admin
+	APIKeyScopeOnBehalf = "on_behalf_of_user" // разрешает заголовок X-On-Behalf-Of-User
+)
+
+// APIKey - модель API ключа сервиса, секретная часть ключа не хранится
+type APIKey struct {
+	ID         uuid.UUID  `json:"api_key_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"key_prefix"`
+	Hash       string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  *uuid.UUID `json:"created_by"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// HasScope проверяет, выдано ли ключу право scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IssuedAPIKey - созданный или перевыпущенный ключ вместе с его значением,
+// значение Key возвращается клиенту один раз
+type IssuedAPIKey struct {
+	APIKey *APIKey `json:"api_key"`
+	Key    string  `json:"key"`
+}
+
+// Conversation - состояние многошагового диалога бота с чатом.
+// Step - номер текущего шага сценария Flow, Draft - введенные значения по полям шагов.
+// После ExpiresAt диалог считается прерванным, но черновик сохраняется и его можно продолжить.
+type Conversation struct {
+	TgChatID  string            `json:"tg_chat_id"`
+	Flow      string            `json:"flow"`
+	Step      int               `json:"step"`
+	Draft     map[string]string `json:"draft"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// Expired проверяет, истек ли таймаут диалога к моменту now
+func (c *Conversation) Expired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// Типы уведомлений
+const (
+	NotificationTypeNewVacancy     = "new_vacancy"     // новая вакансия по навыкам сотрудника
+	NotificationTypeCandidateLiked = "candidate_liked" // кандидат лайкнул вакансию работодателя
+)
+
+// Статусы доставки уведомлений
+const (
+	NotificationStatusPending  = "pending"
+	NotificationStatusSent     = "sent"
+	NotificationStatusFailed   = "failed"
+	NotificationStatusCanceled = "canceled"
+)
+
+// NotificationPreferences - настройки уведомлений пользователя.
+// Тихие часы QuietHoursStart-QuietHoursEnd задаются часами в часовом поясе Timezone и могут переходить через полночь.
+type NotificationPreferences struct {
+	UserID          uuid.UUID `json:"user_id"`
+	NewVacancies    bool      `json:"new_vacancies"`
+	CandidateLikes  bool      `json:"candidate_likes"`
+	QuietHoursStart *int      `json:"quiet_hours_start"`
+	QuietHoursEnd   *int      `json:"quiet_hours_end"`
+	Timezone        string    `json:"timezone"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Notification - уведомление пользователю в Telegram и состояние его доставки.
+// Preferences, Vacancy, Candidate и LastSentAt загружаются вместе с уведомлением для доставки:
+// Vacancy = nil, если вакансия удалена или архивирована, Candidate = nil, если кандидат убрал лайк
+// или его профиль архивирован, LastSentAt - последнее отправленное получателю уведомление того же типа.
+type Notification struct {
+	ID            uuid.UUID  `json:"notification_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	TgChatID      string     `json:"tg_chat_id"`
+	Type          string     `json:"type"`
+	VacancyID     *uuid.UUID `json:"vacancy_id"`
+	EmployeeID    *uuid.UUID `json:"employee_id"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"last_error"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	Preferences NotificationPreferences `json:"-"`
+	Vacancy     *Vacancy                `json:"-"`
+	Candidate   *NotificationCandidate  `json:"-"`
+	LastSentAt  *time.Time              `json:"-"`
+}
+
+// NotificationCandidate - кандидат, лайкнувший вакансию, в уведомлении работодателю
+type NotificationCandidate struct {
+	EmployeeID uuid.UUID `json:"employee_id"`
+	TgUserName string    `json:"tg_user_name"`
+	Tags       []string  `json:"tags"`
+	HasResume  bool      `json:"has_resume"`
+}
+
+// NotificationDeliveryStats - итог одного прохода доставки уведомлений
+type NotificationDeliveryStats struct {
+	Sent      int `json:"sent"`
+	Retried   int `json:"retried"`
+	Failed    int `json:"failed"`
+	Postponed int `json:"postponed"`
+	Canceled  int `json:"canceled"`
+	Digests   int `json:"digests"`
+}
+
+// Типы событий outbox: "<агрегат>.<действие>"
+const (
+	OutboxEventUserCreated = "user.created"
+	OutboxEventUserUpdated = "user.updated"
+	OutboxEventUserDeleted = "user.deleted"
+
+	OutboxEventVacancyCreated  = "vacancy.created"
+	OutboxEventVacancyUpdated  = "vacancy.updated"
+	OutboxEventVacancyArchived = "vacancy.archived"
+	OutboxEventVacancyDeleted  = "vacancy.deleted"
+
+	OutboxEventReactionCreated = "reaction.created"
+	OutboxEventReactionUpdated = "reaction.updated"
+	OutboxEventReactionDeleted = "reaction.deleted"
+
+	OutboxEventEmployerReactionCreated = "employer_reaction.created"
+	OutboxEventEmployerReactionUpdated = "employer_reaction.updated"
+	OutboxEventEmployerReactionDeleted = "employer_reaction.deleted"
+)
+
+// OutboxEvent - доменное событие из outbox. JSON поля событий - формат, в котором его получают приемники,
+// остальные поля - состояние отправки: DeliveredSinks - приемники, которые уже приняли событие.
+type OutboxEvent struct {
+	ID            uuid.UUID       `json:"event_id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+
+	DeliveredSinks []string   `json:"-"`
+	Attempts       int        `json:"-"`
+	LastError      *string    `json:"-"`
+	NextAttemptAt  time.Time  `json:"-"`
+	DispatchedAt   *time.Time `json:"-"`
+}
+
+// OutboxDispatchStats - итог одного прохода отправки событий outbox
+type OutboxDispatchStats struct {
+	Dispatched int   `json:"dispatched"`
+	Retried    int   `json:"retried"`
+	Deleted    int64 `json:"deleted"`
+}
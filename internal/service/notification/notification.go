@@ -0,0 +1,429 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	// Часовые пояса тихих часов не должны зависеть от tzdata в образе
+	_ "time/tzdata"
+
+	"jobot/internal/repository"
+	notificationRepo "jobot/internal/repository/notification"
+	"jobot/internal/service/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUndeliverable     = errors.New("notification can not be delivered to the chat")
+	ErrInvalidQuietHours = errors.New("quiet hours must be both set or both empty, with different hours from 0 to 23")
+	ErrInvalidTimezone   = errors.New("unknown timezone")
+)
+
+// DefaultTimezone - часовой пояс тихих часов, если пользователь его не указал
+const DefaultTimezone = "UTC"
+
+// ResumeCommandPrefix - начало команды бота с резюме кандидата, за ним следует employee_id без дефисов
+const ResumeCommandPrefix = "/cv_"
+
+// maxRetryDelay ограничивает экспоненциальную паузу между попытками доставки
+const maxRetryDelay = time.Hour
+
+// maxDigestLines - сколько лайков перечисляется в дайджесте, остальные только считаются
+const maxDigestLines = 30
+
+// Sender доставляет текст уведомления в чат Telegram.
+// Ошибка с ErrUndeliverable означает, что повторять доставку бессмысленно (бот заблокирован, чат удален).
+type Sender interface {
+	Send(ctx context.Context, tgChatID string, text string) error
+}
+
+// Config - настройки доставки
+// BatchSize - сколько уведомлений забирается за один проход, MaxAttempts - попыток до статуса failed,
+// RetryDelay - пауза после первой неудачи (дальше удваивается), Lease - на сколько уведомление
+// закрепляется за проходом доставки, DigestInterval - как часто работодатель получает уведомления о лайках.
+type Config struct {
+	BatchSize      int
+	MaxAttempts    int
+	RetryDelay     time.Duration
+	Lease          time.Duration
+	DigestInterval time.Duration
+}
+
+type NotificationService struct {
+	notificationRepository repository.NotificationRepository
+	userRepository         repository.UserRepository
+	sender                 Sender
+	config                 Config
+	now                    func() time.Time
+}
+
+func NewNotificationService(
+	notificationRepository repository.NotificationRepository,
+	userRepository repository.UserRepository,
+	sender Sender,
+	config Config,
+) *NotificationService {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = time.Minute
+	}
+	if config.Lease <= 0 {
+		config.Lease = 5 * time.Minute
+	}
+	if config.DigestInterval <= 0 {
+		config.DigestInterval = 10 * time.Minute
+	}
+
+	return &NotificationService{
+		notificationRepository: notificationRepository,
+		userRepository:         userRepository,
+		sender:                 sender,
+		config:                 config,
+		now:                    time.Now,
+	}
+}
+
+// DeliverDue выполняет один проход доставки: забирает уведомления, срок которых наступил, и отправляет их.
+// Уведомления в тихие часы получателя откладываются до их окончания, неактуальные отменяются,
+// неудачные повторяются с растущей паузой до MaxAttempts. Уведомления о лайках кандидатов приходят
+// не чаще раза в DigestInterval, накопившиеся за это время отправляются одним сообщением-дайджестом.
+// Ошибки сохранения не прерывают проход.
+func (s *NotificationService) DeliverDue(ctx context.Context) (*models.NotificationDeliveryStats, error) {
+	now := s.now()
+
+	notifications, err := s.notificationRepository.ClaimDueNotifications(ctx, now, now.Add(s.config.Lease), s.config.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due notifications: %w", err)
+	}
+
+	stats := &models.NotificationDeliveryStats{}
+
+	// Сообщения к отправке: лайки кандидатов собираются в одно сообщение на получателя
+	var (
+		messages [][]*models.Notification
+		digests  = map[uuid.UUID]int{}
+	)
+	for i := range notifications {
+		notification := &notifications[i]
+
+		if !s.prepare(notification, now, stats) {
+			continue
+		}
+
+		if notification.Type != models.NotificationTypeCandidateLiked {
+			messages = append(messages, []*models.Notification{notification})
+			continue
+		}
+
+		if index, ok := digests[notification.UserID]; ok {
+			messages[index] = append(messages[index], notification)
+			continue
+		}
+
+		digests[notification.UserID] = len(messages)
+		messages = append(messages, []*models.Notification{notification})
+	}
+
+	for _, message := range messages {
+		s.send(ctx, message, now, stats)
+	}
+
+	var errs []error
+	for i := range notifications {
+		notification := &notifications[i]
+
+		notification.UpdatedAt = now
+		if err := s.notificationRepository.UpdateNotificationDelivery(ctx, notification); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update notification %s: %w", notification.ID, err))
+		}
+	}
+
+	return stats, errors.Join(errs...)
+}
+
+// prepare отменяет неактуальное уведомление или откладывает его и возвращает true, если уведомление пора отправить
+func (s *NotificationService) prepare(notification *models.Notification, now time.Time, stats *models.NotificationDeliveryStats) bool {
+	if !relevant(notification) {
+		notification.Status = models.NotificationStatusCanceled
+		stats.Canceled++
+
+		return false
+	}
+
+	if until, quiet := QuietUntil(&notification.Preferences, now); quiet {
+		notification.NextAttemptAt = until
+		stats.Postponed++
+
+		return false
+	}
+
+	// Лайки после недавнего уведомления ждут конца окна и уходят вместе
+	if notification.Type == models.NotificationTypeCandidateLiked && notification.LastSentAt != nil {
+		if next := notification.LastSentAt.Add(s.config.DigestInterval); now.Before(next) {
+			notification.NextAttemptAt = next
+			stats.Postponed++
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// send отправляет одно сообщение с уведомлениями message (все одному получателю) и записывает итог в каждое из них
+func (s *NotificationService) send(ctx context.Context, message []*models.Notification, now time.Time, stats *models.NotificationDeliveryStats) {
+	text := notificationText(message[0])
+	if len(message) > 1 {
+		text = digestText(message)
+		stats.Digests++
+	}
+
+	err := s.sender.Send(ctx, message[0].TgChatID, text)
+
+	for _, notification := range message {
+		notification.Attempts++
+		s.record(notification, err, now, stats)
+	}
+}
+
+// record записывает в notification и stats результат попытки доставки с ошибкой err
+func (s *NotificationService) record(notification *models.Notification, err error, now time.Time, stats *models.NotificationDeliveryStats) {
+	if err == nil {
+		notification.Status = models.NotificationStatusSent
+		notification.SentAt = &now
+		notification.LastError = nil
+		stats.Sent++
+
+		return
+	}
+
+	lastError := err.Error()
+	notification.LastError = &lastError
+
+	if errors.Is(err, ErrUndeliverable) || notification.Attempts >= s.config.MaxAttempts {
+		notification.Status = models.NotificationStatusFailed
+		stats.Failed++
+
+		return
+	}
+
+	notification.NextAttemptAt = now.Add(s.retryDelay(notification.Attempts))
+	stats.Retried++
+}
+
+// retryDelay - пауза после attempts неудачных попыток
+func (s *NotificationService) retryDelay(attempts int) time.Duration {
+	delay := s.config.RetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+// GetNotificationPreferences возвращает настройки уведомлений пользователя или настройки по умолчанию
+func (s *NotificationService) GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	if _, err := s.userRepository.GetUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	preferences, err := s.notificationRepository.GetNotificationPreferences(ctx, userID)
+	if errors.Is(err, notificationRepo.ErrNotificationPreferencesNotFound) {
+		return DefaultPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// UpdateNotificationPreferences заменяет настройки уведомлений пользователя
+func (s *NotificationService) UpdateNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	if preferences.Timezone == "" {
+		preferences.Timezone = DefaultTimezone
+	}
+
+	if err := validatePreferences(preferences); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepository.GetUser(ctx, preferences.UserID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := s.now()
+	preferences.CreatedAt = now
+	preferences.UpdatedAt = now
+
+	if err := s.notificationRepository.SaveNotificationPreferences(ctx, preferences); err != nil {
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// DefaultPreferences - настройки пользователя, который их не менял: все уведомления, без тихих часов
+func DefaultPreferences(userID uuid.UUID) *models.NotificationPreferences {
+	return &models.NotificationPreferences{
+		UserID:         userID,
+		NewVacancies:   true,
+		CandidateLikes: true,
+		Timezone:       DefaultTimezone,
+	}
+}
+
+// QuietUntil проверяет, попадает ли now в тихие часы, и возвращает момент их окончания
+func QuietUntil(preferences *models.NotificationPreferences, now time.Time) (time.Time, bool) {
+	if preferences.QuietHoursStart == nil || preferences.QuietHoursEnd == nil {
+		return time.Time{}, false
+	}
+
+	start, end := *preferences.QuietHoursStart, *preferences.QuietHoursEnd
+
+	location, err := time.LoadLocation(preferences.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	local := now.In(location)
+	hour := local.Hour()
+
+	quiet := hour >= start && hour < end
+	if start > end {
+		// Тихие часы через полночь, например 22-8
+		quiet = hour >= start || hour < end
+	}
+
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end, 0, 0, 0, location)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+
+	return until, true
+}
+
+func validatePreferences(preferences *models.NotificationPreferences) error {
+	start, end := preferences.QuietHoursStart, preferences.QuietHoursEnd
+
+	switch {
+	case start == nil && end == nil:
+	case start == nil || end == nil,
+		*start < 0 || *start > 23 || *end < 0 || *end > 23,
+		*start == *end:
+		return ErrInvalidQuietHours
+	}
+
+	if _, err := time.LoadLocation(preferences.Timezone); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTimezone, preferences.Timezone)
+	}
+
+	return nil
+}
+
+// relevant проверяет, нужно ли еще уведомление: предмет уведомления существует и получатель их не выключил
+func relevant(notification *models.Notification) bool {
+	switch notification.Type {
+	case models.NotificationTypeNewVacancy:
+		return notification.Vacancy != nil && notification.Preferences.NewVacancies
+	case models.NotificationTypeCandidateLiked:
+		return notification.Vacancy != nil && notification.Candidate != nil && notification.Preferences.CandidateLikes
+	default:
+		return false
+	}
+}
+
+func notificationText(notification *models.Notification) string {
+	if notification.Type == models.NotificationTypeCandidateLiked {
+		return candidateLikedText(notification.Candidate, notification.Vacancy)
+	}
+
+	return newVacancyText(notification.Vacancy)
+}
+
+func newVacancyText(vacancy *models.Vacancy) string {
+	text := fmt.Sprintf(textNewVacancy, vacancy.Title, vacancy.Location, vacancy.Salary)
+	if len(vacancy.Tags) > 0 {
+		text += fmt.Sprintf(textNewVacancyTags, strings.Join(vacancy.Tags, ", "))
+	}
+
+	return text + textNewVacancyFooter
+}
+
+func candidateLikedText(candidate *models.NotificationCandidate, vacancy *models.Vacancy) string {
+	text := fmt.Sprintf(textCandidateLiked, candidateName(candidate), vacancy.Title)
+	if len(candidate.Tags) > 0 {
+		text += fmt.Sprintf(textNewVacancyTags, strings.Join(candidate.Tags, ", "))
+	}
+
+	if candidate.HasResume {
+		text += fmt.Sprintf(textCandidateResume, ResumeCommand(candidate.EmployeeID))
+	} else {
+		text += textCandidateNoResume
+	}
+
+	return text + textCandidateLikedFooter
+}
+
+// digestText - одно сообщение о нескольких лайках кандидатов, не длиннее maxDigestLines строк
+func digestText(notifications []*models.Notification) string {
+	text := fmt.Sprintf(textCandidatesDigest, len(notifications))
+
+	for i, notification := range notifications {
+		if i == maxDigestLines {
+			text += fmt.Sprintf(textCandidatesDigestMore, len(notifications)-i)
+			break
+		}
+
+		text += fmt.Sprintf(textCandidatesDigestLine, candidateName(notification.Candidate), notification.Vacancy.Title)
+		if notification.Candidate.HasResume {
+			text += fmt.Sprintf(textCandidatesDigestResume, ResumeCommand(notification.Candidate.EmployeeID))
+		}
+	}
+
+	return text + textCandidateLikedFooter
+}
+
+func candidateName(candidate *models.NotificationCandidate) string {
+	if candidate.TgUserName == "" {
+		return textCandidateNoUserName
+	}
+
+	return "@" + candidate.TgUserName
+}
+
+// ResumeCommand - команда бота, которая присылает резюме кандидата. UUID записывается без дефисов,
+// чтобы Telegram показал команду ссылкой.
+func ResumeCommand(employeeID uuid.UUID) string {
+	return ResumeCommandPrefix + strings.ReplaceAll(employeeID.String(), "-", "")
+}
+
+// Тексты уведомлений
+const (
+	textNewVacancy       = "Новая вакансия по вашим навыкам:\n\n%s\n📍 %s\n💰 %s"
+	textNewVacancyTags   = "\n🏷 %s"
+	textNewVacancyFooter = "\n\nОткройте /vacancies, чтобы откликнуться. Настроить уведомления: /notifications"
+
+	textCandidateLiked       = "👍 Кандидат %s лайкнул вакансию «%s»"
+	textCandidateResume      = "\n📄 Резюме: %s"
+	textCandidateNoResume    = "\n📄 Резюме не загружено"
+	textCandidateNoUserName  = "без имени пользователя"
+	textCandidateLikedFooter = "\n\nНастроить уведомления: /notifications"
+
+	textCandidatesDigest       = "👍 Новые лайки ваших вакансий: %d\n"
+	textCandidatesDigestLine   = "\n• %s — «%s»"
+	textCandidatesDigestResume = ", резюме: %s"
+	textCandidatesDigestMore   = "\n…и еще %d"
+)
@@ -0,0 +1,270 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	notificationRepo "jobot/internal/repository/notification"
+	userRepo "jobot/internal/repository/user"
+	"jobot/internal/service/models"
+	. "jobot/internal/service/notification"
+)
+
+type notificationRepositoryStub struct {
+	repository.NotificationRepository
+	due         []models.Notification
+	updated     map[uuid.UUID]models.Notification
+	preferences map[uuid.UUID]*models.NotificationPreferences
+}
+
+func (r *notificationRepositoryStub) ClaimDueNotifications(_ context.Context, _, _ time.Time, limit int) ([]models.Notification, error) {
+	claimed := r.due[:min(limit, len(r.due))]
+	r.due = r.due[len(claimed):]
+
+	return claimed, nil
+}
+
+func (r *notificationRepositoryStub) UpdateNotificationDelivery(_ context.Context, notification *models.Notification) error {
+	r.updated[notification.ID] = *notification
+
+	return nil
+}
+
+func (r *notificationRepositoryStub) GetNotificationPreferences(_ context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	preferences, ok := r.preferences[userID]
+	if !ok {
+		return nil, notificationRepo.ErrNotificationPreferencesNotFound
+	}
+
+	return preferences, nil
+}
+
+func (r *notificationRepositoryStub) SaveNotificationPreferences(_ context.Context, preferences *models.NotificationPreferences) error {
+	r.preferences[preferences.UserID] = preferences
+
+	return nil
+}
+
+type userRepositoryStub struct {
+	repository.UserRepository
+	users map[uuid.UUID]bool
+}
+
+func (r *userRepositoryStub) GetUser(_ context.Context, id uuid.UUID) (*models.User, error) {
+	if !r.users[id] {
+		return nil, userRepo.ErrUserNotFound
+	}
+
+	return &models.User{ID: id}, nil
+}
+
+// senderStub возвращает ошибку из errs по tg_chat_id и запоминает отправленные тексты
+type senderStub struct {
+	errs map[string]error
+	sent map[string]string
+}
+
+func (s *senderStub) Send(_ context.Context, tgChatID string, text string) error {
+	if err := s.errs[tgChatID]; err != nil {
+		return err
+	}
+
+	s.sent[tgChatID] = text
+
+	return nil
+}
+
+func newNotification(tgChatID string, vacancy *models.Vacancy) models.Notification {
+	return models.Notification{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		TgChatID:    tgChatID,
+		Type:        models.NotificationTypeNewVacancy,
+		Status:      models.NotificationStatusPending,
+		Preferences: models.NotificationPreferences{NewVacancies: true, Timezone: "UTC"},
+		Vacancy:     vacancy,
+	}
+}
+
+func newCandidateLiked(tgChatID string, userID uuid.UUID, vacancy *models.Vacancy, userName string) models.Notification {
+	notification := newNotification(tgChatID, vacancy)
+	notification.UserID = userID
+	notification.Type = models.NotificationTypeCandidateLiked
+	notification.Preferences.CandidateLikes = true
+	notification.Candidate = &models.NotificationCandidate{EmployeeID: uuid.New(), TgUserName: userName, HasResume: true}
+
+	return notification
+}
+
+func hour(h int) *int {
+	return &h
+}
+
+func TestDeliverDue(t *testing.T) {
+	vacancy := &models.Vacancy{VacansieID: uuid.New(), Title: "Go developer", Location: "Remote", Salary: "300k", Tags: []string{"go"}}
+
+	sent := newNotification("1", vacancy)
+	retried := newNotification("2", vacancy)
+	blocked := newNotification("3", vacancy)
+	exhausted := newNotification("4", vacancy)
+	exhausted.Attempts = 2
+	archived := newNotification("5", nil)
+	disabled := newNotification("6", vacancy)
+	disabled.Preferences.NewVacancies = false
+	quiet := newNotification("7", vacancy)
+	// Тихие часы вокруг текущего часа, чтобы тест не зависел от времени запуска
+	now := time.Now().UTC()
+	quiet.Preferences.QuietHoursStart = hour((now.Hour() + 23) % 24)
+	quiet.Preferences.QuietHoursEnd = hour((now.Hour() + 1) % 24)
+
+	repo := &notificationRepositoryStub{
+		due:     []models.Notification{sent, retried, blocked, exhausted, archived, disabled, quiet},
+		updated: map[uuid.UUID]models.Notification{},
+	}
+	sender := &senderStub{
+		errs: map[string]error{
+			"2": errors.New("timeout"),
+			"3": fmt.Errorf("%w: bot was blocked by the user", ErrUndeliverable),
+			"4": errors.New("timeout"),
+		},
+		sent: map[string]string{},
+	}
+	service := NewNotificationService(repo, &userRepositoryStub{}, sender, Config{MaxAttempts: 3, RetryDelay: time.Minute})
+
+	stats, err := service.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.NotificationDeliveryStats{Sent: 1, Retried: 1, Failed: 2, Postponed: 1, Canceled: 2}, *stats)
+
+	assert.Contains(t, sender.sent["1"], "Go developer")
+	assert.Len(t, sender.sent, 1)
+
+	assert.Equal(t, models.NotificationStatusSent, repo.updated[sent.ID].Status)
+	assert.NotNil(t, repo.updated[sent.ID].SentAt)
+	assert.Equal(t, 1, repo.updated[sent.ID].Attempts)
+
+	assert.Equal(t, models.NotificationStatusPending, repo.updated[retried.ID].Status)
+	assert.Equal(t, 1, repo.updated[retried.ID].Attempts)
+	assert.Equal(t, "timeout", *repo.updated[retried.ID].LastError)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), repo.updated[retried.ID].NextAttemptAt, 5*time.Second)
+
+	assert.Equal(t, models.NotificationStatusFailed, repo.updated[blocked.ID].Status)
+	assert.Equal(t, models.NotificationStatusFailed, repo.updated[exhausted.ID].Status)
+	assert.Equal(t, 3, repo.updated[exhausted.ID].Attempts)
+
+	assert.Equal(t, models.NotificationStatusCanceled, repo.updated[archived.ID].Status)
+	assert.Equal(t, models.NotificationStatusCanceled, repo.updated[disabled.ID].Status)
+
+	assert.Equal(t, models.NotificationStatusPending, repo.updated[quiet.ID].Status)
+	assert.Equal(t, 0, repo.updated[quiet.ID].Attempts)
+	assert.True(t, repo.updated[quiet.ID].NextAttemptAt.After(now))
+}
+
+func TestDeliverDueCandidateLikes(t *testing.T) {
+	vacancy := &models.Vacancy{VacansieID: uuid.New(), Title: "Go developer"}
+	employer, busyEmployer, throttledEmployer := uuid.New(), uuid.New(), uuid.New()
+
+	single := newCandidateLiked("1", employer, vacancy, "alice")
+	first := newCandidateLiked("2", busyEmployer, vacancy, "bob")
+	second := newCandidateLiked("2", busyEmployer, vacancy, "")
+	second.Candidate.HasResume = false
+	withdrawn := newCandidateLiked("2", busyEmployer, vacancy, "carol")
+	withdrawn.Candidate = nil
+	throttled := newCandidateLiked("3", throttledEmployer, vacancy, "dave")
+	lastSentAt := time.Now().Add(-time.Minute)
+	throttled.LastSentAt = &lastSentAt
+
+	repo := &notificationRepositoryStub{
+		due:     []models.Notification{single, first, withdrawn, second, throttled},
+		updated: map[uuid.UUID]models.Notification{},
+	}
+	sender := &senderStub{sent: map[string]string{}}
+	service := NewNotificationService(repo, &userRepositoryStub{}, sender, Config{DigestInterval: 10 * time.Minute})
+
+	stats, err := service.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.NotificationDeliveryStats{Sent: 3, Postponed: 1, Canceled: 1, Digests: 1}, *stats)
+
+	assert.Contains(t, sender.sent["1"], "@alice")
+	assert.Contains(t, sender.sent["1"], ResumeCommand(single.Candidate.EmployeeID))
+
+	// Два лайка одному работодателю - одно сообщение
+	assert.Contains(t, sender.sent["2"], "@bob")
+	assert.Contains(t, sender.sent["2"], ResumeCommand(first.Candidate.EmployeeID))
+	assert.NotContains(t, sender.sent["2"], ResumeCommand(second.Candidate.EmployeeID))
+	assert.Equal(t, models.NotificationStatusSent, repo.updated[second.ID].Status)
+	assert.Equal(t, models.NotificationStatusCanceled, repo.updated[withdrawn.ID].Status)
+
+	assert.NotContains(t, sender.sent, "3")
+	assert.Equal(t, models.NotificationStatusPending, repo.updated[throttled.ID].Status)
+	assert.Equal(t, lastSentAt.Add(10*time.Minute), repo.updated[throttled.ID].NextAttemptAt)
+}
+
+func TestQuietUntil(t *testing.T) {
+	preferences := &models.NotificationPreferences{QuietHoursStart: hour(22), QuietHoursEnd: hour(8), Timezone: "Europe/Moscow"}
+
+	// 20:30 UTC = 23:30 MSK
+	until, quiet := QuietUntil(preferences, time.Date(2026, 3, 1, 20, 30, 0, 0, time.UTC))
+	require.True(t, quiet)
+	assert.Equal(t, time.Date(2026, 3, 2, 5, 0, 0, 0, time.UTC), until.UTC())
+
+	// 03:00 UTC = 06:00 MSK
+	until, quiet = QuietUntil(preferences, time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC))
+	require.True(t, quiet)
+	assert.Equal(t, time.Date(2026, 3, 2, 5, 0, 0, 0, time.UTC), until.UTC())
+
+	// 12:00 UTC = 15:00 MSK
+	_, quiet = QuietUntil(preferences, time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC))
+	assert.False(t, quiet)
+
+	_, quiet = QuietUntil(&models.NotificationPreferences{Timezone: "UTC"}, time.Now())
+	assert.False(t, quiet)
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	userID := uuid.New()
+	repo := &notificationRepositoryStub{preferences: map[uuid.UUID]*models.NotificationPreferences{}}
+	users := &userRepositoryStub{users: map[uuid.UUID]bool{userID: true}}
+	service := NewNotificationService(repo, users, &senderStub{}, Config{})
+	ctx := context.Background()
+
+	preferences, err := service.GetNotificationPreferences(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPreferences(userID), preferences)
+
+	_, err = service.GetNotificationPreferences(ctx, uuid.New())
+	assert.ErrorIs(t, err, userRepo.ErrUserNotFound)
+
+	invalid := []*models.NotificationPreferences{
+		{UserID: userID, QuietHoursStart: hour(22)},
+		{UserID: userID, QuietHoursStart: hour(22), QuietHoursEnd: hour(24)},
+		{UserID: userID, QuietHoursStart: hour(8), QuietHoursEnd: hour(8)},
+	}
+	for _, preferences := range invalid {
+		_, err = service.UpdateNotificationPreferences(ctx, preferences)
+		assert.ErrorIs(t, err, ErrInvalidQuietHours)
+	}
+
+	_, err = service.UpdateNotificationPreferences(ctx, &models.NotificationPreferences{UserID: userID, Timezone: "Mars/Olympus"})
+	assert.ErrorIs(t, err, ErrInvalidTimezone)
+
+	_, err = service.UpdateNotificationPreferences(ctx, &models.NotificationPreferences{
+		UserID:          userID,
+		QuietHoursStart: hour(22),
+		QuietHoursEnd:   hour(8),
+	})
+	require.NoError(t, err)
+
+	preferences, err = service.GetNotificationPreferences(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, preferences.NewVacancies)
+	assert.Equal(t, DefaultTimezone, preferences.Timezone)
+	assert.Equal(t, 22, *preferences.QuietHoursStart)
+}
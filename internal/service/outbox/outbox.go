@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"jobot/internal/repository"
+	"jobot/internal/service/models"
+)
+
+// maxRetryDelay ограничивает экспоненциальную паузу между попытками отправки
+const maxRetryDelay = time.Hour
+
+// Sink - приемник событий outbox (webhook, лог, канал внутри процесса).
+// Name должно быть уникальным и не меняться между запусками: по нему запоминается, какие приемники
+// уже приняли событие. Publish может получить одно событие несколько раз, повторы отсекаются по event_id.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+}
+
+// Config - настройки отправки
+// BatchSize - сколько событий забирается за один проход, RetryDelay - пауза после первой неудачи
+// (дальше удваивается), Lease - на сколько событие закрепляется за проходом, Retention - сколько
+// хранятся отправленные события.
+type Config struct {
+	BatchSize  int
+	RetryDelay time.Duration
+	Lease      time.Duration
+	Retention  time.Duration
+}
+
+type OutboxService struct {
+	outboxRepository repository.OutboxRepository
+	sinks            []Sink
+	config           Config
+	now              func() time.Time
+}
+
+func NewOutboxService(outboxRepository repository.OutboxRepository, sinks []Sink, config Config) *OutboxService {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = 10 * time.Second
+	}
+	if config.Lease <= 0 {
+		config.Lease = time.Minute
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+
+	return &OutboxService{
+		outboxRepository: outboxRepository,
+		sinks:            sinks,
+		config:           config,
+		now:              time.Now,
+	}
+}
+
+// DispatchPending выполняет один проход отправки: забирает события, срок которых наступил, и передает
+// каждое всем приемникам, которые его еще не приняли. Событие считается отправленным, когда его приняли
+// все приемники; иначе оно повторяется с растущей паузой без ограничения числа попыток (at-least-once).
+// Порядок доставки соблюдается только в пределах прохода. Отправленные события старше Retention удаляются.
+// Ошибки сохранения не прерывают проход.
+func (s *OutboxService) DispatchPending(ctx context.Context) (*models.OutboxDispatchStats, error) {
+	now := s.now()
+
+	events, err := s.outboxRepository.ClaimEvents(ctx, now, now.Add(s.config.Lease), s.config.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	stats := &models.OutboxDispatchStats{}
+
+	var errs []error
+	for i := range events {
+		event := &events[i]
+
+		s.dispatch(ctx, event, now, stats)
+
+		if err := s.outboxRepository.UpdateEventDispatch(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update outbox event %s: %w", event.ID, err))
+		}
+	}
+
+	deleted, err := s.outboxRepository.DeleteDispatchedEvents(ctx, now.Add(-s.config.Retention))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete dispatched outbox events: %w", err))
+	}
+	stats.Deleted = deleted
+
+	return stats, errors.Join(errs...)
+}
+
+// dispatch передает event приемникам, которые его еще не приняли, и записывает итог в event и stats
+func (s *OutboxService) dispatch(ctx context.Context, event *models.OutboxEvent, now time.Time, stats *models.OutboxDispatchStats) {
+	var errs []error
+	for _, sink := range s.sinks {
+		if slices.Contains(event.DeliveredSinks, sink.Name()) {
+			continue
+		}
+
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+
+		event.DeliveredSinks = append(event.DeliveredSinks, sink.Name())
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		event.Attempts++
+		lastError := err.Error()
+		event.LastError = &lastError
+		event.NextAttemptAt = now.Add(s.retryDelay(event.Attempts))
+		stats.Retried++
+
+		return
+	}
+
+	event.LastError = nil
+	event.DispatchedAt = &now
+	stats.Dispatched++
+}
+
+// retryDelay - пауза после attempts неудачных попыток
+func (s *OutboxService) retryDelay(attempts int) time.Duration {
+	delay := s.config.RetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
@@ -0,0 +1,115 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	"jobot/internal/service/models"
+	. "jobot/internal/service/outbox"
+)
+
+type outboxRepositoryStub struct {
+	repository.OutboxRepository
+	due     []models.OutboxEvent
+	updated map[uuid.UUID]models.OutboxEvent
+	before  time.Time
+}
+
+func (r *outboxRepositoryStub) ClaimEvents(_ context.Context, _, _ time.Time, limit int) ([]models.OutboxEvent, error) {
+	claimed := r.due[:min(limit, len(r.due))]
+	r.due = r.due[len(claimed):]
+
+	return claimed, nil
+}
+
+func (r *outboxRepositoryStub) UpdateEventDispatch(_ context.Context, event *models.OutboxEvent) error {
+	r.updated[event.ID] = *event
+
+	return nil
+}
+
+func (r *outboxRepositoryStub) DeleteDispatchedEvents(_ context.Context, before time.Time) (int64, error) {
+	r.before = before
+
+	return 2, nil
+}
+
+// sinkStub возвращает ошибку err и запоминает принятые события
+type sinkStub struct {
+	name      string
+	err       error
+	published []uuid.UUID
+}
+
+func (s *sinkStub) Name() string {
+	return s.name
+}
+
+func (s *sinkStub) Publish(_ context.Context, event *models.OutboxEvent) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.published = append(s.published, event.ID)
+
+	return nil
+}
+
+func newEvent(deliveredSinks ...string) models.OutboxEvent {
+	return models.OutboxEvent{
+		ID:             uuid.New(),
+		Type:           models.OutboxEventVacancyCreated,
+		AggregateType:  "vacancy",
+		AggregateID:    uuid.New(),
+		Payload:        []byte(`{}`),
+		DeliveredSinks: deliveredSinks,
+	}
+}
+
+func TestDispatchPending(t *testing.T) {
+	fresh := newEvent()
+	redelivered := newEvent("log")
+	retried := newEvent()
+	retried.Attempts = 2
+
+	repo := &outboxRepositoryStub{
+		due:     []models.OutboxEvent{fresh, redelivered},
+		updated: map[uuid.UUID]models.OutboxEvent{},
+	}
+	log := &sinkStub{name: "log"}
+	webhook := &sinkStub{name: "webhook"}
+	service := NewOutboxService(repo, []Sink{log, webhook}, Config{RetryDelay: time.Minute, Retention: time.Hour})
+
+	stats, err := service.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxDispatchStats{Dispatched: 2, Deleted: 2}, *stats)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), repo.before, 5*time.Second)
+
+	// Приемник, уже принявший событие, его не получает повторно
+	assert.Equal(t, []uuid.UUID{fresh.ID}, log.published)
+	assert.Equal(t, []uuid.UUID{fresh.ID, redelivered.ID}, webhook.published)
+	assert.NotNil(t, repo.updated[fresh.ID].DispatchedAt)
+	assert.ElementsMatch(t, []string{"log", "webhook"}, repo.updated[redelivered.ID].DeliveredSinks)
+
+	// Webhook недоступен: событие остается в outbox, лог его уже принял
+	webhook.err = errors.New("status 503")
+	repo.due = []models.OutboxEvent{retried}
+
+	stats, err = service.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Retried)
+
+	event := repo.updated[retried.ID]
+	assert.Nil(t, event.DispatchedAt)
+	assert.Equal(t, []string{"log"}, event.DeliveredSinks)
+	assert.Equal(t, 3, event.Attempts)
+	assert.Equal(t, "webhook: status 503", *event.LastError)
+	assert.WithinDuration(t, time.Now().Add(4*time.Minute), event.NextAttemptAt, 5*time.Second)
+}
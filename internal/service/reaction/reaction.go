@@ -2,29 +2,75 @@ package reaction
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"jobot/internal/repository"
+	reactionRepo "jobot/internal/repository/reaction"
 	"jobot/internal/service/models"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidReactionType    = errors.New("invalid reaction type")
+	ErrInvalidDecision        = errors.New("invalid employer decision")
+	ErrVacancyNotOwned        = errors.New("vacancy does not belong to employer")
+	ErrCandidateNotInterested = errors.New("employee has not liked this vacancy")
+)
+
 type ReactionService struct {
-	reactionRepository repository.ReactionRepository
+	reactionRepository         repository.ReactionRepository
+	employerReactionRepository repository.EmployerReactionRepository
+	matchRepository            repository.MatchRepository
+	vacancyRepository          repository.VacancyRepository
+	notificationRepository     repository.NotificationRepository
+	txManager                  repository.TxManager
 }
 
-func NewReactionService(reactionRepository repository.ReactionRepository) *ReactionService {
-	return &ReactionService{reactionRepository: reactionRepository}
+func NewReactionService(
+	reactionRepository repository.ReactionRepository,
+	employerReactionRepository repository.EmployerReactionRepository,
+	matchRepository repository.MatchRepository,
+	vacancyRepository repository.VacancyRepository,
+	notificationRepository repository.NotificationRepository,
+	txManager repository.TxManager,
+) *ReactionService {
+	return &ReactionService{
+		reactionRepository:         reactionRepository,
+		employerReactionRepository: employerReactionRepository,
+		matchRepository:            matchRepository,
+		vacancyRepository:          vacancyRepository,
+		notificationRepository:     notificationRepository,
+		txManager:                  txManager,
+	}
 }
 
 func (s *ReactionService) CreateReaction(ctx context.Context, reaction *models.Reaction) (*models.Reaction, error) {
+	if !isValidReactionType(reaction.Type) {
+		return nil, ErrInvalidReactionType
+	}
+
 	reaction.ID = uuid.New()
-	reaction.CreatedAt = time.Now()
+	now := time.Now()
+	reaction.CreatedAt = now
+	reaction.UpdatedAt = now
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.reactionRepository.CreateReaction(ctx, reaction); err != nil {
+			return fmt.Errorf("failed to create reaction: %w", err)
+		}
 
-	if err := s.reactionRepository.CreateReaction(ctx, reaction); err != nil {
-		return nil, fmt.Errorf("failed to create reaction: %w", err)
+		if err := s.notifyLike(ctx, reaction, now); err != nil {
+			return err
+		}
+
+		// Работодатель мог принять сотрудника до того, как тот удалил и заново поставил лайк
+		return s.syncMatch(ctx, reaction.EmployeeID, reaction.VacancyID)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return reaction, nil
@@ -39,8 +85,12 @@ func (s *ReactionService) GetReaction(ctx context.Context, id uuid.UUID) (*model
 	return reaction, nil
 }
 
-func (s *ReactionService) GetEmployeeReactions(ctx context.Context, employeeID uuid.UUID) (*models.EmployeeReactionList, error) {
-	reactionList, err := s.reactionRepository.GetReactionsByEmployee(ctx, employeeID)
+func (s *ReactionService) GetEmployeeReactions(ctx context.Context, employeeID uuid.UUID, filter *models.ReactionFilter) (*models.EmployeeReactionList, error) {
+	if filter != nil && filter.Type != nil && !isValidReactionType(*filter.Type) {
+		return nil, ErrInvalidReactionType
+	}
+
+	reactionList, err := s.reactionRepository.GetReactionsByEmployee(ctx, employeeID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reactions by employee ID: %w", err)
 	}
@@ -48,11 +98,244 @@ func (s *ReactionService) GetEmployeeReactions(ctx context.Context, employeeID u
 	return reactionList, nil
 }
 
+func (s *ReactionService) GetVacancyReactions(ctx context.Context, vacancyID uuid.UUID, filter *models.ReactionFilter) (*models.VacancyReactionList, error) {
+	if filter != nil && filter.Type != nil && !isValidReactionType(*filter.Type) {
+		return nil, ErrInvalidReactionType
+	}
+
+	reactionList, err := s.reactionRepository.GetReactionsByVacancy(ctx, vacancyID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions by vacancy ID: %w", err)
+	}
+
+	return reactionList, nil
+}
+
+func (s *ReactionService) UpdateReaction(ctx context.Context, req *models.ReactionUpdateRequest, id uuid.UUID) (*models.Reaction, error) {
+	getReaction, err := s.reactionRepository.GetReaction(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reaction: %w", err)
+	}
+
+	// Обновляем только переданные поля
+	if req.Type != nil {
+		if !isValidReactionType(*req.Type) {
+			return nil, ErrInvalidReactionType
+		}
+
+		getReaction.Type = *req.Type
+	}
+
+	getReaction.UpdatedAt = time.Now()
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.reactionRepository.UpdateReaction(ctx, getReaction); err != nil {
+			return fmt.Errorf("failed to update reaction: %w", err)
+		}
+
+		if err := s.notifyLike(ctx, getReaction, getReaction.UpdatedAt); err != nil {
+			return err
+		}
+
+		return s.syncMatch(ctx, getReaction.EmployeeID, getReaction.VacancyID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getReaction, nil
+}
+
 func (s *ReactionService) DeleteReaction(ctx context.Context, id uuid.UUID) error {
-	err := s.reactionRepository.DeleteReaction(ctx, id)
+	getReaction, err := s.reactionRepository.GetReaction(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get reaction: %w", err)
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.reactionRepository.DeleteReaction(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete reaction: %w", err)
+		}
+
+		return s.syncMatch(ctx, getReaction.EmployeeID, getReaction.VacancyID)
+	})
+}
+
+func (s *ReactionService) CreateEmployerReaction(ctx context.Context, reaction *models.EmployerReaction) (*models.EmployerReaction, error) {
+	if !isValidDecision(reaction.Decision) {
+		return nil, ErrInvalidDecision
+	}
+
+	vacancy, err := s.vacancyRepository.GetVacancy(ctx, reaction.VacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vacancy: %w", err)
+	}
+
+	if vacancy.EmployerID != reaction.EmployerID {
+		return nil, ErrVacancyNotOwned
+	}
+
+	// Работодатель отвечает только кандидатам, которые лайкнули вакансию
+	employeeReaction, err := s.reactionRepository.GetReactionByEmployeeAndVacancy(ctx, reaction.EmployeeID, reaction.VacancyID)
+	if err != nil && !errors.Is(err, reactionRepo.ErrReactionNotFound) {
+		return nil, fmt.Errorf("failed to get employee reaction: %w", err)
+	}
+
+	if employeeReaction == nil || employeeReaction.Type != models.ReactionTypeLike {
+		return nil, ErrCandidateNotInterested
+	}
+
+	reaction.ID = uuid.New()
+	now := time.Now()
+	reaction.CreatedAt = now
+	reaction.UpdatedAt = now
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.employerReactionRepository.CreateEmployerReaction(ctx, reaction); err != nil {
+			return fmt.Errorf("failed to create employer reaction: %w", err)
+		}
+
+		matched, err := s.matchRepository.SyncMatch(ctx, reaction.EmployeeID, reaction.VacancyID)
+		if err != nil {
+			return fmt.Errorf("failed to sync match: %w", err)
+		}
+
+		reaction.Matched = matched
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (s *ReactionService) GetEmployerReaction(ctx context.Context, id uuid.UUID) (*models.EmployerReaction, error) {
+	reaction, err := s.employerReactionRepository.GetEmployerReaction(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employer reaction by ID: %w", err)
+	}
+
+	return reaction, nil
+}
+
+func (s *ReactionService) GetEmployerReactions(ctx context.Context, employerID uuid.UUID, filter *models.EmployerReactionFilter) (*models.EmployerReactionList, error) {
+	if filter != nil && filter.Decision != nil && !isValidDecision(*filter.Decision) {
+		return nil, ErrInvalidDecision
+	}
+
+	reactionList, err := s.employerReactionRepository.GetEmployerReactionsByEmployer(ctx, employerID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employer reactions by employer ID: %w", err)
+	}
+
+	return reactionList, nil
+}
+
+func (s *ReactionService) UpdateEmployerReaction(ctx context.Context, req *models.EmployerReactionUpdateRequest, id uuid.UUID) (*models.EmployerReaction, error) {
+	getReaction, err := s.employerReactionRepository.GetEmployerReaction(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employer reaction: %w", err)
+	}
+
+	// Обновляем только переданные поля
+	if req.Decision != nil {
+		if !isValidDecision(*req.Decision) {
+			return nil, ErrInvalidDecision
+		}
+
+		getReaction.Decision = *req.Decision
+	}
+
+	getReaction.UpdatedAt = time.Now()
+
+	err = s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.employerReactionRepository.UpdateEmployerReaction(ctx, getReaction); err != nil {
+			return fmt.Errorf("failed to update employer reaction: %w", err)
+		}
+
+		matched, err := s.matchRepository.SyncMatch(ctx, getReaction.EmployeeID, getReaction.VacancyID)
+		if err != nil {
+			return fmt.Errorf("failed to sync match: %w", err)
+		}
+
+		getReaction.Matched = matched
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getReaction, nil
+}
+
+func (s *ReactionService) DeleteEmployerReaction(ctx context.Context, id uuid.UUID) error {
+	getReaction, err := s.employerReactionRepository.GetEmployerReaction(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get employer reaction: %w", err)
+	}
+
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.employerReactionRepository.DeleteEmployerReaction(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete employer reaction: %w", err)
+		}
+
+		return s.syncMatch(ctx, getReaction.EmployeeID, getReaction.VacancyID)
+	})
+}
+
+func (s *ReactionService) GetEmployeeMatches(ctx context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.MatchList, error) {
+	matchList, err := s.matchRepository.GetMatchesByEmployee(ctx, employeeID, pagination)
 	if err != nil {
-		return fmt.Errorf("failed to delete reaction: %w", err)
+		return nil, fmt.Errorf("failed to get matches by employee ID: %w", err)
+	}
+
+	return matchList, nil
+}
+
+func (s *ReactionService) GetEmployerMatches(ctx context.Context, employerID uuid.UUID, pagination models.Pagination) (*models.MatchList, error) {
+	matchList, err := s.matchRepository.GetMatchesByEmployer(ctx, employerID, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matches by employer ID: %w", err)
+	}
+
+	return matchList, nil
+}
+
+// syncMatch пересчитывает мэтч после изменения реакции одной из сторон
+func (s *ReactionService) syncMatch(ctx context.Context, employeeID, vacancyID uuid.UUID) error {
+	if _, err := s.matchRepository.SyncMatch(ctx, employeeID, vacancyID); err != nil {
+		return fmt.Errorf("failed to sync match: %w", err)
+	}
+
+	return nil
+}
+
+// notifyLike ставит в очередь уведомление работодателю, если реакция - лайк.
+// Вызывается в транзакции реакции, поэтому уведомление появляется только вместе с лайком.
+func (s *ReactionService) notifyLike(ctx context.Context, reaction *models.Reaction, now time.Time) error {
+	if reaction.Type != models.ReactionTypeLike {
+		return nil
+	}
+
+	if _, err := s.notificationRepository.EnqueueCandidateLikedNotification(ctx, reaction.EmployeeID, reaction.VacancyID, now); err != nil {
+		return fmt.Errorf("failed to enqueue candidate liked notification: %w", err)
 	}
 
 	return nil
 }
+
+func isValidReactionType(reactionType string) bool {
+	return reactionType == models.ReactionTypeLike || reactionType == models.ReactionTypeDislike
+}
+
+func isValidDecision(decision string) bool {
+	switch decision {
+	case models.EmployerDecisionAccept, models.EmployerDecisionReject, models.EmployerDecisionShortlist:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,403 @@
+package reaction_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	reactionRepo "jobot/internal/repository/reaction"
+	"jobot/internal/service/models"
+	. "jobot/internal/service/reaction"
+)
+
+type reactionRepositoryStub struct {
+	repository.ReactionRepository
+	reactions map[uuid.UUID]*models.Reaction
+}
+
+func (r *reactionRepositoryStub) CreateReaction(_ context.Context, reaction *models.Reaction) error {
+	stored := *reaction
+	r.reactions[reaction.ID] = &stored
+
+	return nil
+}
+
+func (r *reactionRepositoryStub) GetReaction(_ context.Context, id uuid.UUID) (*models.Reaction, error) {
+	reaction, ok := r.reactions[id]
+	if !ok {
+		return nil, reactionRepo.ErrReactionNotFound
+	}
+
+	found := *reaction
+
+	return &found, nil
+}
+
+func (r *reactionRepositoryStub) GetReactionByEmployeeAndVacancy(_ context.Context, employeeID, vacancyID uuid.UUID) (*models.Reaction, error) {
+	for _, reaction := range r.reactions {
+		if reaction.EmployeeID == employeeID && reaction.VacancyID == vacancyID {
+			found := *reaction
+
+			return &found, nil
+		}
+	}
+
+	return nil, reactionRepo.ErrReactionNotFound
+}
+
+func (r *reactionRepositoryStub) UpdateReaction(_ context.Context, reaction *models.Reaction) error {
+	stored := *reaction
+	r.reactions[reaction.ID] = &stored
+
+	return nil
+}
+
+func (r *reactionRepositoryStub) DeleteReaction(_ context.Context, id uuid.UUID) error {
+	delete(r.reactions, id)
+
+	return nil
+}
+
+type employerReactionRepositoryStub struct {
+	repository.EmployerReactionRepository
+	reactions map[uuid.UUID]*models.EmployerReaction
+}
+
+func (r *employerReactionRepositoryStub) CreateEmployerReaction(_ context.Context, reaction *models.EmployerReaction) error {
+	stored := *reaction
+	r.reactions[reaction.ID] = &stored
+
+	return nil
+}
+
+func (r *employerReactionRepositoryStub) GetEmployerReaction(_ context.Context, id uuid.UUID) (*models.EmployerReaction, error) {
+	reaction, ok := r.reactions[id]
+	if !ok {
+		return nil, reactionRepo.ErrEmployerReactionNotFound
+	}
+
+	found := *reaction
+
+	return &found, nil
+}
+
+func (r *employerReactionRepositoryStub) UpdateEmployerReaction(_ context.Context, reaction *models.EmployerReaction) error {
+	stored := *reaction
+	r.reactions[reaction.ID] = &stored
+
+	return nil
+}
+
+func (r *employerReactionRepositoryStub) DeleteEmployerReaction(_ context.Context, id uuid.UUID) error {
+	delete(r.reactions, id)
+
+	return nil
+}
+
+type matchKey struct {
+	employeeID, vacancyID uuid.UUID
+}
+
+// matchRepositoryStub повторяет условие взаимного интереса из MatchRepository.SyncMatch:
+// лайк сотрудника и решение accept работодателя
+type matchRepositoryStub struct {
+	repository.MatchRepository
+	reactions         *reactionRepositoryStub
+	employerReactions *employerReactionRepositoryStub
+	matches           map[matchKey]bool
+}
+
+func (r *matchRepositoryStub) SyncMatch(_ context.Context, employeeID, vacancyID uuid.UUID) (bool, error) {
+	var liked, accepted bool
+
+	for _, reaction := range r.reactions.reactions {
+		if reaction.EmployeeID == employeeID && reaction.VacancyID == vacancyID && reaction.Type == models.ReactionTypeLike {
+			liked = true
+		}
+	}
+
+	for _, reaction := range r.employerReactions.reactions {
+		if reaction.EmployeeID == employeeID && reaction.VacancyID == vacancyID && reaction.Decision == models.EmployerDecisionAccept {
+			accepted = true
+		}
+	}
+
+	key := matchKey{employeeID: employeeID, vacancyID: vacancyID}
+	if liked && accepted {
+		r.matches[key] = true
+	} else {
+		delete(r.matches, key)
+	}
+
+	return r.matches[key], nil
+}
+
+type vacancyRepositoryStub struct {
+	repository.VacancyRepository
+	vacancies map[uuid.UUID]*models.Vacancy
+}
+
+func (r *vacancyRepositoryStub) GetVacancy(_ context.Context, id uuid.UUID) (*models.Vacancy, error) {
+	return r.vacancies[id], nil
+}
+
+type notificationRepositoryStub struct {
+	repository.NotificationRepository
+	likes int
+}
+
+func (r *notificationRepositoryStub) EnqueueCandidateLikedNotification(_ context.Context, _, _ uuid.UUID, _ time.Time) (bool, error) {
+	r.likes++
+
+	return true, nil
+}
+
+type txManagerStub struct{}
+
+func (txManagerStub) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type reactionServiceStubs struct {
+	reactions         *reactionRepositoryStub
+	employerReactions *employerReactionRepositoryStub
+	matches           *matchRepositoryStub
+	notifications     *notificationRepositoryStub
+	vacancy           *models.Vacancy
+	employerID        uuid.UUID
+	employeeID        uuid.UUID
+}
+
+func newReactionService() (*ReactionService, *reactionServiceStubs) {
+	reactions := &reactionRepositoryStub{reactions: map[uuid.UUID]*models.Reaction{}}
+	employerReactions := &employerReactionRepositoryStub{reactions: map[uuid.UUID]*models.EmployerReaction{}}
+
+	stubs := &reactionServiceStubs{
+		reactions:         reactions,
+		employerReactions: employerReactions,
+		matches:           &matchRepositoryStub{reactions: reactions, employerReactions: employerReactions, matches: map[matchKey]bool{}},
+		notifications:     &notificationRepositoryStub{},
+		vacancy:           &models.Vacancy{VacansieID: uuid.New(), EmployerID: uuid.New()},
+		employeeID:        uuid.New(),
+	}
+	stubs.employerID = stubs.vacancy.EmployerID
+
+	vacancies := &vacancyRepositoryStub{vacancies: map[uuid.UUID]*models.Vacancy{stubs.vacancy.VacansieID: stubs.vacancy}}
+	service := NewReactionService(reactions, employerReactions, stubs.matches, vacancies, stubs.notifications, txManagerStub{})
+
+	return service, stubs
+}
+
+// react ставит реакцию сотрудника на вакансию
+func (s *reactionServiceStubs) react(t *testing.T, service *ReactionService, reactionType string) *models.Reaction {
+	t.Helper()
+
+	reaction, err := service.CreateReaction(context.Background(), &models.Reaction{
+		EmployeeID: s.employeeID,
+		VacancyID:  s.vacancy.VacansieID,
+		Type:       reactionType,
+	})
+	require.NoError(t, err)
+
+	return reaction
+}
+
+// decide отвечает на реакцию сотрудника от имени работодателя employerID
+func (s *reactionServiceStubs) decide(service *ReactionService, decision string) (*models.EmployerReaction, error) {
+	return service.CreateEmployerReaction(context.Background(), &models.EmployerReaction{
+		EmployerID: s.employerID,
+		EmployeeID: s.employeeID,
+		VacancyID:  s.vacancy.VacansieID,
+		Decision:   decision,
+	})
+}
+
+func (s *reactionServiceStubs) matched() bool {
+	return s.matches.matches[matchKey{employeeID: s.employeeID, vacancyID: s.vacancy.VacansieID}]
+}
+
+func TestCreateEmployerReaction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		employeeType string
+		decision     string
+		foreign      bool
+		err          error
+		matched      bool
+	}{
+		{name: "like and accept", employeeType: models.ReactionTypeLike, decision: models.EmployerDecisionAccept, matched: true},
+		{name: "like and shortlist", employeeType: models.ReactionTypeLike, decision: models.EmployerDecisionShortlist},
+		{name: "like and reject", employeeType: models.ReactionTypeLike, decision: models.EmployerDecisionReject},
+		{name: "dislike", employeeType: models.ReactionTypeDislike, decision: models.EmployerDecisionAccept, err: ErrCandidateNotInterested},
+		{name: "no employee reaction", decision: models.EmployerDecisionAccept, err: ErrCandidateNotInterested},
+		{name: "foreign vacancy", employeeType: models.ReactionTypeLike, decision: models.EmployerDecisionAccept, foreign: true, err: ErrVacancyNotOwned},
+		{name: "invalid decision", employeeType: models.ReactionTypeLike, decision: "maybe", err: ErrInvalidDecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, stubs := newReactionService()
+			if tt.employeeType != "" {
+				stubs.react(t, service, tt.employeeType)
+			}
+
+			if tt.foreign {
+				stubs.employerID = uuid.New()
+			}
+
+			reaction, err := stubs.decide(service, tt.decision)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				assert.Empty(t, stubs.employerReactions.reactions)
+				assert.False(t, stubs.matched())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.matched, reaction.Matched)
+			assert.Equal(t, tt.matched, stubs.matched())
+		})
+	}
+}
+
+func TestMatchFollowsReactions(t *testing.T) {
+	t.Parallel()
+
+	like, dislike := models.ReactionTypeLike, models.ReactionTypeDislike
+	reject, accept := models.EmployerDecisionReject, models.EmployerDecisionAccept
+
+	tests := []struct {
+		name    string
+		change  func(ctx context.Context, service *ReactionService, reaction *models.Reaction, decision *models.EmployerReaction) error
+		matched bool
+	}{
+		{
+			name: "employee switches to dislike",
+			change: func(ctx context.Context, service *ReactionService, reaction *models.Reaction, _ *models.EmployerReaction) error {
+				_, err := service.UpdateReaction(ctx, &models.ReactionUpdateRequest{Type: &dislike}, reaction.ID)
+				return err
+			},
+		},
+		{
+			name: "employee likes again after dislike",
+			change: func(ctx context.Context, service *ReactionService, reaction *models.Reaction, _ *models.EmployerReaction) error {
+				if _, err := service.UpdateReaction(ctx, &models.ReactionUpdateRequest{Type: &dislike}, reaction.ID); err != nil {
+					return err
+				}
+
+				_, err := service.UpdateReaction(ctx, &models.ReactionUpdateRequest{Type: &like}, reaction.ID)
+				return err
+			},
+			matched: true,
+		},
+		{
+			name: "employee deletes like",
+			change: func(ctx context.Context, service *ReactionService, reaction *models.Reaction, _ *models.EmployerReaction) error {
+				return service.DeleteReaction(ctx, reaction.ID)
+			},
+		},
+		{
+			name: "employer rejects",
+			change: func(ctx context.Context, service *ReactionService, _ *models.Reaction, decision *models.EmployerReaction) error {
+				_, err := service.UpdateEmployerReaction(ctx, &models.EmployerReactionUpdateRequest{Decision: &reject}, decision.ID)
+				return err
+			},
+		},
+		{
+			name: "employer keeps accept",
+			change: func(ctx context.Context, service *ReactionService, _ *models.Reaction, decision *models.EmployerReaction) error {
+				_, err := service.UpdateEmployerReaction(ctx, &models.EmployerReactionUpdateRequest{Decision: &accept}, decision.ID)
+				return err
+			},
+			matched: true,
+		},
+		{
+			name: "employer deletes decision",
+			change: func(ctx context.Context, service *ReactionService, _ *models.Reaction, decision *models.EmployerReaction) error {
+				return service.DeleteEmployerReaction(ctx, decision.ID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, stubs := newReactionService()
+			reaction := stubs.react(t, service, like)
+
+			decision, err := stubs.decide(service, accept)
+			require.NoError(t, err)
+			require.True(t, decision.Matched)
+
+			require.NoError(t, tt.change(context.Background(), service, reaction, decision))
+			assert.Equal(t, tt.matched, stubs.matched())
+		})
+	}
+}
+
+func TestReactionTypeValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service, stubs := newReactionService()
+	invalid := "superlike"
+
+	_, err := service.CreateReaction(ctx, &models.Reaction{EmployeeID: stubs.employeeID, VacancyID: stubs.vacancy.VacansieID, Type: invalid})
+	require.ErrorIs(t, err, ErrInvalidReactionType)
+	assert.Empty(t, stubs.reactions.reactions)
+
+	reaction := stubs.react(t, service, models.ReactionTypeLike)
+
+	_, err = service.UpdateReaction(ctx, &models.ReactionUpdateRequest{Type: &invalid}, reaction.ID)
+	require.ErrorIs(t, err, ErrInvalidReactionType)
+	assert.Equal(t, models.ReactionTypeLike, stubs.reactions.reactions[reaction.ID].Type)
+
+	_, err = service.GetEmployeeReactions(ctx, stubs.employeeID, &models.ReactionFilter{Type: &invalid})
+	require.ErrorIs(t, err, ErrInvalidReactionType)
+
+	_, err = service.GetVacancyReactions(ctx, stubs.vacancy.VacansieID, &models.ReactionFilter{Type: &invalid})
+	require.ErrorIs(t, err, ErrInvalidReactionType)
+}
+
+func TestUpdateReactionType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from, to string
+		notified int
+	}{
+		{from: models.ReactionTypeLike, to: models.ReactionTypeDislike, notified: 1},
+		{from: models.ReactionTypeDislike, to: models.ReactionTypeLike, notified: 1},
+		{from: models.ReactionTypeLike, to: models.ReactionTypeLike, notified: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			t.Parallel()
+
+			service, stubs := newReactionService()
+			reaction := stubs.react(t, service, tt.from)
+
+			updated, err := service.UpdateReaction(context.Background(), &models.ReactionUpdateRequest{Type: &tt.to}, reaction.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, updated.Type)
+			assert.Equal(t, reaction.ID, updated.ID)
+			assert.Equal(t, tt.to, stubs.reactions.reactions[reaction.ID].Type)
+			assert.Len(t, stubs.reactions.reactions, 1)
+
+			// Уведомление работодателю ставится в очередь при каждом лайке, дубликаты отсекает репозиторий
+			assert.Equal(t, tt.notified, stubs.notifications.likes)
+		})
+	}
+}
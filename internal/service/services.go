@@ -13,6 +13,10 @@ type UserService interface {
 	UpdateUser(ctx context.Context, req *models.UserUpdateRequest, id uuid.UUID) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	GetUserProfile(ctx context.Context, id uuid.UUID) (*models.UserProfile, error)
+	GetUserProfileByTgChatID(ctx context.Context, tgChatID string) (*models.UserProfile, error)
+	GetUserProfileByTgUserName(ctx context.Context, tgUserName string) (*models.UserProfile, error)
+	Onboard(ctx context.Context, profile *models.UserProfile) (*models.UserProfile, bool, error)
+	ChangeRole(ctx context.Context, id uuid.UUID, req *models.RoleChangeRequest) (*models.UserProfile, error)
 }
 
 type EmployeeService interface {
@@ -42,7 +46,8 @@ type EmployerService interface {
 type VacancyService interface {
 	CreateVacancy(ctx context.Context, vacancy *models.Vacancy) (*models.Vacancy, error)
 	GetVacancyByID(ctx context.Context, vacancyID uuid.UUID) (*models.Vacancy, error)
-	GetVacancyList(ctx context.Context) (*models.VacancyList, error)
+	GetVacancyList(ctx context.Context, filter *models.VacancyFilter, pagination models.Pagination) (*models.VacancyList, error)
+	SearchVacancies(ctx context.Context, query string, filter *models.VacancyFilter, pagination models.Pagination) (*models.VacancySearchResult, error)
 	GetEmployerVacancies(ctx context.Context, employerID uuid.UUID) (*models.EmployerVacancyList, error)
 	UpdateVacancy(ctx context.Context, req *models.VacancyUpdateRequest, id uuid.UUID) error
 	DeleteVacancy(ctx context.Context, id uuid.UUID) error
@@ -51,6 +56,83 @@ type VacancyService interface {
 type ReactionService interface {
 	CreateReaction(ctx context.Context, reaction *models.Reaction) (*models.Reaction, error)
 	GetReaction(ctx context.Context, id uuid.UUID) (*models.Reaction, error)
-	GetEmployeeReactions(ctx context.Context, employeeID uuid.UUID) (*models.EmployeeReactionList, error)
+	GetEmployeeReactions(ctx context.Context, employeeID uuid.UUID, filter *models.ReactionFilter) (*models.EmployeeReactionList, error)
+	UpdateReaction(ctx context.Context, req *models.ReactionUpdateRequest, id uuid.UUID) (*models.Reaction, error)
 	DeleteReaction(ctx context.Context, id uuid.UUID) error
+	GetVacancyReactions(ctx context.Context, vacancyID uuid.UUID, filter *models.ReactionFilter) (*models.VacancyReactionList, error)
+
+	CreateEmployerReaction(ctx context.Context, reaction *models.EmployerReaction) (*models.EmployerReaction, error)
+	GetEmployerReaction(ctx context.Context, id uuid.UUID) (*models.EmployerReaction, error)
+	GetEmployerReactions(ctx context.Context, employerID uuid.UUID, filter *models.EmployerReactionFilter) (*models.EmployerReactionList, error)
+	UpdateEmployerReaction(ctx context.Context, req *models.EmployerReactionUpdateRequest, id uuid.UUID) (*models.EmployerReaction, error)
+	DeleteEmployerReaction(ctx context.Context, id uuid.UUID) error
+
+	GetEmployeeMatches(ctx context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.MatchList, error)
+	GetEmployerMatches(ctx context.Context, employerID uuid.UUID, pagination models.Pagination) (*models.MatchList, error)
+}
+
+type FeedService interface {
+	GetEmployeeFeed(ctx context.Context, employeeID uuid.UUID, pagination models.Pagination) (*models.VacancyFeed, error)
+	GetVacancyCandidates(ctx context.Context, vacancyID uuid.UUID, pagination models.Pagination) (*models.CandidateList, error)
+}
+
+type ApplicationService interface {
+	CreateApplication(ctx context.Context, application *models.Application) (*models.Application, error)
+	GetApplication(ctx context.Context, id uuid.UUID) (*models.Application, error)
+	GetEmployeeApplications(ctx context.Context, employeeID uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error)
+	GetEmployerApplications(ctx context.Context, employerID uuid.UUID, filter *models.ApplicationFilter, pagination models.Pagination) (*models.ApplicationList, error)
+	TransitApplication(ctx context.Context, req *models.ApplicationTransitionRequest, id uuid.UUID) (*models.Application, error)
+}
+
+type AuthService interface {
+	LoginTelegramWidget(ctx context.Context, data map[string]string, role string) (*models.AuthResult, error)
+	LoginTelegramWebApp(ctx context.Context, initData string, role string) (*models.AuthResult, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*models.AuthResult, error)
+	Authenticate(ctx context.Context, accessToken string) (*models.User, error)
+}
+
+type AccessService interface {
+	RequireRole(ctx context.Context, roles ...string) error
+	CheckUser(ctx context.Context, userID uuid.UUID) error
+	CheckTelegramUser(ctx context.Context, tgChatID string) error
+	CheckNewProfile(ctx context.Context, userID uuid.UUID, role string) error
+	CheckRoleChange(ctx context.Context, role string) error
+	CheckEmployee(ctx context.Context, employeeID uuid.UUID) error
+	CheckEmployer(ctx context.Context, employerID uuid.UUID) error
+	CheckResume(ctx context.Context, resumeID uuid.UUID) error
+	CheckResumeRead(ctx context.Context, resumeID uuid.UUID) error
+	CheckCandidateResume(ctx context.Context, employeeID uuid.UUID) error
+	EmployerHasCandidate(ctx context.Context, employerID, employeeID uuid.UUID) (bool, error)
+	CheckVacancy(ctx context.Context, vacancyID uuid.UUID) error
+	CheckReaction(ctx context.Context, reactionID uuid.UUID) error
+	CheckEmployerReaction(ctx context.Context, reactionID uuid.UUID) error
+	CheckApplication(ctx context.Context, applicationID uuid.UUID) error
+	CheckApplicationActor(ctx context.Context, actor string, actorID uuid.UUID) error
+}
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, key *models.APIKey) (*models.IssuedAPIKey, error)
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	RotateAPIKey(ctx context.Context, id uuid.UUID) (*models.IssuedAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	Authenticate(ctx context.Context, rawKey string, onBehalfOf string) (*models.APIKey, *models.User, error)
+}
+
+type ConversationService interface {
+	StartConversation(ctx context.Context, tgChatID, flow string) (*models.Conversation, bool, error)
+	GetConversation(ctx context.Context, tgChatID string) (*models.Conversation, error)
+	ResumeConversation(ctx context.Context, tgChatID string) (*models.Conversation, error)
+	AnswerStep(ctx context.Context, conversation *models.Conversation, field, value string) error
+	StepBack(ctx context.Context, conversation *models.Conversation) error
+	DeleteConversation(ctx context.Context, tgChatID string) error
+}
+
+type NotificationService interface {
+	DeliverDue(ctx context.Context) (*models.NotificationDeliveryStats, error)
+	GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+}
+
+type OutboxService interface {
+	DispatchPending(ctx context.Context) (*models.OutboxDispatchStats, error)
 }
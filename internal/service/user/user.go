@@ -5,24 +5,59 @@ import (
 	"errors"
 	"fmt"
 	"jobot/internal/repository"
+	employeeRepo "jobot/internal/repository/employee"
+	employerRepo "jobot/internal/repository/employer"
+	userRepo "jobot/internal/repository/user"
+	"jobot/internal/service/auth"
 	"jobot/internal/service/models"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrUserRoleNotFound = errors.New("user role not found")
+	ErrUserRoleNotFound      = errors.New("user role not found")
+	ErrInvalidOnboardingRole = errors.New("onboarding role must be employee or employer")
+	ErrProfileDataRequired   = errors.New("profile data for the role is required")
+	ErrRoleMismatch          = errors.New("user is already registered with another role")
+	ErrTgUserNameRequired    = errors.New("telegram user name is required")
+	ErrInvalidRole           = errors.New("role must be employee, employer or admin")
+	ErrRoleUnchanged         = errors.New("user already has this role")
+	ErrRoleChangeNotAllowed  = errors.New("role can only be changed with the role change operation")
 )
 
+// onboardingAttempts - сколько раз повторить онбординг, если параллельный запрос создал те же записи
+const onboardingAttempts = 2
+
+// roleChangeComment - комментарий в истории заявок, отклоненных при смене роли
+const roleChangeComment = "closed automatically: user changed role"
+
 type UserService struct {
-	userRepository     repository.UserRepository
-	employeeRepository repository.EmployeeRepository
-	employerRepository repository.EmployerRepository
+	userRepository        repository.UserRepository
+	employeeRepository    repository.EmployeeRepository
+	employerRepository    repository.EmployerRepository
+	vacancyRepository     repository.VacancyRepository
+	applicationRepository repository.ApplicationRepository
+	txManager             repository.TxManager
 }
 
-func NewUserService(userRepository repository.UserRepository, employeeRepository repository.EmployeeRepository, employerRepository repository.EmployerRepository) *UserService {
-	return &UserService{userRepository: userRepository, employeeRepository: employeeRepository, employerRepository: employerRepository}
+func NewUserService(
+	userRepository repository.UserRepository,
+	employeeRepository repository.EmployeeRepository,
+	employerRepository repository.EmployerRepository,
+	vacancyRepository repository.VacancyRepository,
+	applicationRepository repository.ApplicationRepository,
+	txManager repository.TxManager,
+) *UserService {
+	return &UserService{
+		userRepository:        userRepository,
+		employeeRepository:    employeeRepository,
+		employerRepository:    employerRepository,
+		vacancyRepository:     vacancyRepository,
+		applicationRepository: applicationRepository,
+		txManager:             txManager,
+	}
 }
 
 func (s *UserService) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
@@ -47,12 +82,17 @@ func (s *UserService) GetUser(ctx context.Context, id uuid.UUID) (*models.User,
 	return user, nil
 }
 
+// UpdateUser обновляет данные пользователя. Роль здесь не меняется, для этого есть ChangeRole.
 func (s *UserService) UpdateUser(ctx context.Context, req *models.UserUpdateRequest, id uuid.UUID) error {
 	getUser, err := s.GetUser(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
+	if req.Role != nil && *req.Role != getUser.Role {
+		return ErrRoleChangeNotAllowed
+	}
+
 	if req.TgUserName != nil {
 		getUser.TgUserName = *req.TgUserName
 	}
@@ -69,10 +109,6 @@ func (s *UserService) UpdateUser(ctx context.Context, req *models.UserUpdateRequ
 		getUser.IsPremium = *req.IsPremium
 	}
 
-	if req.Role != nil {
-		getUser.Role = *req.Role
-	}
-
 	getUser.UpdatedAt = time.Now()
 
 	err = s.userRepository.UpdateUser(ctx, getUser)
@@ -98,6 +134,44 @@ func (s *UserService) GetUserProfile(ctx context.Context, id uuid.UUID) (*models
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	return s.profileOf(ctx, user)
+}
+
+// GetUserProfileByTgChatID возвращает полный профиль пользователя по Telegram chat ID
+func (s *UserService) GetUserProfileByTgChatID(ctx context.Context, tgChatID string) (*models.UserProfile, error) {
+	user, err := s.userRepository.GetUserByTgChatID(ctx, tgChatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by tg_chat_id: %w", err)
+	}
+
+	return s.profileOf(ctx, user)
+}
+
+// GetUserProfileByTgUserName возвращает полный профиль пользователя по Telegram username.
+// Username сравнивается без учета регистра, ведущий @ отбрасывается.
+func (s *UserService) GetUserProfileByTgUserName(ctx context.Context, tgUserName string) (*models.UserProfile, error) {
+	tgUserName = NormalizeTgUserName(tgUserName)
+	if tgUserName == "" {
+		return nil, ErrTgUserNameRequired
+	}
+
+	user, err := s.userRepository.GetUserByTgUserName(ctx, tgUserName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by tg_user_name: %w", err)
+	}
+
+	return s.profileOf(ctx, user)
+}
+
+// NormalizeTgUserName приводит Telegram username к виду без @ и пробелов по краям
+func NormalizeTgUserName(tgUserName string) string {
+	return strings.TrimPrefix(strings.TrimSpace(tgUserName), "@")
+}
+
+// profileOf дополняет пользователя профилем его роли
+func (s *UserService) profileOf(ctx context.Context, user *models.User) (*models.UserProfile, error) {
+	id := user.ID
+
 	switch user.Role {
 	case "employee":
 		employee, err := s.employeeRepository.GetEmployeeByUserID(ctx, id)
@@ -113,7 +187,374 @@ func (s *UserService) GetUserProfile(ctx context.Context, id uuid.UUID) (*models
 		}
 
 		return &models.UserProfile{User: user, Employer: employer}, nil
+	case models.UserRoleAdmin:
+		return &models.UserProfile{User: user}, nil
 	default:
 		return nil, ErrUserRoleNotFound
 	}
 }
+
+// Onboard регистрирует пользователя Telegram вместе с профилем его роли в одной транзакции.
+// В profile задаются User (tg_chat_id, имя, роль) и Employee или Employer для этой роли.
+// Повторный вызов с тем же tg_chat_id не создает дублей: существующий пользователь и профиль
+// возвращаются как есть, недостающий профиль создается. created - создана ли хотя бы одна запись.
+func (s *UserService) Onboard(ctx context.Context, profile *models.UserProfile) (*models.UserProfile, bool, error) {
+	if err := validateOnboarding(profile); err != nil {
+		return nil, false, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, created, err := s.onboard(ctx, profile)
+		if isAlreadyExists(err) && attempt < onboardingAttempts {
+			// Параллельный онбординг того же tg_chat_id успел создать записи, при повторе они будут найдены
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		return result, created, nil
+	}
+}
+
+func (s *UserService) onboard(ctx context.Context, profile *models.UserProfile) (*models.UserProfile, bool, error) {
+	result := &models.UserProfile{}
+	created := false
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, userCreated, err := s.getOrCreateUser(ctx, profile.User)
+		if err != nil {
+			return err
+		}
+
+		result.User = user
+		created = userCreated
+
+		switch user.Role {
+		case models.UserRoleEmployee:
+			employee, employeeCreated, err := s.getOrCreateEmployee(ctx, user.ID, profile.Employee)
+			if err != nil {
+				return err
+			}
+
+			result.Employee = employee
+			created = created || employeeCreated
+		case models.UserRoleEmployer:
+			employer, employerCreated, err := s.getOrCreateEmployer(ctx, user.ID, profile.Employer)
+			if err != nil {
+				return err
+			}
+
+			result.Employer = employer
+			created = created || employerCreated
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, created, nil
+}
+
+// ChangeRole меняет роль пользователя вместе с профилями ролей в одной транзакции.
+//
+// Профиль прежней роли архивируется, а не удаляется: реакции, мэтчи, резюме и история заявок
+// продолжают на него ссылаться. Незавершенные заявки отклоняются от имени прежней роли,
+// вакансии работодателя архивируются и пропадают из выдачи.
+// Профиль новой роли восстанавливается из архива, если пользователь уже был в этой роли,
+// иначе создается из req.Employee или req.Employer. Архивные вакансии при возврате не восстанавливаются.
+func (s *UserService) ChangeRole(ctx context.Context, id uuid.UUID, req *models.RoleChangeRequest) (*models.UserProfile, error) {
+	switch req.Role {
+	case models.UserRoleEmployee, models.UserRoleEmployer, models.UserRoleAdmin:
+	default:
+		return nil, ErrInvalidRole
+	}
+
+	var profile *models.UserProfile
+
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.GetUser(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		if !user.IsActive {
+			return auth.ErrUserInactive
+		}
+
+		if user.Role == req.Role {
+			return ErrRoleUnchanged
+		}
+
+		switch req.Role {
+		case models.UserRoleEmployee:
+			err = s.activateEmployee(ctx, user.ID, req.Employee)
+		case models.UserRoleEmployer:
+			err = s.activateEmployer(ctx, user.ID, req.Employer)
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		if err := s.archiveRoleProfile(ctx, user, now); err != nil {
+			return err
+		}
+
+		user.Role = req.Role
+		user.UpdatedAt = now
+
+		if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		profile, err = s.profileOf(ctx, user)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// archiveRoleProfile архивирует профиль текущей роли пользователя и закрывает связанные с ним записи
+func (s *UserService) archiveRoleProfile(ctx context.Context, user *models.User, now time.Time) error {
+	switch user.Role {
+	case models.UserRoleEmployee:
+		employee, err := s.employeeRepository.GetEmployeeByUserID(ctx, user.ID)
+		if errors.Is(err, employeeRepo.ErrEmployeeNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get employee: %w", err)
+		}
+
+		if _, err := s.applicationRepository.RejectOpenApplicationsByEmployee(ctx, employee.EmployeeID, roleChangeComment); err != nil {
+			return fmt.Errorf("failed to reject employee applications: %w", err)
+		}
+
+		if err := s.employeeRepository.SetEmployeeArchived(ctx, employee.EmployeeID, &now); err != nil {
+			return fmt.Errorf("failed to archive employee: %w", err)
+		}
+	case models.UserRoleEmployer:
+		employer, err := s.employerRepository.GetEmployerByUserID(ctx, user.ID)
+		if errors.Is(err, employerRepo.ErrEmployerNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get employer: %w", err)
+		}
+
+		if _, err := s.applicationRepository.RejectOpenApplicationsByEmployer(ctx, employer.EmployerID, roleChangeComment); err != nil {
+			return fmt.Errorf("failed to reject employer applications: %w", err)
+		}
+
+		if _, err := s.vacancyRepository.ArchiveVacanciesByEmployer(ctx, employer.EmployerID, now); err != nil {
+			return fmt.Errorf("failed to archive employer vacancies: %w", err)
+		}
+
+		if err := s.employerRepository.SetEmployerArchived(ctx, employer.EmployerID, &now); err != nil {
+			return fmt.Errorf("failed to archive employer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// activateEmployee восстанавливает архивный профиль сотрудника или создает новый из data
+func (s *UserService) activateEmployee(ctx context.Context, userID uuid.UUID, data *models.Employee) error {
+	employee, err := s.employeeRepository.GetEmployeeByUserID(ctx, userID)
+	if err == nil {
+		if employee.ArchivedAt == nil {
+			return nil
+		}
+
+		if err := s.employeeRepository.SetEmployeeArchived(ctx, employee.EmployeeID, nil); err != nil {
+			return fmt.Errorf("failed to restore employee: %w", err)
+		}
+
+		return nil
+	}
+
+	if !errors.Is(err, employeeRepo.ErrEmployeeNotFound) {
+		return fmt.Errorf("failed to get employee: %w", err)
+	}
+
+	if data == nil {
+		return fmt.Errorf("%w: employee", ErrProfileDataRequired)
+	}
+
+	_, err = s.createEmployee(ctx, userID, data)
+
+	return err
+}
+
+// activateEmployer восстанавливает архивный профиль работодателя или создает новый из data
+func (s *UserService) activateEmployer(ctx context.Context, userID uuid.UUID, data *models.Employer) error {
+	employer, err := s.employerRepository.GetEmployerByUserID(ctx, userID)
+	if err == nil {
+		if employer.ArchivedAt == nil {
+			return nil
+		}
+
+		if err := s.employerRepository.SetEmployerArchived(ctx, employer.EmployerID, nil); err != nil {
+			return fmt.Errorf("failed to restore employer: %w", err)
+		}
+
+		return nil
+	}
+
+	if !errors.Is(err, employerRepo.ErrEmployerNotFound) {
+		return fmt.Errorf("failed to get employer: %w", err)
+	}
+
+	if data == nil {
+		return fmt.Errorf("%w: employer", ErrProfileDataRequired)
+	}
+
+	_, err = s.createEmployer(ctx, userID, data)
+
+	return err
+}
+
+func (s *UserService) getOrCreateUser(ctx context.Context, identity *models.User) (*models.User, bool, error) {
+	user, err := s.userRepository.GetUserByTgChatID(ctx, identity.TgChatID)
+	if err == nil {
+		if !user.IsActive {
+			return nil, false, auth.ErrUserInactive
+		}
+
+		if user.Role != identity.Role {
+			return nil, false, fmt.Errorf("%w: %s", ErrRoleMismatch, user.Role)
+		}
+
+		return user, false, nil
+	}
+
+	if !errors.Is(err, userRepo.ErrUserNotFound) {
+		return nil, false, fmt.Errorf("failed to get user by tg_chat_id: %w", err)
+	}
+
+	now := time.Now()
+	user = &models.User{
+		ID:         uuid.New(),
+		TgUserName: identity.TgUserName,
+		TgChatID:   identity.TgChatID,
+		IsActive:   true,
+		Role:       identity.Role,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.userRepository.CreateUser(ctx, user); err != nil {
+		return nil, false, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, true, nil
+}
+
+func (s *UserService) getOrCreateEmployee(ctx context.Context, userID uuid.UUID, data *models.Employee) (*models.Employee, bool, error) {
+	employee, err := s.employeeRepository.GetEmployeeByUserID(ctx, userID)
+	if err == nil {
+		return employee, false, nil
+	}
+
+	if !errors.Is(err, employeeRepo.ErrEmployeeNotFound) {
+		return nil, false, fmt.Errorf("failed to get employee: %w", err)
+	}
+
+	employee, err = s.createEmployee(ctx, userID, data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return employee, true, nil
+}
+
+func (s *UserService) createEmployee(ctx context.Context, userID uuid.UUID, data *models.Employee) (*models.Employee, error) {
+	now := time.Now()
+	employee := &models.Employee{
+		EmployeeID: uuid.New(),
+		UserID:     userID,
+		Tags:       data.Tags,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.employeeRepository.CreateEmployee(ctx, employee); err != nil {
+		return nil, fmt.Errorf("failed to create employee: %w", err)
+	}
+
+	return employee, nil
+}
+
+func (s *UserService) getOrCreateEmployer(ctx context.Context, userID uuid.UUID, data *models.Employer) (*models.Employer, bool, error) {
+	employer, err := s.employerRepository.GetEmployerByUserID(ctx, userID)
+	if err == nil {
+		return employer, false, nil
+	}
+
+	if !errors.Is(err, employerRepo.ErrEmployerNotFound) {
+		return nil, false, fmt.Errorf("failed to get employer: %w", err)
+	}
+
+	employer, err = s.createEmployer(ctx, userID, data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return employer, true, nil
+}
+
+func (s *UserService) createEmployer(ctx context.Context, userID uuid.UUID, data *models.Employer) (*models.Employer, error) {
+	now := time.Now()
+	employer := &models.Employer{
+		EmployerID:         uuid.New(),
+		UserID:             userID,
+		CompanyName:        data.CompanyName,
+		CompanyDescription: data.CompanyDescription,
+		CompanyWebsite:     data.CompanyWebsite,
+		CompanyLocation:    data.CompanyLocation,
+		CompanySize:        data.CompanySize,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	if err := s.employerRepository.CreateEmployer(ctx, employer); err != nil {
+		return nil, fmt.Errorf("failed to create employer: %w", err)
+	}
+
+	return employer, nil
+}
+
+func validateOnboarding(profile *models.UserProfile) error {
+	if profile.User == nil {
+		return ErrProfileDataRequired
+	}
+
+	switch profile.User.Role {
+	case models.UserRoleEmployee:
+		if profile.Employee == nil {
+			return fmt.Errorf("%w: employee", ErrProfileDataRequired)
+		}
+	case models.UserRoleEmployer:
+		if profile.Employer == nil {
+			return fmt.Errorf("%w: employer", ErrProfileDataRequired)
+		}
+	default:
+		return ErrInvalidOnboardingRole
+	}
+
+	return nil
+}
+
+func isAlreadyExists(err error) bool {
+	return errors.Is(err, userRepo.ErrUserAlreadyExists) ||
+		errors.Is(err, employeeRepo.ErrEmployeeAlreadyExists) ||
+		errors.Is(err, employerRepo.ErrEmployerAlreadyExists)
+}
@@ -0,0 +1,319 @@
+package user_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	employeeRepo "jobot/internal/repository/employee"
+	employerRepo "jobot/internal/repository/employer"
+	userRepo "jobot/internal/repository/user"
+	"jobot/internal/service/models"
+	. "jobot/internal/service/user"
+)
+
+type userRepositoryStub struct {
+	repository.UserRepository
+	users map[string]*models.User
+}
+
+func (r *userRepositoryStub) CreateUser(_ context.Context, user *models.User) error {
+	stored := *user
+	r.users[user.TgChatID] = &stored
+
+	return nil
+}
+
+func (r *userRepositoryStub) GetUserByTgChatID(_ context.Context, tgChatID string) (*models.User, error) {
+	user, ok := r.users[tgChatID]
+	if !ok {
+		return nil, userRepo.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (r *userRepositoryStub) GetUserByTgUserName(_ context.Context, tgUserName string) (*models.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.TgUserName, tgUserName) {
+			return user, nil
+		}
+	}
+
+	return nil, userRepo.ErrUserNotFound
+}
+
+func (r *userRepositoryStub) GetUser(_ context.Context, id uuid.UUID) (*models.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			stored := *user
+
+			return &stored, nil
+		}
+	}
+
+	return nil, userRepo.ErrUserNotFound
+}
+
+func (r *userRepositoryStub) UpdateUser(_ context.Context, user *models.User) error {
+	stored := *user
+	r.users[user.TgChatID] = &stored
+
+	return nil
+}
+
+type employeeRepositoryStub struct {
+	repository.EmployeeRepository
+	employees map[uuid.UUID]*models.Employee
+}
+
+func (r *employeeRepositoryStub) CreateEmployee(_ context.Context, employee *models.Employee) error {
+	stored := *employee
+	r.employees[employee.UserID] = &stored
+
+	return nil
+}
+
+func (r *employeeRepositoryStub) GetEmployeeByUserID(_ context.Context, userID uuid.UUID) (*models.Employee, error) {
+	employee, ok := r.employees[userID]
+	if !ok {
+		return nil, employeeRepo.ErrEmployeeNotFound
+	}
+
+	return employee, nil
+}
+
+func (r *employeeRepositoryStub) SetEmployeeArchived(_ context.Context, employeeID uuid.UUID, archivedAt *time.Time) error {
+	for _, employee := range r.employees {
+		if employee.EmployeeID == employeeID {
+			employee.ArchivedAt = archivedAt
+
+			return nil
+		}
+	}
+
+	return employeeRepo.ErrEmployeeNotFound
+}
+
+type employerRepositoryStub struct {
+	repository.EmployerRepository
+	employers map[uuid.UUID]*models.Employer
+}
+
+func (r *employerRepositoryStub) CreateEmployer(_ context.Context, employer *models.Employer) error {
+	stored := *employer
+	r.employers[employer.UserID] = &stored
+
+	return nil
+}
+
+func (r *employerRepositoryStub) GetEmployerByUserID(_ context.Context, userID uuid.UUID) (*models.Employer, error) {
+	employer, ok := r.employers[userID]
+	if !ok {
+		return nil, employerRepo.ErrEmployerNotFound
+	}
+
+	return employer, nil
+}
+
+func (r *employerRepositoryStub) SetEmployerArchived(_ context.Context, employerID uuid.UUID, archivedAt *time.Time) error {
+	for _, employer := range r.employers {
+		if employer.EmployerID == employerID {
+			employer.ArchivedAt = archivedAt
+
+			return nil
+		}
+	}
+
+	return employerRepo.ErrEmployerNotFound
+}
+
+type vacancyRepositoryStub struct {
+	repository.VacancyRepository
+	archivedEmployers []uuid.UUID
+}
+
+func (r *vacancyRepositoryStub) ArchiveVacanciesByEmployer(_ context.Context, employerID uuid.UUID, _ time.Time) (int64, error) {
+	r.archivedEmployers = append(r.archivedEmployers, employerID)
+
+	return 1, nil
+}
+
+type applicationRepositoryStub struct {
+	repository.ApplicationRepository
+	rejectedEmployees []uuid.UUID
+	rejectedEmployers []uuid.UUID
+}
+
+func (r *applicationRepositoryStub) RejectOpenApplicationsByEmployee(_ context.Context, employeeID uuid.UUID, _ string) (int64, error) {
+	r.rejectedEmployees = append(r.rejectedEmployees, employeeID)
+
+	return 0, nil
+}
+
+func (r *applicationRepositoryStub) RejectOpenApplicationsByEmployer(_ context.Context, employerID uuid.UUID, _ string) (int64, error) {
+	r.rejectedEmployers = append(r.rejectedEmployers, employerID)
+
+	return 0, nil
+}
+
+type txManagerStub struct{}
+
+func (txManagerStub) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type userServiceStubs struct {
+	users        *userRepositoryStub
+	employees    *employeeRepositoryStub
+	employers    *employerRepositoryStub
+	vacancies    *vacancyRepositoryStub
+	applications *applicationRepositoryStub
+}
+
+func newUserService() (*UserService, *userServiceStubs) {
+	stubs := &userServiceStubs{
+		users:        &userRepositoryStub{users: map[string]*models.User{}},
+		employees:    &employeeRepositoryStub{employees: map[uuid.UUID]*models.Employee{}},
+		employers:    &employerRepositoryStub{employers: map[uuid.UUID]*models.Employer{}},
+		vacancies:    &vacancyRepositoryStub{},
+		applications: &applicationRepositoryStub{},
+	}
+
+	service := NewUserService(stubs.users, stubs.employees, stubs.employers, stubs.vacancies, stubs.applications, txManagerStub{})
+
+	return service, stubs
+}
+
+func newEmployeeProfile(tgChatID string) *models.UserProfile {
+	return &models.UserProfile{
+		User:     &models.User{TgChatID: tgChatID, TgUserName: "alice", Role: models.UserRoleEmployee},
+		Employee: &models.Employee{Tags: []string{"go"}},
+	}
+}
+
+func TestOnboardIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service, stubs := newUserService()
+
+	profile, created, err := service.Onboard(ctx, newEmployeeProfile("100"))
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.True(t, profile.User.IsActive)
+	assert.Equal(t, profile.User.ID, profile.Employee.UserID)
+	assert.Equal(t, []string{"go"}, profile.Employee.Tags)
+
+	again, created, err := service.Onboard(ctx, newEmployeeProfile("100"))
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, profile.User.ID, again.User.ID)
+	assert.Equal(t, profile.Employee.EmployeeID, again.Employee.EmployeeID)
+	assert.Len(t, stubs.users.users, 1)
+	assert.Len(t, stubs.employees.employees, 1)
+}
+
+func TestOnboardIgnoresClientPremium(t *testing.T) {
+	t.Parallel()
+
+	service, stubs := newUserService()
+
+	requested := newEmployeeProfile("150")
+	requested.User.IsPremium = true
+
+	profile, _, err := service.Onboard(context.Background(), requested)
+	require.NoError(t, err)
+	assert.False(t, profile.User.IsPremium)
+	assert.False(t, stubs.users.users["150"].IsPremium)
+}
+
+func TestOnboardRejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service, _ := newUserService()
+
+	_, _, err := service.Onboard(ctx, newEmployeeProfile("200"))
+	require.NoError(t, err)
+
+	_, _, err = service.Onboard(ctx, &models.UserProfile{
+		User:     &models.User{TgChatID: "200", Role: models.UserRoleEmployer},
+		Employer: &models.Employer{CompanyName: "Acme"},
+	})
+	assert.ErrorIs(t, err, ErrRoleMismatch)
+
+	_, _, err = service.Onboard(ctx, &models.UserProfile{User: &models.User{TgChatID: "300", Role: models.UserRoleEmployer}})
+	assert.ErrorIs(t, err, ErrProfileDataRequired)
+
+	_, _, err = service.Onboard(ctx, &models.UserProfile{User: &models.User{TgChatID: "300", Role: models.UserRoleAdmin}})
+	assert.ErrorIs(t, err, ErrInvalidOnboardingRole)
+}
+
+func TestGetUserProfileByTelegram(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service, _ := newUserService()
+
+	onboarded, _, err := service.Onboard(ctx, newEmployeeProfile("400"))
+	require.NoError(t, err)
+
+	profile, err := service.GetUserProfileByTgChatID(ctx, "400")
+	require.NoError(t, err)
+	assert.Equal(t, onboarded.Employee.EmployeeID, profile.Employee.EmployeeID)
+
+	profile, err = service.GetUserProfileByTgUserName(ctx, " @Alice")
+	require.NoError(t, err)
+	assert.Equal(t, onboarded.User.ID, profile.User.ID)
+
+	_, err = service.GetUserProfileByTgChatID(ctx, "500")
+	assert.ErrorIs(t, err, userRepo.ErrUserNotFound)
+
+	_, err = service.GetUserProfileByTgUserName(ctx, "@")
+	assert.ErrorIs(t, err, ErrTgUserNameRequired)
+}
+
+func TestChangeRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	service, stubs := newUserService()
+
+	onboarded, _, err := service.Onboard(ctx, newEmployeeProfile("600"))
+	require.NoError(t, err)
+	userID := onboarded.User.ID
+
+	_, err = service.ChangeRole(ctx, userID, &models.RoleChangeRequest{Role: models.UserRoleEmployer})
+	assert.ErrorIs(t, err, ErrProfileDataRequired)
+
+	profile, err := service.ChangeRole(ctx, userID, &models.RoleChangeRequest{
+		Role:     models.UserRoleEmployer,
+		Employer: &models.Employer{CompanyName: "Acme"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.UserRoleEmployer, profile.User.Role)
+	assert.Equal(t, "Acme", profile.Employer.CompanyName)
+	assert.NotNil(t, stubs.employees.employees[userID].ArchivedAt)
+	assert.Equal(t, []uuid.UUID{onboarded.Employee.EmployeeID}, stubs.applications.rejectedEmployees)
+
+	_, err = service.ChangeRole(ctx, userID, &models.RoleChangeRequest{Role: models.UserRoleEmployer})
+	assert.ErrorIs(t, err, ErrRoleUnchanged)
+
+	profile, err = service.ChangeRole(ctx, userID, &models.RoleChangeRequest{Role: models.UserRoleEmployee})
+	require.NoError(t, err)
+	assert.Equal(t, onboarded.Employee.EmployeeID, profile.Employee.EmployeeID)
+	assert.Nil(t, profile.Employee.ArchivedAt)
+	assert.NotNil(t, stubs.employers.employers[userID].ArchivedAt)
+	assert.Equal(t, []uuid.UUID{stubs.employers.employers[userID].EmployerID}, stubs.vacancies.archivedEmployers)
+
+	role := models.UserRoleAdmin
+	err = service.UpdateUser(ctx, &models.UserUpdateRequest{Role: &role}, userID)
+	assert.ErrorIs(t, err, ErrRoleChangeNotAllowed)
+}
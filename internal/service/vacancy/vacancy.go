@@ -2,7 +2,9 @@ package vacancy
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"jobot/internal/repository"
@@ -11,14 +13,31 @@ import (
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidDateRange = errors.New("created_from must not be after created_to")
+	ErrEmptySearchQuery = errors.New("search query must not be empty")
+)
+
 type VacancyService struct {
-	vacancyRepository repository.VacancyRepository
+	vacancyRepository      repository.VacancyRepository
+	notificationRepository repository.NotificationRepository
+	txManager              repository.TxManager
 }
 
-func NewVacancyService(vacancyRepository repository.VacancyRepository) *VacancyService {
-	return &VacancyService{vacancyRepository: vacancyRepository}
+func NewVacancyService(
+	vacancyRepository repository.VacancyRepository,
+	notificationRepository repository.NotificationRepository,
+	txManager repository.TxManager,
+) *VacancyService {
+	return &VacancyService{
+		vacancyRepository:      vacancyRepository,
+		notificationRepository: notificationRepository,
+		txManager:              txManager,
+	}
 }
 
+// CreateVacancy создает вакансию и в той же транзакции ставит в очередь уведомления сотрудникам,
+// чьи теги пересекаются с тегами вакансии. Уведомления доставляет NotificationService.
 func (s *VacancyService) CreateVacancy(ctx context.Context, vacancy *models.Vacancy) (*models.Vacancy, error) {
 	// Генерируем UUID для vacancy
 	vacancy.VacansieID = uuid.New()
@@ -26,8 +45,23 @@ func (s *VacancyService) CreateVacancy(ctx context.Context, vacancy *models.Vaca
 	vacancy.CreatedAt = now
 	vacancy.UpdatedAt = now
 
-	if err := s.vacancyRepository.CreateVacancy(ctx, vacancy); err != nil {
-		return nil, fmt.Errorf("failed to create vacancy: %w", err)
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.vacancyRepository.CreateVacancy(ctx, vacancy); err != nil {
+			return fmt.Errorf("failed to create vacancy: %w", err)
+		}
+
+		if len(vacancy.Tags) == 0 {
+			return nil
+		}
+
+		if _, err := s.notificationRepository.EnqueueNewVacancyNotifications(ctx, vacancy.VacansieID, now); err != nil {
+			return fmt.Errorf("failed to enqueue vacancy notifications: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return vacancy, nil
@@ -51,8 +85,12 @@ func (s *VacancyService) GetEmployerVacancies(ctx context.Context, employerID uu
 	return vacancyList, nil
 }
 
-func (s *VacancyService) GetVacancyList(ctx context.Context) (*models.VacancyList, error) {
-	vacancyList, err := s.vacancyRepository.GetVacancyList(ctx)
+func (s *VacancyService) GetVacancyList(ctx context.Context, filter *models.VacancyFilter, pagination models.Pagination) (*models.VacancyList, error) {
+	if filter != nil && filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		return nil, ErrInvalidDateRange
+	}
+
+	vacancyList, err := s.vacancyRepository.GetVacancyList(ctx, filter, pagination)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get vacancies list: %w", err)
 	}
@@ -60,6 +98,24 @@ func (s *VacancyService) GetVacancyList(ctx context.Context) (*models.VacancyLis
 	return vacancyList, nil
 }
 
+func (s *VacancyService) SearchVacancies(ctx context.Context, query string, filter *models.VacancyFilter, pagination models.Pagination) (*models.VacancySearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	if filter != nil && filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		return nil, ErrInvalidDateRange
+	}
+
+	result, err := s.vacancyRepository.SearchVacancies(ctx, query, filter, pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search vacancies: %w", err)
+	}
+
+	return result, nil
+}
+
 func (s *VacancyService) UpdateVacancy(ctx context.Context, req *models.VacancyUpdateRequest, id uuid.UUID) error {
 	getVacancy, err := s.vacancyRepository.GetVacancy(ctx, id)
 	if err != nil {
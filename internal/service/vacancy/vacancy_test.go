@@ -0,0 +1,64 @@
+package vacancy_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	"jobot/internal/service/models"
+	. "jobot/internal/service/vacancy"
+)
+
+type vacancyRepositoryStub struct {
+	repository.VacancyRepository
+	queries []string
+}
+
+func (r *vacancyRepositoryStub) SearchVacancies(_ context.Context, query string, _ *models.VacancyFilter, _ models.Pagination) (*models.VacancySearchResult, error) {
+	r.queries = append(r.queries, query)
+
+	return &models.VacancySearchResult{}, nil
+}
+
+func TestSearchVacancies(t *testing.T) {
+	t.Parallel()
+
+	from, to := time.Now(), time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		query  string
+		filter *models.VacancyFilter
+		err    error
+		search string
+	}{
+		{name: "query is trimmed", query: "  golang  ", search: "golang"},
+		{name: "empty query", query: "", err: ErrEmptySearchQuery},
+		{name: "whitespace query", query: " \t\n ", err: ErrEmptySearchQuery},
+		{name: "reversed dates", query: "golang", filter: &models.VacancyFilter{CreatedFrom: &from, CreatedTo: &to}, err: ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vacancies := &vacancyRepositoryStub{}
+			service := NewVacancyService(vacancies, nil, nil)
+
+			_, err := service.SearchVacancies(context.Background(), tt.query, tt.filter, models.Pagination{})
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				assert.Empty(t, vacancies.queries)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.search}, vacancies.queries)
+		})
+	}
+}
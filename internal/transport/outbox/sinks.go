@@ -0,0 +1,89 @@
+package outbox
+
+import (
+	"context"
+
+	"jobot/internal/service/models"
+
+	"go.uber.org/zap"
+)
+
+// LogSinkName - имя приемника лога в настройках и в outbox
+const LogSinkName = "log"
+
+// LogSink пишет события в лог, принимает их всегда
+type LogSink struct {
+	logger *zap.Logger
+}
+
+func NewLogSink(logger *zap.Logger) *LogSink {
+	return &LogSink{logger: logger}
+}
+
+func (s *LogSink) Name() string {
+	return LogSinkName
+}
+
+func (s *LogSink) Publish(_ context.Context, event *models.OutboxEvent) error {
+	s.logger.Info("outbox event",
+		zap.String("event_id", event.ID.String()),
+		zap.String("type", event.Type),
+		zap.String("aggregate_id", event.AggregateID.String()),
+		zap.ByteString("payload", event.Payload),
+	)
+
+	return nil
+}
+
+// ChannelSink передает события подписчику внутри процесса (боту, индексатору поиска) через канал.
+// Событие принято, когда подписчик обработал его и вызвал Done без ошибки: если процесс остановится
+// раньше, событие будет передано снова, поэтому подписчик должен отсекать повторы по event_id.
+type ChannelSink struct {
+	name       string
+	deliveries chan *Delivery
+}
+
+// Delivery - событие для подписчика ChannelSink. Подписчик обязан вызвать Done ровно один раз.
+type Delivery struct {
+	Event models.OutboxEvent
+	done  chan error
+}
+
+// Done сообщает результат обработки события: ошибка означает, что событие нужно передать повторно
+func (d *Delivery) Done(err error) {
+	d.done <- err
+}
+
+func NewChannelSink(name string) *ChannelSink {
+	return &ChannelSink{
+		name:       name,
+		deliveries: make(chan *Delivery),
+	}
+}
+
+func (s *ChannelSink) Name() string {
+	return s.name
+}
+
+// Publish ждет, пока подписчик заберет и обработает событие, или отмены ctx
+func (s *ChannelSink) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	delivery := &Delivery{Event: *event, done: make(chan error, 1)}
+
+	select {
+	case s.deliveries <- delivery:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-delivery.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Events - канал событий для подписчика
+func (s *ChannelSink) Events() <-chan *Delivery {
+	return s.deliveries
+}
@@ -0,0 +1,75 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/service/models"
+	. "jobot/internal/transport/outbox"
+)
+
+func newEvent() *models.OutboxEvent {
+	return &models.OutboxEvent{
+		ID:            uuid.New(),
+		Type:          models.OutboxEventReactionCreated,
+		AggregateType: "reaction",
+		AggregateID:   uuid.New(),
+		Payload:       []byte(`{"type":"like"}`),
+		CreatedAt:     time.Now(),
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	event := newEvent()
+	status := http.StatusNoContent
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.OutboxEventReactionCreated, r.Header.Get(EventHeader))
+		assert.Equal(t, event.ID.String(), r.Header.Get(EventIDHeader))
+		assert.Equal(t, Sign("secret", body), r.Header.Get(SignatureHeader))
+		assert.Contains(t, string(body), `"payload":{"type":"like"}`)
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, "secret", time.Second)
+	require.NoError(t, sink.Publish(context.Background(), event))
+
+	status = http.StatusServiceUnavailable
+	assert.ErrorContains(t, sink.Publish(context.Background(), event), "503")
+}
+
+func TestChannelSink(t *testing.T) {
+	sink := NewChannelSink("search")
+	event := newEvent()
+
+	go func() {
+		delivery := <-sink.Events()
+		delivery.Done(errors.New("index is not ready"))
+
+		delivery = <-sink.Events()
+		assert.Equal(t, event.ID, delivery.Event.ID)
+		delivery.Done(nil)
+	}()
+
+	assert.ErrorContains(t, sink.Publish(context.Background(), event), "index is not ready")
+	assert.NoError(t, sink.Publish(context.Background(), event))
+
+	// Без подписчика Publish ждет до отмены ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, sink.Publish(ctx, event), context.DeadlineExceeded)
+}
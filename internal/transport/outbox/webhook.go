@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"jobot/internal/service/models"
+)
+
+// Заголовки запроса webhook
+const (
+	EventHeader     = "X-Jobot-Event"
+	EventIDHeader   = "X-Jobot-Event-ID"
+	SignatureHeader = "X-Jobot-Signature"
+)
+
+// WebhookSinkName - имя приемника webhook в настройках и в outbox
+const WebhookSinkName = "webhook"
+
+// WebhookSink отправляет события POST запросом с JSON события на url.
+// Событие принято, если получатель ответил 2xx. Если задан secret, тело подписывается
+// HMAC-SHA256 и подпись передается в SignatureHeader как "sha256=<hex>".
+type WebhookSink struct {
+	url        string
+	secret     string
+	httpClient *http.Client
+}
+
+func NewWebhookSink(url, secret string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:        url,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return WebhookSinkName
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(EventIDHeader, event.ID.String())
+	if s.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(s.secret, body))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Соединение переиспользуется только после чтения тела
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign возвращает подпись тела body для SignatureHeader. Получатель проверяет ее тем же secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
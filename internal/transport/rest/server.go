@@ -15,9 +15,12 @@ import (
 
 const readHeaderTimeoutSeconds = 5
 
+// ConfigHTTPServer - настройки HTTP сервера
+// Webhooks - обработчики входящих webhook по путям (например, Telegram бота), доступны без аутентификации API
 type ConfigHTTPServer struct {
-	Port string
-	Host string
+	Port     string
+	Host     string
+	Webhooks map[string]http.Handler
 }
 
 /*
@@ -61,6 +64,11 @@ func CreateHTTPServerWithChi(ctx context.Context, cfg *ConfigHTTPServer, control
 		})
 	})
 
+	// Incoming webhooks authenticate requests themselves
+	for path, handler := range cfg.Webhooks {
+		r.Method(http.MethodPost, path, handler)
+	}
+
 	// Swagger documentation
 	r.Get("/api/docs", func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, "api/swagger-ui.html")
@@ -76,72 +84,140 @@ func CreateHTTPServerWithChi(ctx context.Context, cfg *ConfigHTTPServer, control
 
 	// API routes
 	r.Route("/api", func(r chi.Router) {
-		// User routes
-		r.Route("/users", func(r chi.Router) {
-			r.Post("/", controller.UserController.CreateUser)
-
-			r.Route("/{UserID}", func(r chi.Router) {
-				r.Get("/profile", controller.UserController.GetUserProfile)
-				r.Get("/employee", controller.EmployeeController.GetEmployeeByUserID)
-				r.Get("/employer", controller.EmployerController.GetEmployerByUserID)
-				r.Put("/", controller.UserController.UpdateUser)
-				r.Get("/", controller.UserController.GetUser)
-				r.Delete("/", controller.UserController.DeleteUser)
-			})
+		// Auth routes (public)
+		r.Route("/auth", func(r chi.Router) {
+			r.Post("/telegram/widget", controller.AuthController.LoginTelegramWidget)
+			r.Post("/telegram/webapp", controller.AuthController.LoginTelegramWebApp)
+			r.Post("/refresh", controller.AuthController.RefreshToken)
+			r.With(controller.AuthController.Authenticate).Get("/me", controller.AuthController.GetCurrentUser)
 		})
 
-		// Employee routes
-		r.Route("/employees", func(r chi.Router) {
-			r.Post("/", controller.EmployeeController.CreateEmployee)
+		// Routes below require an access token or an API key, the user is available via auth.UserFromContext
+		r.Group(func(r chi.Router) {
+			r.Use(controller.AuthController.Authenticate)
+
+			// Onboarding: user and role profile in one request
+			r.Post("/onboarding", controller.UserController.Onboard)
+
+			// User routes
+			r.Route("/users", func(r chi.Router) {
+				r.Post("/", controller.UserController.CreateUser)
+				r.Get("/by-telegram/{ChatID}", controller.UserController.GetUserProfileByTgChatID)
+				r.Get("/by-username/{UserName}", controller.UserController.GetUserProfileByTgUserName)
+
+				r.Route("/{UserID}", func(r chi.Router) {
+					r.Get("/profile", controller.UserController.GetUserProfile)
+					r.Post("/role", controller.UserController.ChangeUserRole)
+					r.Get("/employee", controller.EmployeeController.GetEmployeeByUserID)
+					r.Get("/employer", controller.EmployerController.GetEmployerByUserID)
+					r.Get("/notification-preferences", controller.NotificationController.GetNotificationPreferences)
+					r.Put("/notification-preferences", controller.NotificationController.UpdateNotificationPreferences)
+					r.Put("/", controller.UserController.UpdateUser)
+					r.Get("/", controller.UserController.GetUser)
+					r.Delete("/", controller.UserController.DeleteUser)
+				})
+			})
 
-			r.Route("/{EmployeeID}", func(r chi.Router) {
-				r.Get("/resume", controller.ResumeController.GetResumeByEmployeeID)
-				r.Get("/reactions", controller.ReactionController.GetEmployeeReactions)
-				r.Get("/", controller.EmployeeController.GetEmployee)
-				r.Put("/", controller.EmployeeController.UpdateEmployee)
-				r.Delete("/", controller.EmployeeController.DeleteEmployee)
+			// Employee routes
+			r.Route("/employees", func(r chi.Router) {
+				r.Post("/", controller.EmployeeController.CreateEmployee)
+
+				r.Route("/{EmployeeID}", func(r chi.Router) {
+					r.Get("/resume", controller.ResumeController.GetResumeByEmployeeID)
+					r.Get("/reactions", controller.ReactionController.GetEmployeeReactions)
+					r.Get("/feed", controller.FeedController.GetEmployeeFeed)
+					r.Get("/matches", controller.ReactionController.GetEmployeeMatches)
+					r.Get("/applications", controller.ApplicationController.GetEmployeeApplications)
+					r.Get("/", controller.EmployeeController.GetEmployee)
+					r.Put("/", controller.EmployeeController.UpdateEmployee)
+					r.Delete("/", controller.EmployeeController.DeleteEmployee)
+				})
 			})
-		})
 
-		// Resume routes
-		r.Route("/resumes", func(r chi.Router) {
-			r.Post("/", controller.ResumeController.CreateResume)
+			// Resume routes
+			r.Route("/resumes", func(r chi.Router) {
+				r.Post("/", controller.ResumeController.CreateResume)
 
-			r.Route("/{ResumeID}", func(r chi.Router) {
-				r.Get("/", controller.ResumeController.GetResume)
-				r.Put("/", controller.ResumeController.UpdateResume)
-				r.Delete("/", controller.ResumeController.DeleteResume)
+				r.Route("/{ResumeID}", func(r chi.Router) {
+					r.Get("/", controller.ResumeController.GetResume)
+					r.Put("/", controller.ResumeController.UpdateResume)
+					r.Delete("/", controller.ResumeController.DeleteResume)
+				})
 			})
-		})
 
-		// Employer routes
-		r.Route("/employers", func(r chi.Router) {
-			r.Post("/", controller.EmployerController.CreateEmployer)
+			// Employer routes
+			r.Route("/employers", func(r chi.Router) {
+				r.Post("/", controller.EmployerController.CreateEmployer)
+
+				r.Route("/{EmployerID}", func(r chi.Router) {
+					r.Get("/", controller.EmployerController.GetEmployer)
+					r.Get("/vacancies", controller.VacancyController.GetEmployerVacancies)
+					r.Get("/reactions", controller.ReactionController.GetEmployerReactions)
+					r.Get("/matches", controller.ReactionController.GetEmployerMatches)
+					r.Get("/applications", controller.ApplicationController.GetEmployerApplications)
+					r.Put("/", controller.EmployerController.UpdateEmployer)
+					r.Delete("/", controller.EmployerController.DeleteEmployer)
+				})
+			})
+
+			// Job posting routes (vacancies)
+			r.Route("/vacancies", func(r chi.Router) {
+				r.Post("/", controller.VacancyController.CreateVacancy)
+				r.Get("/", controller.VacancyController.GetVacancyList)
+				r.Get("/search", controller.VacancyController.SearchVacancies)
+
+				r.Route("/{VacancyID}", func(r chi.Router) {
+					r.Get("/", controller.VacancyController.GetVacancy)
+					r.Get("/candidates", controller.FeedController.GetVacancyCandidates)
+					r.Get("/reactions", controller.ReactionController.GetVacancyReactions)
+					r.Put("/", controller.VacancyController.UpdateVacancy)
+					r.Delete("/", controller.VacancyController.DeleteVacancy)
+				})
 
-			r.Route("/{EmployerID}", func(r chi.Router) {
-				r.Get("/", controller.EmployerController.GetEmployer)
-				r.Get("/vacancies", controller.VacancyController.GetEmployerVacancies)
-				r.Put("/", controller.EmployerController.UpdateEmployer)
-				r.Delete("/", controller.EmployerController.DeleteEmployer)
 			})
-		})
 
-		// Job posting routes (vacancies)
-		r.Route("/vacancies", func(r chi.Router) {
-			r.Post("/", controller.VacancyController.CreateVacancy)
-			r.Get("/", controller.VacancyController.GetVacancyList)
+			// Reaction routes
+			r.Route("/reactions", func(r chi.Router) {
+				r.Post("/", controller.ReactionController.CreateReaction)
 
-			r.Route("/{VacansyID}", func(r chi.Router) {
-				r.Get("/", controller.VacancyController.GetVacancy)
-				r.Put("/", controller.VacancyController.UpdateVacancy)
-				r.Delete("/", controller.VacancyController.DeleteVacancy)
+				r.Route("/{ReactionID}", func(r chi.Router) {
+					r.Get("/", controller.ReactionController.GetReaction)
+					r.Put("/", controller.ReactionController.UpdateReaction)
+					r.Delete("/", controller.ReactionController.DeleteReaction)
+				})
 			})
 
-		})
+			// Application routes (hiring pipeline)
+			r.Route("/applications", func(r chi.Router) {
+				r.Post("/", controller.ApplicationController.CreateApplication)
+
+				r.Route("/{ApplicationID}", func(r chi.Router) {
+					r.Get("/", controller.ApplicationController.GetApplication)
+					r.Post("/transitions", controller.ApplicationController.TransitApplication)
+				})
+			})
+
+			// API key routes (service credentials, admin only)
+			r.Route("/api-keys", func(r chi.Router) {
+				r.Post("/", controller.APIKeyController.CreateAPIKey)
+				r.Get("/", controller.APIKeyController.GetAPIKeys)
 
-		// Reaction routes
-		r.Route("/reactions", func(r chi.Router) {
-			r.Post("/", controller.ReactionController.CreateReaction)
+				r.Route("/{ApiKeyID}", func(r chi.Router) {
+					r.Post("/rotate", controller.APIKeyController.RotateAPIKey)
+					r.Delete("/", controller.APIKeyController.RevokeAPIKey)
+				})
+			})
+
+			// Employer reaction routes (employer decisions on candidates)
+			r.Route("/employer-reactions", func(r chi.Router) {
+				r.Post("/", controller.ReactionController.CreateEmployerReaction)
+
+				r.Route("/{EmployerReactionID}", func(r chi.Router) {
+					r.Get("/", controller.ReactionController.GetEmployerReaction)
+					r.Put("/", controller.ReactionController.UpdateEmployerReaction)
+					r.Delete("/", controller.ReactionController.DeleteEmployerReaction)
+				})
+			})
 		})
 	})
 
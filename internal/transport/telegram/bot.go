@@ -0,0 +1,253 @@
+package telegram
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"jobot/internal/service"
+	"jobot/internal/service/auth"
+	"jobot/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Режимы получения обновлений
+const (
+	ModePolling = "polling"
+	ModeWebhook = "webhook"
+)
+
+// SecretTokenHeader - заголовок, в котором Telegram передает secret_token webhook
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// Паузы между повторами getUpdates после ошибки
+const (
+	minRetryDelay = time.Second
+	maxRetryDelay = 30 * time.Second
+)
+
+var (
+	ErrUnknownMode          = errors.New("unknown telegram bot mode")
+	ErrWebhookNotConfigured = errors.New("webhook mode requires webhook url and secret")
+)
+
+// Config - настройки бота
+// PollTimeout - сколько getUpdates ждет новые обновления в режиме polling.
+// WebhookURL - публичный адрес, на который Telegram доставляет обновления в режиме webhook,
+// WebhookSecret - значение заголовка SecretTokenHeader, запросы без него отклоняются.
+type Config struct {
+	Mode          string
+	PollTimeout   time.Duration
+	WebhookURL    string
+	WebhookSecret string
+}
+
+// Services - сервисы, через которые бот выполняет действия пользователя
+type Services struct {
+	User     service.UserService
+	Employee service.EmployeeService
+	Resume   service.ResumeService
+	Vacancy  service.VacancyService
+	Reaction service.ReactionService
+	Feed     service.FeedService
+	Access   service.AccessService
+
+	Conversation service.ConversationService
+	Notification service.NotificationService
+}
+
+// Bot - Telegram транспорт: принимает обновления Bot API и выполняет их через сервисы.
+// Пользователь определяется по tg_chat_id, поэтому бот работает с правами самого пользователя.
+type Bot struct {
+	client   *Client
+	services Services
+	config   Config
+}
+
+func NewBot(client *Client, services Services, config Config) (*Bot, error) {
+	switch config.Mode {
+	case ModePolling:
+	case ModeWebhook:
+		if config.WebhookURL == "" || config.WebhookSecret == "" {
+			return nil, ErrWebhookNotConfigured
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMode, config.Mode)
+	}
+
+	return &Bot{client: client, services: services, config: config}, nil
+}
+
+// Start запускает получение обновлений в режиме из конфигурации.
+// В режиме polling блокируется до отмены ctx, в режиме webhook регистрирует WebhookURL и возвращается:
+// обновления приходят в WebhookHandler.
+func (b *Bot) Start(ctx context.Context) error {
+	if b.config.Mode == ModeWebhook {
+		if err := b.client.SetWebhook(ctx, b.config.WebhookURL, b.config.WebhookSecret); err != nil {
+			return fmt.Errorf("failed to set webhook: %w", err)
+		}
+
+		logger.FromContext(ctx).Info("Telegram webhook registered")
+
+		return nil
+	}
+
+	return b.Poll(ctx)
+}
+
+// Poll получает обновления через getUpdates и обрабатывает их по очереди, пока ctx не отменен.
+// Ошибки Bot API не останавливают бота: запрос повторяется с растущей паузой.
+func (b *Bot) Poll(ctx context.Context) error {
+	log := logger.FromContext(ctx).Named("telegram_polling")
+
+	if err := b.client.DeleteWebhook(ctx); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	log.Info("Telegram polling started")
+
+	var offset int64
+	delay := minRetryDelay
+
+	for {
+		updates, err := b.client.GetUpdates(ctx, offset, b.config.PollTimeout)
+		if ctx.Err() != nil {
+			log.Info("Telegram polling stopped")
+
+			return nil
+		}
+
+		if err != nil {
+			log.Error("Failed to get telegram updates", zap.Error(err), zap.Duration("retry_in", delay))
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(delay):
+			}
+
+			delay = min(delay*2, maxRetryDelay)
+
+			continue
+		}
+
+		delay = minRetryDelay
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+
+			b.HandleUpdate(ctx, &update)
+		}
+	}
+}
+
+// WebhookHandler принимает обновления от Telegram в режиме webhook.
+// Ошибки обработки логируются, а Telegram получает 200, чтобы не повторять доставку.
+func (b *Bot) WebhookHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := r.Header.Get(SecretTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(b.config.WebhookSecret)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		update := &Update{}
+		if err := json.NewDecoder(r.Body).Decode(update); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		b.HandleUpdate(r.Context(), update)
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// HandleUpdate обрабатывает одно обновление. Ошибки логируются, пользователь получает сообщение о сбое;
+// деактивированному пользователю вместо этого сообщается о блокировке аккаунта.
+func (b *Bot) HandleUpdate(ctx context.Context, update *Update) {
+	log := logger.FromContext(ctx).Named("telegram").With(zap.Int64("update_id", update.UpdateID))
+	ctx = logger.ContextWithLogger(ctx, log)
+
+	if !fromPrivateChat(update) {
+		log.Debug("Skipping update from non-private chat")
+
+		return
+	}
+
+	var (
+		chatID int64
+		err    error
+	)
+
+	switch {
+	case update.CallbackQuery != nil:
+		chatID = update.CallbackQuery.From.ID
+		err = b.handleCallback(ctx, update.CallbackQuery)
+	case update.Message != nil:
+		chatID = update.Message.Chat.ID
+		err = b.handleMessage(ctx, update.Message)
+	default:
+		return
+	}
+
+	if err == nil {
+		return
+	}
+
+	if errors.Is(err, auth.ErrUserInactive) {
+		if sendErr := b.reply(ctx, chatID, textUserInactive, nil); sendErr != nil {
+			log.Error("Failed to send inactive user message", zap.Error(sendErr))
+		}
+
+		return
+	}
+
+	log.Error("Failed to handle telegram update", zap.Error(err))
+
+	if sendErr := b.reply(ctx, chatID, textInternalError, nil); sendErr != nil {
+		log.Error("Failed to send error message", zap.Error(sendErr))
+	}
+}
+
+// fromPrivateChat проверяет, что обновление пришло из личного чата. Профиль пользователя и диалог
+// хранятся по tg_chat_id, а в группе Chat.ID общий для всех участников и не совпадает с From.ID
+func fromPrivateChat(update *Update) bool {
+	var (
+		message *Message
+		fromID  int64
+	)
+
+	switch {
+	case update.CallbackQuery != nil:
+		// Кнопки без сообщения (inline режим) привязаны только к пользователю
+		if update.CallbackQuery.Message == nil {
+			return true
+		}
+
+		message, fromID = update.CallbackQuery.Message, update.CallbackQuery.From.ID
+	case update.Message != nil:
+		message = update.Message
+		if message.From == nil {
+			return message.Chat.Type == ChatTypePrivate
+		}
+
+		fromID = message.From.ID
+	default:
+		return true
+	}
+
+	return message.Chat.Type == ChatTypePrivate && message.Chat.ID == fromID
+}
+
+// reply отправляет текст в чат с необязательной inline клавиатурой
+func (b *Bot) reply(ctx context.Context, chatID int64, text string, keyboard *InlineKeyboardMarkup) error {
+	return b.client.SendMessage(ctx, &SendMessage{ChatID: chatID, Text: text, ReplyMarkup: keyboard})
+}
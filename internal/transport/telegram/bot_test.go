@@ -0,0 +1,631 @@
+package telegram_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jobot/internal/repository"
+	conversationRepo "jobot/internal/repository/conversation"
+	resumeRepo "jobot/internal/repository/resume"
+	userRepo "jobot/internal/repository/user"
+	"jobot/internal/service"
+	accessSrv "jobot/internal/service/access"
+	conversationSrv "jobot/internal/service/conversation"
+	"jobot/internal/service/models"
+	notificationSrv "jobot/internal/service/notification"
+	. "jobot/internal/transport/telegram"
+)
+
+// fakeBotAPI имитирует Telegram Bot API: отдает updates в getUpdates и запоминает вызовы остальных методов
+type fakeBotAPI struct {
+	mu        sync.Mutex
+	updates   []Update
+	offsets   []int64
+	messages  []SendMessage
+	answers   []string
+	documents []string
+	calls     []string
+}
+
+func (f *fakeBotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	f.calls = append(f.calls, method)
+
+	var result any = true
+
+	switch method {
+	case "getUpdates":
+		var params struct {
+			Offset int64 `json:"offset"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		f.offsets = append(f.offsets, params.Offset)
+
+		pending := make([]Update, 0)
+		for _, update := range f.updates {
+			if update.UpdateID >= params.Offset {
+				pending = append(pending, update)
+			}
+		}
+		result = pending
+	case "sendMessage":
+		var message SendMessage
+		_ = json.NewDecoder(r.Body).Decode(&message)
+		f.messages = append(f.messages, message)
+	case "answerCallbackQuery":
+		var params struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		f.answers = append(f.answers, params.Text)
+	case "sendDocument":
+		var params struct {
+			Document string `json:"document"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		f.documents = append(f.documents, params.Document)
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+func (f *fakeBotAPI) sent() []SendMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]SendMessage(nil), f.messages...)
+}
+
+type userServiceStub struct {
+	service.UserService
+	profiles map[string]*models.UserProfile
+	onboards []*models.UserProfile
+}
+
+func (s *userServiceStub) GetUserProfileByTgChatID(_ context.Context, tgChatID string) (*models.UserProfile, error) {
+	profile, ok := s.profiles[tgChatID]
+	if !ok {
+		return nil, userRepo.ErrUserNotFound
+	}
+
+	return profile, nil
+}
+
+func (s *userServiceStub) Onboard(_ context.Context, profile *models.UserProfile) (*models.UserProfile, bool, error) {
+	s.onboards = append(s.onboards, profile)
+	profile.User.IsActive = true
+	s.profiles[profile.User.TgChatID] = profile
+
+	return profile, true, nil
+}
+
+type vacancyServiceStub struct {
+	service.VacancyService
+	vacancies map[uuid.UUID]*models.Vacancy
+	created   []*models.Vacancy
+}
+
+func (s *vacancyServiceStub) CreateVacancy(_ context.Context, vacancy *models.Vacancy) (*models.Vacancy, error) {
+	s.created = append(s.created, vacancy)
+
+	return vacancy, nil
+}
+
+func (s *vacancyServiceStub) GetVacancyByID(_ context.Context, id uuid.UUID) (*models.Vacancy, error) {
+	return s.vacancies[id], nil
+}
+
+type reactionServiceStub struct {
+	service.ReactionService
+	reactions []*models.Reaction
+}
+
+func (s *reactionServiceStub) CreateReaction(_ context.Context, reaction *models.Reaction) (*models.Reaction, error) {
+	s.reactions = append(s.reactions, reaction)
+
+	return reaction, nil
+}
+
+func (s *reactionServiceStub) GetEmployeeReactions(_ context.Context, employeeID uuid.UUID, filter *models.ReactionFilter) (*models.EmployeeReactionList, error) {
+	list := &models.EmployeeReactionList{EmployeeID: employeeID}
+	for _, reaction := range s.reactions {
+		if reaction.EmployeeID == employeeID && (filter.Type == nil || reaction.Type == *filter.Type) {
+			list.Reactions = append(list.Reactions, *reaction)
+		}
+	}
+
+	return list, nil
+}
+
+// employerRepositoryStub отвечает на проверку настоящего правила доступа к резюме (access.EmployerHasCandidate)
+// по данным заглушек сервисов: лайк или отклик кандидата на вакансию работодателя
+type employerRepositoryStub struct {
+	repository.EmployerRepository
+	reactions    *reactionServiceStub
+	vacancies    map[uuid.UUID]*models.Vacancy
+	applications []models.Application
+}
+
+func (r *employerRepositoryStub) HasCandidate(_ context.Context, employerID, employeeID uuid.UUID) (bool, error) {
+	owned := func(vacancyID uuid.UUID) bool {
+		vacancy, ok := r.vacancies[vacancyID]
+		return ok && vacancy.EmployerID == employerID
+	}
+
+	for _, reaction := range r.reactions.reactions {
+		if reaction.EmployeeID == employeeID && reaction.Type == models.ReactionTypeLike && owned(reaction.VacancyID) {
+			return true, nil
+		}
+	}
+
+	for _, application := range r.applications {
+		if application.EmployeeID == employeeID && owned(application.VacancyID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+type resumeServiceStub struct {
+	service.ResumeService
+	resumes map[uuid.UUID]*models.Resume
+}
+
+func (s *resumeServiceStub) GetResumeByEmployeeID(_ context.Context, employeeID uuid.UUID) (*models.Resume, error) {
+	resume, ok := s.resumes[employeeID]
+	if !ok {
+		return nil, resumeRepo.ErrResumeNotFound
+	}
+
+	return resume, nil
+}
+
+// feedServiceStub отдает вакансии, которые сотрудник еще не оценил
+type feedServiceStub struct {
+	service.FeedService
+	vacancies []models.Vacancy
+	reactions *reactionServiceStub
+}
+
+func (s *feedServiceStub) GetEmployeeFeed(_ context.Context, employeeID uuid.UUID, _ models.Pagination) (*models.VacancyFeed, error) {
+	feed := &models.VacancyFeed{EmployeeID: employeeID}
+
+	for _, vacancy := range s.vacancies {
+		rated := false
+		for _, reaction := range s.reactions.reactions {
+			rated = rated || reaction.VacancyID == vacancy.VacansieID
+		}
+
+		if !rated {
+			feed.Items = append(feed.Items, models.VacancyFeedItem{Vacancy: vacancy})
+		}
+	}
+
+	return feed, nil
+}
+
+type conversationRepositoryStub struct {
+	repository.ConversationRepository
+	conversations map[string]models.Conversation
+}
+
+func (r *conversationRepositoryStub) GetConversation(_ context.Context, tgChatID string) (*models.Conversation, error) {
+	conversation, ok := r.conversations[tgChatID]
+	if !ok {
+		return nil, conversationRepo.ErrConversationNotFound
+	}
+
+	draft := make(map[string]string, len(conversation.Draft))
+	for field, value := range conversation.Draft {
+		draft[field] = value
+	}
+	conversation.Draft = draft
+
+	return &conversation, nil
+}
+
+func (r *conversationRepositoryStub) SaveConversation(_ context.Context, conversation *models.Conversation) error {
+	r.conversations[conversation.TgChatID] = *conversation
+
+	return nil
+}
+
+func (r *conversationRepositoryStub) DeleteConversation(_ context.Context, tgChatID string) error {
+	if _, ok := r.conversations[tgChatID]; !ok {
+		return conversationRepo.ErrConversationNotFound
+	}
+
+	delete(r.conversations, tgChatID)
+
+	return nil
+}
+
+type testBot struct {
+	bot        *Bot
+	api        *fakeBotAPI
+	users      *userServiceStub
+	reactions  *reactionServiceStub
+	resumes    *resumeServiceStub
+	vacancies  []models.Vacancy
+	created    *vacancyServiceStub
+	dialogs    *conversationRepositoryStub
+	candidates *employerRepositoryStub
+}
+
+func newTestBot(t *testing.T, config Config) *testBot {
+	t.Helper()
+
+	api := &fakeBotAPI{}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	vacancies := []models.Vacancy{
+		{VacansieID: uuid.New(), Title: "Go developer", Description: "API", Location: "Remote", Salary: "300k", Tags: []string{"go"}},
+		{VacansieID: uuid.New(), Title: "SRE", Description: "Infra", Location: "Moscow", Salary: "350k"},
+	}
+	byID := make(map[uuid.UUID]*models.Vacancy)
+	for i := range vacancies {
+		byID[vacancies[i].VacansieID] = &vacancies[i]
+	}
+
+	users := &userServiceStub{profiles: make(map[string]*models.UserProfile)}
+	reactions := &reactionServiceStub{}
+	resumes := &resumeServiceStub{resumes: make(map[uuid.UUID]*models.Resume)}
+	vacancyService := &vacancyServiceStub{vacancies: byID}
+	dialogs := &conversationRepositoryStub{conversations: make(map[string]models.Conversation)}
+	candidates := &employerRepositoryStub{reactions: reactions, vacancies: byID}
+	access := accessSrv.NewAccessService(nil, candidates, nil, nil, nil, nil, nil)
+
+	bot, err := NewBot(NewClient(server.URL, "123:secret", server.Client()), Services{
+		User:     users,
+		Resume:   resumes,
+		Vacancy:  vacancyService,
+		Reaction: reactions,
+		Feed:     &feedServiceStub{vacancies: vacancies, reactions: reactions},
+		Access:   access,
+
+		Conversation: conversationSrv.NewConversationService(dialogs, time.Hour),
+	}, config)
+	require.NoError(t, err)
+
+	return &testBot{
+		bot:        bot,
+		api:        api,
+		users:      users,
+		reactions:  reactions,
+		resumes:    resumes,
+		vacancies:  vacancies,
+		created:    vacancyService,
+		dialogs:    dialogs,
+		candidates: candidates,
+	}
+}
+
+// send обрабатывает текстовое сообщение из чата chatID и возвращает ответ бота
+func (tb *testBot) send(t *testing.T, chatID int64, text string) string {
+	t.Helper()
+
+	before := len(tb.api.sent())
+	tb.bot.HandleUpdate(context.Background(), &Update{Message: &Message{
+		Chat: Chat{ID: chatID, Type: ChatTypePrivate},
+		From: &User{ID: chatID},
+		Text: text,
+	}})
+
+	messages := tb.api.sent()
+	require.Len(t, messages, before+1)
+
+	return messages[before].Text
+}
+
+func (tb *testBot) addEmployee(chatID int64) *models.Employee {
+	employee := &models.Employee{EmployeeID: uuid.New()}
+	tb.users.profiles[strconv.FormatInt(chatID, 10)] = &models.UserProfile{
+		User:     &models.User{Role: models.UserRoleEmployee, IsActive: true},
+		Employee: employee,
+	}
+
+	return employee
+}
+
+func TestNewBot(t *testing.T) {
+	_, err := NewBot(NewClient("", "", nil), Services{}, Config{Mode: "push"})
+	assert.ErrorIs(t, err, ErrUnknownMode)
+
+	_, err = NewBot(NewClient("", "", nil), Services{}, Config{Mode: ModeWebhook})
+	assert.ErrorIs(t, err, ErrWebhookNotConfigured)
+}
+
+func TestStartOnboardsEmployee(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModePolling})
+	ctx := context.Background()
+
+	tb.bot.HandleUpdate(ctx, &Update{UpdateID: 1, Message: &Message{
+		Chat: Chat{ID: 42, Type: ChatTypePrivate},
+		Text: "/start",
+	}})
+
+	messages := tb.api.sent()
+	require.Len(t, messages, 1)
+	assert.Equal(t, int64(42), messages[0].ChatID)
+	require.NotNil(t, messages[0].ReplyMarkup)
+	assert.Equal(t, "role:employee", messages[0].ReplyMarkup.InlineKeyboard[0][0].CallbackData)
+
+	tb.bot.HandleUpdate(ctx, &Update{UpdateID: 2, CallbackQuery: &CallbackQuery{
+		ID:   "cb",
+		From: User{ID: 42, Username: "alice"},
+		Data: "role:employee",
+	}})
+
+	require.Len(t, tb.users.onboards, 1)
+	onboarded := tb.users.onboards[0]
+	assert.Equal(t, "42", onboarded.User.TgChatID)
+	assert.Equal(t, "alice", onboarded.User.TgUserName)
+	assert.Equal(t, models.UserRoleEmployee, onboarded.User.Role)
+	assert.NotNil(t, onboarded.Employee)
+
+	assert.Len(t, tb.api.sent(), 2)
+	assert.Equal(t, []string{""}, tb.api.answers)
+}
+
+func TestReactionSendsNextVacancy(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModePolling})
+	employee := tb.addEmployee(42)
+	ctx := context.Background()
+
+	tb.bot.HandleUpdate(ctx, &Update{UpdateID: 1, Message: &Message{Chat: Chat{ID: 42, Type: ChatTypePrivate}, Text: "/vacancies"}})
+
+	messages := tb.api.sent()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0].Text, "Go developer")
+	like := messages[0].ReplyMarkup.InlineKeyboard[0][0].CallbackData
+	assert.Equal(t, "like:"+tb.vacancies[0].VacansieID.String(), like)
+
+	tb.bot.HandleUpdate(ctx, &Update{UpdateID: 2, CallbackQuery: &CallbackQuery{
+		ID:   "cb",
+		From: User{ID: 42},
+		Data: like,
+	}})
+
+	require.Len(t, tb.reactions.reactions, 1)
+	assert.Equal(t, employee.EmployeeID, tb.reactions.reactions[0].EmployeeID)
+	assert.Equal(t, tb.vacancies[0].VacansieID, tb.reactions.reactions[0].VacancyID)
+	assert.Equal(t, models.ReactionTypeLike, tb.reactions.reactions[0].Type)
+	require.Len(t, tb.api.answers, 1)
+	assert.NotEmpty(t, tb.api.answers[0])
+
+	messages = tb.api.sent()
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[1].Text, "SRE")
+}
+
+func TestCandidateResume(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModePolling})
+	employerID := uuid.New()
+	tb.vacancies[0].EmployerID = employerID
+	tb.users.profiles["7"] = &models.UserProfile{
+		User:     &models.User{Role: models.UserRoleEmployer, IsActive: true},
+		Employer: &models.Employer{EmployerID: employerID},
+	}
+
+	candidate := tb.addEmployee(42)
+	tb.reactions.reactions = append(tb.reactions.reactions, &models.Reaction{
+		EmployeeID: candidate.EmployeeID,
+		VacancyID:  tb.vacancies[0].VacansieID,
+		Type:       models.ReactionTypeLike,
+	})
+
+	// Кандидат без лайка вакансий работодателя
+	stranger := tb.addEmployee(43)
+	tb.resumes.resumes[stranger.EmployeeID] = &models.Resume{TgFileID: "stranger-file"}
+	assert.Contains(t, tb.send(t, 7, notificationSrv.ResumeCommand(stranger.EmployeeID)), "не найден")
+
+	assert.Contains(t, tb.send(t, 7, notificationSrv.ResumeCommand(candidate.EmployeeID)), "не загрузил")
+
+	tb.resumes.resumes[candidate.EmployeeID] = &models.Resume{TgFileID: "candidate-file"}
+	tb.bot.HandleUpdate(context.Background(), &Update{Message: &Message{
+		Chat: Chat{ID: 7, Type: ChatTypePrivate},
+		Text: notificationSrv.ResumeCommand(candidate.EmployeeID),
+	}})
+	assert.Equal(t, []string{"candidate-file"}, tb.api.documents)
+
+	// Кандидат откликнулся на вакансию работодателя без лайка
+	applicant := tb.addEmployee(44)
+	tb.resumes.resumes[applicant.EmployeeID] = &models.Resume{TgFileID: "applicant-file"}
+	tb.candidates.applications = append(tb.candidates.applications, models.Application{
+		EmployeeID: applicant.EmployeeID,
+		VacancyID:  tb.vacancies[0].VacansieID,
+	})
+	tb.bot.HandleUpdate(context.Background(), &Update{Message: &Message{
+		Chat: Chat{ID: 7, Type: ChatTypePrivate},
+		Text: notificationSrv.ResumeCommand(applicant.EmployeeID),
+	}})
+	assert.Equal(t, []string{"candidate-file", "applicant-file"}, tb.api.documents)
+
+	// Соискателю команда недоступна
+	assert.Contains(t, tb.send(t, 42, notificationSrv.ResumeCommand(candidate.EmployeeID)), "работодател")
+}
+
+func TestEmployeeCommandRequiresRegistration(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModePolling})
+
+	tb.bot.HandleUpdate(context.Background(), &Update{UpdateID: 1, Message: &Message{Chat: Chat{ID: 7, Type: ChatTypePrivate}, Text: "/tags go"}})
+
+	messages := tb.api.sent()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0].Text, "/start")
+}
+
+func TestInactiveUserIsRejected(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModePolling})
+	tb.addEmployee(42)
+	tb.users.profiles["42"].User.IsActive = false
+
+	assert.Contains(t, tb.send(t, 42, "/tags go"), "деактивирован")
+	assert.Contains(t, tb.send(t, 42, "/help"), "деактивирован")
+	assert.Empty(t, tb.reactions.reactions)
+}
+
+func TestNonPrivateChatsAreIgnored(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModePolling})
+	tb.addEmployee(42)
+	group := Chat{ID: -100, Type: "group"}
+
+	tests := []struct {
+		name   string
+		update *Update
+	}{
+		{
+			name:   "group message",
+			update: &Update{Message: &Message{Chat: group, From: &User{ID: 42}, Text: "/start"}},
+		},
+		{
+			name:   "supergroup message",
+			update: &Update{Message: &Message{Chat: Chat{ID: -200, Type: "supergroup"}, From: &User{ID: 42}, Text: "/tags go"}},
+		},
+		{
+			name:   "channel post without sender",
+			update: &Update{Message: &Message{Chat: Chat{ID: -300, Type: "channel"}, Text: "/start"}},
+		},
+		{
+			name:   "sender differs from private chat",
+			update: &Update{Message: &Message{Chat: Chat{ID: 42, Type: ChatTypePrivate}, From: &User{ID: 43}, Text: "/tags go"}},
+		},
+		{
+			name: "button under group message",
+			update: &Update{CallbackQuery: &CallbackQuery{
+				ID:      "cb",
+				From:    User{ID: 43},
+				Message: &Message{Chat: group},
+				Data:    "role:employee",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb.bot.HandleUpdate(context.Background(), tt.update)
+
+			assert.Empty(t, tb.api.sent())
+			assert.Empty(t, tb.api.answers)
+			assert.Empty(t, tb.users.onboards)
+		})
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModeWebhook, WebhookURL: "https://example.com/telegram/webhook", WebhookSecret: "s3cret"})
+	handler := tb.bot.WebhookHandler()
+
+	body := `{"update_id":1,"message":{"message_id":1,"chat":{"id":42,"type":"private"},"text":"/start"}}`
+
+	req := httptest.NewRequest(http.MethodPost, "/telegram/webhook", strings.NewReader(body))
+	req.Header.Set(SecretTokenHeader, "wrong")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, tb.api.sent())
+
+	req = httptest.NewRequest(http.MethodPost, "/telegram/webhook", strings.NewReader(body))
+	req.Header.Set(SecretTokenHeader, "s3cret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, tb.api.sent(), 1)
+}
+
+func TestPoll(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModePolling})
+	tb.api.updates = []Update{
+		{UpdateID: 10, Message: &Message{Chat: Chat{ID: 1, Type: ChatTypePrivate}, Text: "/start"}},
+		{UpdateID: 11, Message: &Message{Chat: Chat{ID: 2, Type: ChatTypePrivate}, Text: "/start"}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- tb.bot.Poll(ctx) }()
+
+	require.Eventually(t, func() bool {
+		tb.api.mu.Lock()
+		defer tb.api.mu.Unlock()
+
+		return len(tb.api.offsets) >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Poll did not stop after context cancel")
+	}
+
+	assert.Equal(t, "deleteWebhook", tb.api.calls[0])
+	assert.Equal(t, []int64{0, 12}, tb.api.offsets[:2])
+	assert.Len(t, tb.api.sent(), 2)
+}
+
+func TestVacancyDialog(t *testing.T) {
+	tb := newTestBot(t, Config{Mode: ModePolling})
+	employerID := uuid.New()
+	tb.users.profiles["42"] = &models.UserProfile{
+		User:     &models.User{Role: models.UserRoleEmployer, IsActive: true},
+		Employer: &models.Employer{EmployerID: employerID},
+	}
+
+	assert.Contains(t, tb.send(t, 42, "/newvacancy"), "Шаг 1 из 5")
+	assert.Contains(t, tb.send(t, 42, "Go developer"), "Шаг 2 из 5")
+	assert.Contains(t, tb.send(t, 42, "   "), "обязательно")
+	assert.Contains(t, tb.send(t, 42, "Write APIs"), "Шаг 3 из 5")
+
+	// Назад: показывается введенное значение
+	reply := tb.send(t, 42, "/back")
+	assert.Contains(t, reply, "Шаг 2 из 5")
+	assert.Contains(t, reply, "Write APIs")
+	assert.Contains(t, tb.send(t, 42, "Write and own APIs"), "Шаг 3 из 5")
+
+	// Диалог прерван по таймауту: черновик сохранен и продолжается командой /resume
+	stored := tb.dialogs.conversations["42"]
+	stored.ExpiresAt = time.Now().Add(-time.Minute)
+	tb.dialogs.conversations["42"] = stored
+
+	assert.Contains(t, tb.send(t, 42, "Remote"), "/resume")
+	assert.Contains(t, tb.send(t, 42, "/resume"), "Шаг 3 из 5")
+
+	// Повторный /newvacancy продолжает тот же черновик
+	assert.Contains(t, tb.send(t, 42, "/newvacancy"), "Шаг 3 из 5")
+	assert.Contains(t, tb.send(t, 42, "Remote"), "Шаг 4 из 5")
+	assert.Contains(t, tb.send(t, 42, "300k"), "/skip")
+	assert.Contains(t, tb.send(t, 42, "/skip"), "Go developer")
+
+	require.Len(t, tb.created.created, 1)
+	vacancy := tb.created.created[0]
+	assert.Equal(t, employerID, vacancy.EmployerID)
+	assert.Equal(t, "Go developer", vacancy.Title)
+	assert.Equal(t, "Write and own APIs", vacancy.Description)
+	assert.Equal(t, "Remote", vacancy.Location)
+	assert.Equal(t, "300k", vacancy.Salary)
+	assert.Empty(t, vacancy.Tags)
+	assert.Empty(t, tb.dialogs.conversations)
+
+	assert.Contains(t, tb.send(t, 42, "/cancel"), "Нет начатого диалога")
+}
@@ -0,0 +1,165 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL - адрес Telegram Bot API
+const DefaultAPIURL = "https://api.telegram.org"
+
+// APIError - ошибка, которую вернул Bot API (ok = false)
+type APIError struct {
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram bot api error %d: %s", e.Code, e.Description)
+}
+
+// Client - минимальный клиент Telegram Bot API: только методы, которые использует бот.
+// apiURL можно заменить адресом локального сервера, который имитирует Bot API (в тестах).
+type Client struct {
+	apiURL     string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(apiURL, token string, httpClient *http.Client) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		apiURL:     strings.TrimRight(apiURL, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// apiResponse - общий ответ Bot API
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+}
+
+// GetUpdates получает обновления начиная с offset, ожидая новые не дольше timeout (long polling)
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	params := map[string]any{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message", "callback_query"},
+	}
+
+	var updates []Update
+	if err := c.call(ctx, "getUpdates", params, &updates); err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// SendMessage отправляет сообщение в чат
+func (c *Client) SendMessage(ctx context.Context, message *SendMessage) error {
+	return c.call(ctx, "sendMessage", message, nil)
+}
+
+// SendDocument отправляет в чат файл, уже загруженный в Telegram, по его file_id
+func (c *Client) SendDocument(ctx context.Context, chatID int64, fileID, caption string) error {
+	params := map[string]any{
+		"chat_id":  chatID,
+		"document": fileID,
+		"caption":  caption,
+	}
+
+	return c.call(ctx, "sendDocument", params, nil)
+}
+
+// AnswerCallbackQuery отвечает на нажатие inline кнопки, text показывается всплывающим уведомлением
+func (c *Client) AnswerCallbackQuery(ctx context.Context, callbackQueryID, text string) error {
+	params := map[string]any{
+		"callback_query_id": callbackQueryID,
+		"text":              text,
+	}
+
+	return c.call(ctx, "answerCallbackQuery", params, nil)
+}
+
+// SetWebhook включает доставку обновлений на url, Telegram передает secret в заголовке SecretTokenHeader
+func (c *Client) SetWebhook(ctx context.Context, url, secret string) error {
+	params := map[string]any{
+		"url":             url,
+		"secret_token":    secret,
+		"allowed_updates": []string{"message", "callback_query"},
+	}
+
+	return c.call(ctx, "setWebhook", params, nil)
+}
+
+// DeleteWebhook выключает webhook, без этого getUpdates не работает
+func (c *Client) DeleteWebhook(ctx context.Context) error {
+	return c.call(ctx, "deleteWebhook", map[string]any{}, nil)
+}
+
+// call вызывает метод Bot API с JSON параметрами и декодирует result в result, если он не nil
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s params: %w", method, err)
+	}
+
+	url := fmt.Sprintf("%s/bot%s/%s", c.apiURL, c.token, method)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Ошибка net/http содержит URL вместе с токеном бота
+		return fmt.Errorf("failed to call %s: %w", method, redactToken(err, c.token))
+	}
+	defer resp.Body.Close()
+
+	var response apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode %s response (status %d): %w", method, resp.StatusCode, err)
+	}
+
+	if !response.OK {
+		return &APIError{Code: response.ErrorCode, Description: response.Description}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+
+	return nil
+}
+
+// redactToken убирает токен бота из текста ошибки
+func redactToken(err error, token string) error {
+	if token == "" || !strings.Contains(err.Error(), token) {
+		return err
+	}
+
+	return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), token, "<token>"))
+}
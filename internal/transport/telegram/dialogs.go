@@ -0,0 +1,242 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	conversationRepo "jobot/internal/repository/conversation"
+	conversationSrv "jobot/internal/service/conversation"
+	"jobot/internal/service/models"
+)
+
+// Сценарии многошаговых диалогов
+const (
+	flowEmployer = "employer"
+	flowVacancy  = "vacancy"
+)
+
+// Команды управления диалогом
+const (
+	commandBack   = "/back"
+	commandCancel = "/cancel"
+	commandSkip   = "/skip"
+	commandResume = "/resume"
+)
+
+// dialogStep - шаг диалога, ответ сохраняется в черновик под field
+type dialogStep struct {
+	field    string
+	prompt   string
+	optional bool
+}
+
+// dialogSteps - шаги сценариев по порядку. Поля черновика хранятся в БД,
+// поэтому переименование field ломает начатые черновики.
+var dialogSteps = map[string][]dialogStep{
+	flowEmployer: {
+		{field: "company_name", prompt: textPromptCompanyName},
+		{field: "company_description", prompt: textPromptCompanyDescription},
+		{field: "company_website", prompt: textPromptCompanyWebsite},
+		{field: "company_location", prompt: textPromptCompanyLocation},
+		{field: "company_size", prompt: textPromptCompanySize},
+	},
+	flowVacancy: {
+		{field: "title", prompt: textPromptVacancyTitle},
+		{field: "description", prompt: textPromptVacancyDescription},
+		{field: "location", prompt: textPromptVacancyLocation},
+		{field: "salary", prompt: textPromptVacancySalary},
+		{field: "tags", prompt: textPromptVacancyTags, optional: true},
+	},
+}
+
+// startDialog начинает диалог flow или продолжает черновик этого сценария
+func (b *Bot) startDialog(ctx context.Context, chatID int64, flow string) error {
+	conversation, resumed, err := b.services.Conversation.StartConversation(ctx, chatKey(chatID), flow)
+	if err != nil {
+		return fmt.Errorf("failed to start conversation: %w", err)
+	}
+
+	prefix := ""
+	if resumed {
+		prefix = textDialogResumed
+	}
+
+	return b.prompt(ctx, chatID, conversation, prefix)
+}
+
+// handleText обрабатывает текст без команды: ответ на шаг диалога или подсказку, если диалога нет
+func (b *Bot) handleText(ctx context.Context, message *Message, text string) error {
+	conversation, err := b.services.Conversation.GetConversation(ctx, chatKey(message.Chat.ID))
+	switch {
+	case errors.Is(err, conversationRepo.ErrConversationNotFound):
+		return b.handleHelp(ctx, message)
+	case errors.Is(err, conversationSrv.ErrConversationExpired):
+		return b.reply(ctx, message.Chat.ID, textDialogExpired, nil)
+	case err != nil:
+		return fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	return b.answerStep(ctx, message, conversation, text)
+}
+
+func (b *Bot) handleSkip(ctx context.Context, message *Message) error {
+	conversation, err := b.activeConversation(ctx, message.Chat.ID)
+	if conversation == nil || err != nil {
+		return err
+	}
+
+	return b.answerStep(ctx, message, conversation, "")
+}
+
+func (b *Bot) handleBack(ctx context.Context, message *Message) error {
+	conversation, err := b.activeConversation(ctx, message.Chat.ID)
+	if conversation == nil || err != nil {
+		return err
+	}
+
+	prefix := ""
+	err = b.services.Conversation.StepBack(ctx, conversation)
+	switch {
+	case errors.Is(err, conversationSrv.ErrFirstStep):
+		prefix = textDialogFirstStep
+	case err != nil:
+		return fmt.Errorf("failed to step back: %w", err)
+	}
+
+	return b.prompt(ctx, message.Chat.ID, conversation, prefix)
+}
+
+// handleCancel удаляет диалог с черновиком, в том числе прерванный по таймауту
+func (b *Bot) handleCancel(ctx context.Context, message *Message) error {
+	err := b.services.Conversation.DeleteConversation(ctx, chatKey(message.Chat.ID))
+	switch {
+	case errors.Is(err, conversationRepo.ErrConversationNotFound):
+		return b.reply(ctx, message.Chat.ID, textNoDialog, nil)
+	case err != nil:
+		return fmt.Errorf("failed to delete conversation: %w", err)
+	}
+
+	return b.reply(ctx, message.Chat.ID, textDialogCanceled, nil)
+}
+
+// handleResume продолжает диалог, прерванный по таймауту
+func (b *Bot) handleResume(ctx context.Context, message *Message) error {
+	conversation, err := b.services.Conversation.ResumeConversation(ctx, chatKey(message.Chat.ID))
+	switch {
+	case errors.Is(err, conversationRepo.ErrConversationNotFound):
+		return b.reply(ctx, message.Chat.ID, textNoDialog, nil)
+	case err != nil:
+		return fmt.Errorf("failed to resume conversation: %w", err)
+	}
+
+	return b.prompt(ctx, message.Chat.ID, conversation, textDialogResumed)
+}
+
+// activeConversation возвращает активный диалог чата.
+// Если диалога нет или он прерван по таймауту, отправляет подсказку и возвращает nil без ошибки.
+func (b *Bot) activeConversation(ctx context.Context, chatID int64) (*models.Conversation, error) {
+	conversation, err := b.services.Conversation.GetConversation(ctx, chatKey(chatID))
+	switch {
+	case errors.Is(err, conversationRepo.ErrConversationNotFound):
+		return nil, b.reply(ctx, chatID, textNoDialog, nil)
+	case errors.Is(err, conversationSrv.ErrConversationExpired):
+		return nil, b.reply(ctx, chatID, textDialogExpired, nil)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// answerStep принимает ответ на текущий шаг. После последнего шага черновик отправляется в сервисы
+// и диалог удаляется; если отправка не удалась, диалог остается на последнем шаге.
+func (b *Bot) answerStep(ctx context.Context, message *Message, conversation *models.Conversation, value string) error {
+	steps, ok := dialogSteps[conversation.Flow]
+	if !ok || conversation.Step >= len(steps) {
+		// Черновик сценария, которого больше нет
+		return b.handleCancel(ctx, message)
+	}
+
+	step := steps[conversation.Step]
+	if value == "" && !step.optional {
+		return b.prompt(ctx, message.Chat.ID, conversation, textDialogRequired)
+	}
+
+	if conversation.Step < len(steps)-1 {
+		if err := b.services.Conversation.AnswerStep(ctx, conversation, step.field, value); err != nil {
+			return fmt.Errorf("failed to save conversation step: %w", err)
+		}
+
+		return b.prompt(ctx, message.Chat.ID, conversation, "")
+	}
+
+	conversation.Draft[step.field] = value
+
+	if err := b.submitDialog(ctx, message, conversation); err != nil {
+		return err
+	}
+
+	err := b.services.Conversation.DeleteConversation(ctx, chatKey(message.Chat.ID))
+	if err != nil && !errors.Is(err, conversationRepo.ErrConversationNotFound) {
+		return fmt.Errorf("failed to delete conversation: %w", err)
+	}
+
+	return nil
+}
+
+// submitDialog выполняет действие сценария с заполненным черновиком
+func (b *Bot) submitDialog(ctx context.Context, message *Message, conversation *models.Conversation) error {
+	draft := conversation.Draft
+
+	switch conversation.Flow {
+	case flowEmployer:
+		return b.onboard(ctx, message.Chat.ID, message.From, &models.UserProfile{
+			User: &models.User{Role: models.UserRoleEmployer},
+			Employer: &models.Employer{
+				CompanyName:        draft["company_name"],
+				CompanyDescription: draft["company_description"],
+				CompanyWebsite:     draft["company_website"],
+				CompanyLocation:    draft["company_location"],
+				CompanySize:        draft["company_size"],
+			},
+		}, textEmployerRegistered)
+	case flowVacancy:
+		employer, err := b.employer(ctx, message.Chat.ID)
+		if employer == nil || err != nil {
+			return err
+		}
+
+		return b.publishVacancy(ctx, message.Chat.ID, &models.Vacancy{
+			EmployerID:  employer.EmployerID,
+			Title:       draft["title"],
+			Description: draft["description"],
+			Location:    draft["location"],
+			Salary:      draft["salary"],
+			Tags:        parseTags(draft["tags"]),
+		})
+	}
+
+	return nil
+}
+
+// prompt отправляет вопрос текущего шага с уже введенным значением и командами управления
+func (b *Bot) prompt(ctx context.Context, chatID int64, conversation *models.Conversation, prefix string) error {
+	steps := dialogSteps[conversation.Flow]
+	if conversation.Step >= len(steps) {
+		return nil
+	}
+
+	step := steps[conversation.Step]
+
+	text := prefix + fmt.Sprintf(textDialogStep, conversation.Step+1, len(steps), step.prompt)
+	if value := conversation.Draft[step.field]; value != "" {
+		text += fmt.Sprintf(textDialogCurrent, value)
+	}
+	if step.optional {
+		text += textDialogSkip
+	}
+	text += textDialogControls
+
+	return b.reply(ctx, chatID, text, nil)
+}
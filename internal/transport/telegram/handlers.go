@@ -0,0 +1,620 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"jobot/internal/repository/pgerr"
+	reactionRepo "jobot/internal/repository/reaction"
+	resumeRepo "jobot/internal/repository/resume"
+	userRepo "jobot/internal/repository/user"
+	vacancyRepo "jobot/internal/repository/vacancy"
+	"jobot/internal/service/auth"
+	"jobot/internal/service/models"
+	notificationSrv "jobot/internal/service/notification"
+	userSrv "jobot/internal/service/user"
+
+	"github.com/google/uuid"
+)
+
+// Команды бота
+const (
+	commandStart      = "/start"
+	commandCompany    = "/company"
+	commandTags       = "/tags"
+	commandVacancies  = "/vacancies"
+	commandNewVacancy = "/newvacancy"
+
+	commandNotifications = "/notifications"
+)
+
+// Действия inline кнопок, данные кнопки - "действие:значение"
+const (
+	callbackRole    = "role"
+	callbackLike    = models.ReactionTypeLike
+	callbackDislike = models.ReactionTypeDislike
+)
+
+func (b *Bot) handleMessage(ctx context.Context, message *Message) error {
+	if message.Document != nil {
+		return b.handleDocument(ctx, message)
+	}
+
+	command, args := parseCommand(message.Text)
+
+	// Команда резюме содержит employee_id: /cv_<uuid без дефисов>
+	if value, ok := strings.CutPrefix(command, notificationSrv.ResumeCommandPrefix); ok {
+		return b.handleCandidateResume(ctx, message, value)
+	}
+
+	switch command {
+	case commandStart:
+		return b.handleStart(ctx, message)
+	case commandCompany:
+		return b.handleCompany(ctx, message, args)
+	case commandTags:
+		return b.handleTags(ctx, message, args)
+	case commandVacancies:
+		return b.handleVacancies(ctx, message)
+	case commandNewVacancy:
+		return b.handleNewVacancy(ctx, message, args)
+	case commandNotifications:
+		return b.handleNotifications(ctx, message, args)
+	case commandBack:
+		return b.handleBack(ctx, message)
+	case commandSkip:
+		return b.handleSkip(ctx, message)
+	case commandCancel:
+		return b.handleCancel(ctx, message)
+	case commandResume:
+		return b.handleResume(ctx, message)
+	case "":
+		return b.handleText(ctx, message, args)
+	default:
+		// /help и все, что бот не понимает
+		return b.handleHelp(ctx, message)
+	}
+}
+
+func (b *Bot) handleCallback(ctx context.Context, query *CallbackQuery) error {
+	action, value, _ := strings.Cut(query.Data, ":")
+
+	var (
+		answer string
+		err    error
+	)
+
+	switch action {
+	case callbackRole:
+		err = b.handleRoleChoice(ctx, query, value)
+	case callbackLike, callbackDislike:
+		answer, err = b.handleReaction(ctx, query, action, value)
+	}
+
+	// Telegram показывает на кнопке индикатор загрузки, пока на нажатие не ответили
+	if answerErr := b.client.AnswerCallbackQuery(ctx, query.ID, answer); answerErr != nil && err == nil {
+		err = fmt.Errorf("failed to answer callback query: %w", answerErr)
+	}
+
+	return err
+}
+
+func (b *Bot) handleStart(ctx context.Context, message *Message) error {
+	profile, err := b.profile(ctx, message.Chat.ID)
+	if err != nil {
+		return err
+	}
+
+	if profile == nil {
+		return b.reply(ctx, message.Chat.ID, textWelcome, roleKeyboard())
+	}
+
+	return b.reply(ctx, message.Chat.ID, helpText(profile), nil)
+}
+
+func (b *Bot) handleHelp(ctx context.Context, message *Message) error {
+	profile, err := b.profile(ctx, message.Chat.ID)
+	if err != nil {
+		return err
+	}
+
+	return b.reply(ctx, message.Chat.ID, helpText(profile), nil)
+}
+
+// handleRoleChoice регистрирует соискателя сразу, а работодателя ведет через диалог с данными компании
+func (b *Bot) handleRoleChoice(ctx context.Context, query *CallbackQuery, role string) error {
+	chatID := query.From.ID
+
+	if role == models.UserRoleEmployer {
+		return b.startEmployerDialog(ctx, chatID)
+	}
+
+	return b.onboard(ctx, chatID, &query.From, &models.UserProfile{
+		User:     &models.User{Role: models.UserRoleEmployee},
+		Employee: &models.Employee{Tags: []string{}},
+	}, textEmployeeRegistered)
+}
+
+func (b *Bot) handleCompany(ctx context.Context, message *Message, args string) error {
+	if args == "" {
+		return b.startEmployerDialog(ctx, message.Chat.ID)
+	}
+
+	fields := splitFields(args)
+	if len(fields) != 5 || slices.Contains(fields, "") {
+		return b.reply(ctx, message.Chat.ID, textCompanyUsage, nil)
+	}
+
+	return b.onboard(ctx, message.Chat.ID, message.From, &models.UserProfile{
+		User: &models.User{Role: models.UserRoleEmployer},
+		Employer: &models.Employer{
+			CompanyName:        fields[0],
+			CompanyDescription: fields[1],
+			CompanyWebsite:     fields[2],
+			CompanyLocation:    fields[3],
+			CompanySize:        fields[4],
+		},
+	}, textEmployerRegistered)
+}
+
+// startEmployerDialog начинает регистрацию работодателя по шагам, если пользователь еще не зарегистрирован
+func (b *Bot) startEmployerDialog(ctx context.Context, chatID int64) error {
+	profile, err := b.profile(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case profile == nil:
+		return b.startDialog(ctx, chatID, flowEmployer)
+	case profile.Employer == nil:
+		return b.reply(ctx, chatID, textAlreadyRegistered, nil)
+	default:
+		return b.reply(ctx, chatID, helpText(profile), nil)
+	}
+}
+
+// onboard регистрирует пользователя чата с профилем роли и отправляет text
+func (b *Bot) onboard(ctx context.Context, chatID int64, from *User, profile *models.UserProfile, text string) error {
+	profile.User.TgChatID = chatKey(chatID)
+	if from != nil {
+		profile.User.TgUserName = from.Username
+	}
+
+	_, _, err := b.services.User.Onboard(ctx, profile)
+	if errors.Is(err, userSrv.ErrRoleMismatch) {
+		return b.reply(ctx, chatID, textAlreadyRegistered, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to onboard user: %w", err)
+	}
+
+	return b.reply(ctx, chatID, text, nil)
+}
+
+func (b *Bot) handleTags(ctx context.Context, message *Message, args string) error {
+	employee, err := b.employee(ctx, message.Chat.ID)
+	if employee == nil || err != nil {
+		return err
+	}
+
+	tags := parseTags(args)
+	if len(tags) == 0 {
+		return b.reply(ctx, message.Chat.ID, textTagsUsage, nil)
+	}
+
+	err = b.services.Employee.UpdateEmployee(ctx, &models.EmployeeUpdateRequest{Tags: &tags}, employee.EmployeeID)
+	if err != nil {
+		return fmt.Errorf("failed to update employee tags: %w", err)
+	}
+
+	return b.reply(ctx, message.Chat.ID, fmt.Sprintf(textTagsSaved, strings.Join(tags, ", ")), nil)
+}
+
+func (b *Bot) handleVacancies(ctx context.Context, message *Message) error {
+	employee, err := b.employee(ctx, message.Chat.ID)
+	if employee == nil || err != nil {
+		return err
+	}
+
+	return b.sendNextVacancy(ctx, message.Chat.ID, employee.EmployeeID)
+}
+
+// sendNextVacancy отправляет лучшую вакансию из ленты сотрудника с кнопками оценки.
+// Оцененные вакансии выпадают из ленты, поэтому следующая вакансия - всегда первая.
+func (b *Bot) sendNextVacancy(ctx context.Context, chatID int64, employeeID uuid.UUID) error {
+	feed, err := b.services.Feed.GetEmployeeFeed(ctx, employeeID, models.Pagination{Page: 1, PerPage: 1})
+	if err != nil {
+		return fmt.Errorf("failed to get employee feed: %w", err)
+	}
+
+	if len(feed.Items) == 0 {
+		return b.reply(ctx, chatID, textNoVacancies, nil)
+	}
+
+	vacancy := feed.Items[0].Vacancy
+
+	return b.reply(ctx, chatID, formatVacancy(&vacancy), reactionKeyboard(vacancy.VacansieID))
+}
+
+// handleReaction сохраняет оценку вакансии и показывает следующую, возвращает текст ответа на нажатие
+func (b *Bot) handleReaction(ctx context.Context, query *CallbackQuery, reactionType, value string) (string, error) {
+	vacancyID, err := uuid.Parse(value)
+	if err != nil {
+		return "", nil
+	}
+
+	employee, err := b.employee(ctx, query.From.ID)
+	if employee == nil || err != nil {
+		return "", err
+	}
+
+	// Архивные вакансии не находятся, хотя строка в БД осталась
+	if _, err := b.services.Vacancy.GetVacancyByID(ctx, vacancyID); err != nil {
+		if errors.Is(err, vacancyRepo.ErrVacancyNotFound) {
+			return textVacancyUnavailable, nil
+		}
+
+		return "", fmt.Errorf("failed to get vacancy: %w", err)
+	}
+
+	_, err = b.services.Reaction.CreateReaction(ctx, &models.Reaction{
+		EmployeeID: employee.EmployeeID,
+		VacancyID:  vacancyID,
+		Type:       reactionType,
+	})
+	switch {
+	case errors.Is(err, reactionRepo.ErrReactionAlreadyExists):
+		return textAlreadyRated, nil
+	case errors.Is(err, pgerr.ErrForeignKeyViolation):
+		return textVacancyUnavailable, nil
+	case err != nil:
+		return "", fmt.Errorf("failed to create reaction: %w", err)
+	}
+
+	answer := textDisliked
+	if reactionType == models.ReactionTypeLike {
+		answer = textLiked
+	}
+
+	return answer, b.sendNextVacancy(ctx, query.From.ID, employee.EmployeeID)
+}
+
+// handleNotifications показывает и меняет настройки уведомлений:
+// "on", "off", "quiet 22-8 [часовой пояс]", "quiet off", без аргументов - текущие настройки
+func (b *Bot) handleNotifications(ctx context.Context, message *Message, args string) error {
+	profile, err := b.profile(ctx, message.Chat.ID)
+	if err != nil {
+		return err
+	}
+
+	if profile == nil {
+		return b.reply(ctx, message.Chat.ID, textHelpUnregistered, nil)
+	}
+
+	preferences, err := b.services.Notification.GetNotificationPreferences(ctx, profile.User.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	fields := strings.Fields(strings.ToLower(args))
+	switch {
+	case len(fields) == 0:
+		return b.reply(ctx, message.Chat.ID, formatNotificationPreferences(profile, preferences), nil)
+	case len(fields) == 1 && (fields[0] == "on" || fields[0] == "off"):
+		// Работодатель получает уведомления о лайках кандидатов, соискатель - о новых вакансиях
+		if profile.Employer != nil {
+			preferences.CandidateLikes = fields[0] == "on"
+		} else {
+			preferences.NewVacancies = fields[0] == "on"
+		}
+	case len(fields) == 2 && fields[0] == "quiet" && fields[1] == "off":
+		preferences.QuietHoursStart, preferences.QuietHoursEnd = nil, nil
+	case (len(fields) == 2 || len(fields) == 3) && fields[0] == "quiet":
+		start, end, ok := parseHourRange(fields[1])
+		if !ok {
+			return b.reply(ctx, message.Chat.ID, textInvalidQuietHours, nil)
+		}
+
+		preferences.QuietHoursStart, preferences.QuietHoursEnd = &start, &end
+		if len(fields) == 3 {
+			// Регистр часового пояса важен, а fields в нижнем регистре
+			preferences.Timezone = strings.Fields(args)[2]
+		}
+	default:
+		return b.reply(ctx, message.Chat.ID, textNotificationsUsage, nil)
+	}
+
+	preferences, err = b.services.Notification.UpdateNotificationPreferences(ctx, preferences)
+	switch {
+	case errors.Is(err, notificationSrv.ErrInvalidQuietHours), errors.Is(err, notificationSrv.ErrInvalidTimezone):
+		return b.reply(ctx, message.Chat.ID, textInvalidQuietHours, nil)
+	case err != nil:
+		return fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return b.reply(ctx, message.Chat.ID, formatNotificationPreferences(profile, preferences), nil)
+}
+
+// handleCandidateResume присылает работодателю резюме кандидата, который лайкнул одну из его вакансий или откликнулся на нее
+func (b *Bot) handleCandidateResume(ctx context.Context, message *Message, value string) error {
+	employer, err := b.employer(ctx, message.Chat.ID)
+	if employer == nil || err != nil {
+		return err
+	}
+
+	employeeID, err := uuid.Parse(value)
+	if err != nil {
+		return b.reply(ctx, message.Chat.ID, textCandidateNotFound, nil)
+	}
+
+	// То же правило, что и для резюме через REST API
+	ok, err := b.services.Access.EmployerHasCandidate(ctx, employer.EmployerID, employeeID)
+	if err != nil {
+		return fmt.Errorf("failed to check candidate: %w", err)
+	}
+
+	if !ok {
+		return b.reply(ctx, message.Chat.ID, textCandidateNotFound, nil)
+	}
+
+	resume, err := b.services.Resume.GetResumeByEmployeeID(ctx, employeeID)
+	switch {
+	case errors.Is(err, resumeRepo.ErrResumeNotFound):
+		return b.reply(ctx, message.Chat.ID, textCandidateNoResume, nil)
+	case err != nil:
+		return fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	if err := b.client.SendDocument(ctx, message.Chat.ID, resume.TgFileID, ""); err != nil {
+		return fmt.Errorf("failed to send resume: %w", err)
+	}
+
+	return nil
+}
+
+// handleDocument сохраняет присланный файл как резюме сотрудника, заменяя прежнее
+func (b *Bot) handleDocument(ctx context.Context, message *Message) error {
+	employee, err := b.employee(ctx, message.Chat.ID)
+	if employee == nil || err != nil {
+		return err
+	}
+
+	fileID := message.Document.FileID
+
+	resume, err := b.services.Resume.GetResumeByEmployeeID(ctx, employee.EmployeeID)
+	switch {
+	case err == nil:
+		err = b.services.Resume.UpdateResume(ctx, &models.ResumeUpdateRequest{TgFileID: &fileID}, resume.ResumeID)
+		if err != nil {
+			return fmt.Errorf("failed to update resume: %w", err)
+		}
+	case errors.Is(err, resumeRepo.ErrResumeNotFound):
+		_, err = b.services.Resume.CreateResume(ctx, &models.Resume{EmployeeID: employee.EmployeeID, TgFileID: fileID})
+		if err != nil {
+			return fmt.Errorf("failed to create resume: %w", err)
+		}
+	default:
+		return fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	return b.reply(ctx, message.Chat.ID, textResumeSaved, nil)
+}
+
+func (b *Bot) handleNewVacancy(ctx context.Context, message *Message, args string) error {
+	employer, err := b.employer(ctx, message.Chat.ID)
+	if employer == nil || err != nil {
+		return err
+	}
+
+	if args == "" {
+		return b.startDialog(ctx, message.Chat.ID, flowVacancy)
+	}
+
+	fields := splitFields(args)
+	if len(fields) < 4 || len(fields) > 5 || slices.Contains(fields[:4], "") {
+		return b.reply(ctx, message.Chat.ID, textNewVacancyUsage, nil)
+	}
+
+	vacancy := &models.Vacancy{
+		EmployerID:  employer.EmployerID,
+		Title:       fields[0],
+		Description: fields[1],
+		Location:    fields[2],
+		Salary:      fields[3],
+		Tags:        []string{},
+	}
+	if len(fields) == 5 {
+		vacancy.Tags = parseTags(fields[4])
+	}
+
+	return b.publishVacancy(ctx, message.Chat.ID, vacancy)
+}
+
+// publishVacancy создает вакансию и сообщает о публикации
+func (b *Bot) publishVacancy(ctx context.Context, chatID int64, vacancy *models.Vacancy) error {
+	vacancy, err := b.services.Vacancy.CreateVacancy(ctx, vacancy)
+	if err != nil {
+		return fmt.Errorf("failed to create vacancy: %w", err)
+	}
+
+	return b.reply(ctx, chatID, fmt.Sprintf(textVacancyPublished, vacancy.Title), nil)
+}
+
+// profile возвращает профиль пользователя чата или nil, если пользователь не зарегистрирован
+func (b *Bot) profile(ctx context.Context, chatID int64) (*models.UserProfile, error) {
+	profile, err := b.services.User.GetUserProfileByTgChatID(ctx, chatKey(chatID))
+	if errors.Is(err, userRepo.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+
+	// Как и в REST API, деактивированный пользователь ничего не может делать (ответ отправляет HandleUpdate)
+	if !profile.User.IsActive {
+		return nil, auth.ErrUserInactive
+	}
+
+	return profile, nil
+}
+
+// employee возвращает профиль сотрудника пользователя чата.
+// Если пользователь не сотрудник, отправляет подсказку и возвращает nil без ошибки.
+func (b *Bot) employee(ctx context.Context, chatID int64) (*models.Employee, error) {
+	profile, err := b.profile(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == nil {
+		return nil, b.reply(ctx, chatID, textHelpUnregistered, nil)
+	}
+
+	if profile.Employee == nil {
+		return nil, b.reply(ctx, chatID, textEmployeeOnly, nil)
+	}
+
+	return profile.Employee, nil
+}
+
+// employer возвращает профиль работодателя пользователя чата, как employee
+func (b *Bot) employer(ctx context.Context, chatID int64) (*models.Employer, error) {
+	profile, err := b.profile(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == nil {
+		return nil, b.reply(ctx, chatID, textHelpUnregistered, nil)
+	}
+
+	if profile.Employer == nil {
+		return nil, b.reply(ctx, chatID, textEmployerOnly, nil)
+	}
+
+	return profile.Employer, nil
+}
+
+// chatKey - tg_chat_id в формате, в котором он хранится в БД
+func chatKey(chatID int64) string {
+	return strconv.FormatInt(chatID, 10)
+}
+
+func helpText(profile *models.UserProfile) string {
+	switch {
+	case profile == nil:
+		return textHelpUnregistered
+	case profile.Employee != nil:
+		return textHelpEmployee + textHelpNotifications
+	case profile.Employer != nil:
+		return textHelpEmployer + textHelpNotifications
+	default:
+		return textHelpEmployee + "\n\n" + textHelpEmployer + textHelpNotifications
+	}
+}
+
+func roleKeyboard() *InlineKeyboardMarkup {
+	return &InlineKeyboardMarkup{InlineKeyboard: [][]InlineKeyboardButton{
+		{{Text: buttonEmployee, CallbackData: callbackRole + ":" + models.UserRoleEmployee}},
+		{{Text: buttonEmployer, CallbackData: callbackRole + ":" + models.UserRoleEmployer}},
+	}}
+}
+
+func reactionKeyboard(vacancyID uuid.UUID) *InlineKeyboardMarkup {
+	return &InlineKeyboardMarkup{InlineKeyboard: [][]InlineKeyboardButton{{
+		{Text: buttonLike, CallbackData: callbackLike + ":" + vacancyID.String()},
+		{Text: buttonDislike, CallbackData: callbackDislike + ":" + vacancyID.String()},
+	}}}
+}
+
+func formatVacancy(vacancy *models.Vacancy) string {
+	text := fmt.Sprintf(textVacancy, vacancy.Title, vacancy.Description, vacancy.Location, vacancy.Salary)
+	if len(vacancy.Tags) > 0 {
+		text += fmt.Sprintf(textVacancyTags, strings.Join(vacancy.Tags, ", "))
+	}
+
+	return text
+}
+
+func formatNotificationPreferences(profile *models.UserProfile, preferences *models.NotificationPreferences) string {
+	subject, enabled := textNotificationsNewVacancies, preferences.NewVacancies
+	if profile.Employer != nil {
+		subject, enabled = textNotificationsCandidateLikes, preferences.CandidateLikes
+	}
+
+	state := textNotificationsOff
+	if enabled {
+		state = textNotificationsOn
+	}
+
+	quietHours := textQuietHoursNone
+	if preferences.QuietHoursStart != nil && preferences.QuietHoursEnd != nil {
+		quietHours = fmt.Sprintf(textQuietHours, *preferences.QuietHoursStart, *preferences.QuietHoursEnd, preferences.Timezone)
+	}
+
+	return fmt.Sprintf(textNotificationsStatus, subject, state, quietHours)
+}
+
+// parseHourRange разбирает интервал часов "22-8"
+func parseHourRange(value string) (int, int, bool) {
+	from, to, found := strings.Cut(value, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	start, err := strconv.Atoi(from)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	end, err := strconv.Atoi(to)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
+
+// parseCommand разбирает "/command@bot аргументы" на команду и аргументы
+func parseCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", text
+	}
+
+	command, args, _ := strings.Cut(text, " ")
+	command, _, _ = strings.Cut(command, "@")
+
+	return strings.ToLower(command), strings.TrimSpace(args)
+}
+
+// splitFields разбирает аргументы команды, разделенные "|"
+func splitFields(args string) []string {
+	if strings.TrimSpace(args) == "" {
+		return nil
+	}
+
+	fields := strings.Split(args, "|")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	return fields
+}
+
+// parseTags разбирает теги через запятую, пустые отбрасываются
+func parseTags(args string) []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(args, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,40 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	notificationSrv "jobot/internal/service/notification"
+)
+
+// Notifier доставляет уведомления сервисов в чаты Telegram (notification.Sender).
+// Работает через клиент Bot API и не зависит от того, запущен ли бот.
+type Notifier struct {
+	client *Client
+}
+
+func NewNotifier(client *Client) *Notifier {
+	return &Notifier{client: client}
+}
+
+// Send отправляет текст в чат. Если бот заблокирован пользователем или чат недоступен,
+// ошибка оборачивает notification.ErrUndeliverable, чтобы доставку не повторяли.
+func (n *Notifier) Send(ctx context.Context, tgChatID string, text string) error {
+	chatID, err := strconv.ParseInt(tgChatID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid chat id %q", notificationSrv.ErrUndeliverable, tgChatID)
+	}
+
+	err = n.client.SendMessage(ctx, &SendMessage{ChatID: chatID, Text: text})
+
+	// 403 - бот заблокирован, 400 - чат не найден или сообщение не будет принято и при повторе
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.Code == http.StatusForbidden || apiErr.Code == http.StatusBadRequest) {
+		return fmt.Errorf("%w: %w", notificationSrv.ErrUndeliverable, err)
+	}
+
+	return err
+}
@@ -0,0 +1,99 @@
+package telegram
+
+// Тексты сообщений бота
+const (
+	textWelcome = "Привет! Это jobot — бот для поиска работы.\nКто вы?"
+
+	textHelpEmployee = "Команды соискателя:\n" +
+		"/vacancies — подходящие вакансии\n" +
+		"/tags go, postgres — навыки, по которым подбираются вакансии\n" +
+		"Чтобы загрузить резюме, отправьте его файлом."
+
+	textHelpEmployer = "Команды работодателя:\n" +
+		"/newvacancy — опубликовать вакансию по шагам\n" +
+		"/newvacancy Название | Описание | Город | Зарплата | теги через запятую — то же одной командой"
+
+	textHelpNotifications = "\n\n/notifications — настройки уведомлений"
+
+	textHelpUnregistered = "Чтобы начать, отправьте /start."
+
+	textEmployeeRegistered = "Профиль соискателя создан.\n\n" + textHelpEmployee
+
+	textCompanyUsage = "Расскажите о компании одной командой:\n" +
+		"/company Название | Описание | Сайт | Город | Размер\n" +
+		"или отправьте /company без параметров, чтобы заполнить данные по шагам."
+
+	textEmployerRegistered = "Профиль работодателя создан.\n\n" + textHelpEmployer
+
+	textAlreadyRegistered = "Вы уже зарегистрированы с другой ролью."
+	textEmployeeOnly      = "Команда доступна только соискателям."
+	textEmployerOnly      = "Команда доступна только работодателям."
+
+	textTagsUsage = "Перечислите навыки через запятую: /tags go, postgres"
+	textTagsSaved = "Навыки сохранены: %s"
+
+	textNoVacancies        = "Новых подходящих вакансий пока нет. Загляните позже или обновите навыки командой /tags."
+	textVacancy            = "%s\n\n%s\n\n📍 %s\n💰 %s"
+	textVacancyTags        = "\n🏷 %s"
+	textVacancyUnavailable = "Вакансия больше недоступна"
+	textAlreadyRated       = "Вы уже оценили эту вакансию"
+	textLiked              = "👍 Отклик отправлен работодателю"
+	textDisliked           = "👎 Больше не покажем"
+
+	textResumeSaved = "Резюме сохранено."
+
+	textNewVacancyUsage = "Опишите вакансию одной командой:\n/newvacancy Название | Описание | Город | Зарплата | теги через запятую\n" +
+		"или отправьте /newvacancy без параметров, чтобы заполнить ее по шагам."
+	textVacancyPublished = "Вакансия «%s» опубликована."
+
+	textDialogStep      = "Шаг %d из %d. %s"
+	textDialogCurrent   = "\nСейчас: %s"
+	textDialogSkip      = "\n/skip — пропустить"
+	textDialogControls  = "\n\n/back — назад, /cancel — отменить"
+	textDialogResumed   = "Продолжаем заполнение черновика.\n\n"
+	textDialogRequired  = "Это поле обязательно.\n\n"
+	textDialogFirstStep = "Это первый шаг.\n\n"
+	textDialogExpired   = "Диалог прерван: долго не было ответа. Черновик сохранен.\n" +
+		"/resume — продолжить, /cancel — удалить черновик"
+	textDialogCanceled = "Черновик удален."
+	textNoDialog       = "Нет начатого диалога."
+
+	textPromptCompanyName        = "Как называется компания?"
+	textPromptCompanyDescription = "Коротко опишите компанию."
+	textPromptCompanyWebsite     = "Сайт компании."
+	textPromptCompanyLocation    = "В каком городе находится компания?"
+	textPromptCompanySize        = "Сколько в компании сотрудников? Например, 10-50."
+
+	textPromptVacancyTitle       = "Название вакансии?"
+	textPromptVacancyDescription = "Опишите вакансию: задачи и требования."
+	textPromptVacancyLocation    = "Город или «удаленно»?"
+	textPromptVacancySalary      = "Зарплата? Например, 200 000 - 300 000 ₽."
+	textPromptVacancyTags        = "Теги через запятую, по ним вакансию увидят подходящие соискатели. Например: go, postgres."
+
+	textNotificationsStatus         = "Уведомления о %s: %s\nТихие часы: %s\n\n" + textNotificationsUsage
+	textNotificationsNewVacancies   = "новых вакансиях"
+	textNotificationsCandidateLikes = "лайках кандидатов"
+	textNotificationsUsage          = "/notifications on или off — включить или выключить\n" +
+		"/notifications quiet 22-8 Europe/Moscow — не присылать с 22 до 8 по времени часового пояса\n" +
+		"/notifications quiet off — без тихих часов"
+	textNotificationsOn   = "включены"
+	textNotificationsOff  = "выключены"
+	textQuietHours        = "%02d:00–%02d:00 (%s)"
+	textQuietHoursNone    = "нет"
+	textInvalidQuietHours = "Тихие часы задаются разными часами от 0 до 23 и часовым поясом, например:\n" +
+		"/notifications quiet 22-8 Europe/Moscow"
+
+	textCandidateNotFound = "Кандидат не найден среди откликнувшихся на ваши вакансии."
+	textCandidateNoResume = "Кандидат еще не загрузил резюме."
+
+	textInternalError = "Что-то пошло не так, попробуйте позже."
+	textUserInactive  = "Ваш аккаунт деактивирован, бот для него недоступен."
+)
+
+// Подписи кнопок
+const (
+	buttonEmployee = "🔎 Ищу работу"
+	buttonEmployer = "💼 Ищу сотрудников"
+	buttonLike     = "👍 Интересно"
+	buttonDislike  = "👎 Пропустить"
+)
@@ -0,0 +1,72 @@
+package telegram
+
+// Типы Telegram Bot API, поля ограничены теми, что использует бот.
+// Описание: https://core.telegram.org/bots/api#available-types
+
+// Update - входящее обновление: сообщение или нажатие inline кнопки
+type Update struct {
+	UpdateID      int64          `json:"update_id"`
+	Message       *Message       `json:"message,omitempty"`
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+}
+
+// Message - сообщение в чате
+type Message struct {
+	MessageID int64     `json:"message_id"`
+	From      *User     `json:"from,omitempty"`
+	Chat      Chat      `json:"chat"`
+	Text      string    `json:"text,omitempty"`
+	Document  *Document `json:"document,omitempty"`
+}
+
+// User - пользователь Telegram
+type User struct {
+	ID        int64  `json:"id"`
+	IsBot     bool   `json:"is_bot"`
+	FirstName string `json:"first_name"`
+	Username  string `json:"username,omitempty"`
+	IsPremium bool   `json:"is_premium,omitempty"`
+}
+
+// ChatTypePrivate - тип личного чата с пользователем, остальные типы: group, supergroup, channel
+const ChatTypePrivate = "private"
+
+// Chat - чат, в личных чатах ID совпадает с ID пользователя
+type Chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+// Document - файл, отправленный как документ
+type Document struct {
+	FileID   string `json:"file_id"`
+	FileName string `json:"file_name,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+}
+
+// CallbackQuery - нажатие inline кнопки, Data - данные кнопки
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	From    User     `json:"from"`
+	Message *Message `json:"message,omitempty"`
+	Data    string   `json:"data,omitempty"`
+}
+
+// SendMessage - параметры метода sendMessage
+type SendMessage struct {
+	ChatID      int64                 `json:"chat_id"`
+	Text        string                `json:"text"`
+	ParseMode   string                `json:"parse_mode,omitempty"`
+	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+// InlineKeyboardMarkup - inline клавиатура под сообщением, по строкам
+type InlineKeyboardMarkup struct {
+	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
+}
+
+// InlineKeyboardButton - inline кнопка, CallbackData возвращается боту в CallbackQuery.Data
+type InlineKeyboardButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
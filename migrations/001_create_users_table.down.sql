@@ -0,0 +1,4 @@
+-- Revert 001_create_users_table.sql
+-- Indexes and comments are dropped together with the table
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,4 @@
+-- Revert 002_create_employees_table.sql
+-- Indexes and comments are dropped together with the table
+
+DROP TABLE IF EXISTS employees;
@@ -0,0 +1,4 @@
+-- Revert 003_create_employers_table.sql
+-- Indexes and comments are dropped together with the table
+
+DROP TABLE IF EXISTS employers;
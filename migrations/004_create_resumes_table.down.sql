@@ -0,0 +1,4 @@
+-- Revert 004_create_resumes_table.sql
+-- Indexes and comments are dropped together with the table
+
+DROP TABLE IF EXISTS resumes;
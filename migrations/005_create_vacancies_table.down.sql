@@ -0,0 +1,4 @@
+-- Revert 005_create_vacancies_table.sql
+-- Indexes and comments are dropped together with the table
+
+DROP TABLE IF EXISTS vacancies;
@@ -0,0 +1,4 @@
+-- Revert 006_create_reactions_table.sql
+-- Indexes and comments are dropped together with the table
+
+DROP TABLE IF EXISTS reactions;
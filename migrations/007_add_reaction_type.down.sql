@@ -0,0 +1,7 @@
+-- Revert 007_add_reaction_type.sql
+
+DROP INDEX IF EXISTS idx_reactions_employee_id_reaction;
+
+ALTER TABLE reactions
+    DROP COLUMN IF EXISTS reaction,
+    DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,14 @@
+-- Add reaction type to reactions table
+-- Stores what the employee actually chose when swiping a vacancy (like/dislike)
+
+ALTER TABLE reactions
+    ADD COLUMN IF NOT EXISTS reaction VARCHAR(20) NOT NULL DEFAULT 'like'
+        CHECK (reaction IN ('like', 'dislike')),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_reactions_employee_id_reaction ON reactions(employee_id, reaction);
+
+-- Add comments
+COMMENT ON COLUMN reactions.reaction IS 'Reaction type: like or dislike';
+COMMENT ON COLUMN reactions.updated_at IS 'Timestamp when reaction was last changed';
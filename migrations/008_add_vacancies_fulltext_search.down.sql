@@ -0,0 +1,5 @@
+-- Revert 008_add_vacancies_fulltext_search.sql
+
+DROP INDEX IF EXISTS idx_vacancies_search_vector;
+
+ALTER TABLE vacancies DROP COLUMN IF EXISTS search_vector;
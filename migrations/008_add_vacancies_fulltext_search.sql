@@ -0,0 +1,17 @@
+-- Add full-text search over vacancy title and description
+-- The 'russian' text search configuration stems Cyrillic words with the Russian
+-- snowball dictionary and ASCII words with the English one, so a single vector
+-- covers both languages used in vacancies.
+
+ALTER TABLE vacancies
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+        GENERATED ALWAYS AS (
+            setweight(to_tsvector('russian'::regconfig, coalesce(title, '')), 'A') ||
+            setweight(to_tsvector('russian'::regconfig, coalesce(description, '')), 'B')
+        ) STORED;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_vacancies_search_vector ON vacancies USING GIN(search_vector);
+
+-- Add comments
+COMMENT ON COLUMN vacancies.search_vector IS 'Full-text search vector: title (weight A) and description (weight B), russian + english stemming';
@@ -0,0 +1,4 @@
+-- Revert 009_create_employer_reactions_and_matches_tables.sql
+
+DROP TABLE IF EXISTS matches;
+DROP TABLE IF EXISTS employer_reactions;
@@ -0,0 +1,51 @@
+-- Create employer_reactions table
+-- Employer decisions on candidates who liked one of their vacancies
+
+CREATE TABLE IF NOT EXISTS employer_reactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    employer_id UUID NOT NULL REFERENCES employers(employer_id) ON DELETE CASCADE,
+    employee_id UUID NOT NULL REFERENCES employees(employee_id) ON DELETE CASCADE,
+    vacancy_id UUID NOT NULL REFERENCES vacancies(vacansie_id) ON DELETE CASCADE,
+    decision VARCHAR(20) NOT NULL CHECK (decision IN ('accept', 'reject', 'shortlist')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(employee_id, vacancy_id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_employer_reactions_employer_id_decision ON employer_reactions(employer_id, decision);
+CREATE INDEX IF NOT EXISTS idx_employer_reactions_vacancy_id ON employer_reactions(vacancy_id);
+
+-- Add comments
+COMMENT ON TABLE employer_reactions IS 'Employer decisions (accept/reject/shortlist) on candidates who liked their vacancy';
+COMMENT ON COLUMN employer_reactions.id IS 'Primary key - unique employer reaction ID';
+COMMENT ON COLUMN employer_reactions.employer_id IS 'Foreign key to employers table - employer who responded';
+COMMENT ON COLUMN employer_reactions.employee_id IS 'Foreign key to employees table - candidate';
+COMMENT ON COLUMN employer_reactions.vacancy_id IS 'Foreign key to vacancies table - vacancy the candidate liked';
+COMMENT ON COLUMN employer_reactions.decision IS 'Employer decision: accept, reject or shortlist';
+COMMENT ON COLUMN employer_reactions.created_at IS 'Timestamp when employer reaction was created';
+COMMENT ON COLUMN employer_reactions.updated_at IS 'Timestamp when employer reaction was last changed';
+
+-- Create matches table
+-- A match exists while the employee likes the vacancy and the employer accepted the employee
+
+CREATE TABLE IF NOT EXISTS matches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    employee_id UUID NOT NULL REFERENCES employees(employee_id) ON DELETE CASCADE,
+    vacancy_id UUID NOT NULL REFERENCES vacancies(vacansie_id) ON DELETE CASCADE,
+    employer_id UUID NOT NULL REFERENCES employers(employer_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(employee_id, vacancy_id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_matches_employee_id ON matches(employee_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_matches_employer_id ON matches(employer_id, created_at DESC);
+
+-- Add comments
+COMMENT ON TABLE matches IS 'Mutual interest: employee liked the vacancy and employer accepted the employee';
+COMMENT ON COLUMN matches.id IS 'Primary key - unique match ID';
+COMMENT ON COLUMN matches.employee_id IS 'Foreign key to employees table';
+COMMENT ON COLUMN matches.vacancy_id IS 'Foreign key to vacancies table';
+COMMENT ON COLUMN matches.employer_id IS 'Foreign key to employers table - owner of the vacancy';
+COMMENT ON COLUMN matches.created_at IS 'Timestamp when both sides became positive';
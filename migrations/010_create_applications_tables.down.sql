@@ -0,0 +1,4 @@
+-- Revert 010_create_applications_tables.sql
+
+DROP TABLE IF EXISTS application_status_history;
+DROP TABLE IF EXISTS applications;
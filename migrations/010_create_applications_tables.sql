@@ -0,0 +1,55 @@
+-- Create applications table
+-- Applications track an employee's candidacy for a vacancy through hiring stages
+
+CREATE TABLE IF NOT EXISTS applications (
+    application_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    employee_id UUID NOT NULL REFERENCES employees(employee_id) ON DELETE CASCADE,
+    vacancy_id UUID NOT NULL REFERENCES vacancies(vacansie_id) ON DELETE CASCADE,
+    resume_id UUID REFERENCES resumes(resume_id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'applied'
+        CHECK (status IN ('applied', 'viewed', 'interview', 'offer', 'hired', 'rejected')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(employee_id, vacancy_id)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_applications_employee_id_status ON applications(employee_id, status);
+CREATE INDEX IF NOT EXISTS idx_applications_vacancy_id_status ON applications(vacancy_id, status);
+CREATE INDEX IF NOT EXISTS idx_applications_updated_at ON applications(updated_at DESC);
+
+-- Add comments
+COMMENT ON TABLE applications IS 'Employee applications to vacancies moving through hiring stages';
+COMMENT ON COLUMN applications.application_id IS 'Primary key - unique application ID';
+COMMENT ON COLUMN applications.employee_id IS 'Foreign key to employees table - applicant';
+COMMENT ON COLUMN applications.vacancy_id IS 'Foreign key to vacancies table';
+COMMENT ON COLUMN applications.resume_id IS 'Foreign key to resumes table - resume attached to the application';
+COMMENT ON COLUMN applications.status IS 'Current stage: applied, viewed, interview, offer, hired or rejected';
+COMMENT ON COLUMN applications.created_at IS 'Timestamp when application was submitted';
+COMMENT ON COLUMN applications.updated_at IS 'Timestamp of the last status change';
+
+-- Create application_status_history table
+-- Every status change of an application, including the initial 'applied'
+
+CREATE TABLE IF NOT EXISTS application_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    application_id UUID NOT NULL REFERENCES applications(application_id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(20) NOT NULL CHECK (actor IN ('employee', 'employer')),
+    comment TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_application_status_history_application_id ON application_status_history(application_id, created_at);
+
+-- Add comments
+COMMENT ON TABLE application_status_history IS 'History of application status transitions';
+COMMENT ON COLUMN application_status_history.id IS 'Primary key - unique transition ID';
+COMMENT ON COLUMN application_status_history.application_id IS 'Foreign key to applications table';
+COMMENT ON COLUMN application_status_history.from_status IS 'Status before the transition, NULL for the initial application';
+COMMENT ON COLUMN application_status_history.to_status IS 'Status after the transition';
+COMMENT ON COLUMN application_status_history.actor IS 'Side that made the transition: employee or employer';
+COMMENT ON COLUMN application_status_history.comment IS 'Optional comment left with the transition';
+COMMENT ON COLUMN application_status_history.created_at IS 'Timestamp of the transition';
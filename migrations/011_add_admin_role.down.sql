@@ -0,0 +1,9 @@
+-- Revert 011_add_admin_role.sql
+-- Fails while admin users exist: change their role before rolling back
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK (role IN ('employee', 'employer'));
+
+-- Restore comments
+COMMENT ON COLUMN users.role IS 'User role: employee (job seeker) or employer (company/recruiter)';
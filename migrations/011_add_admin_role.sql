@@ -0,0 +1,9 @@
+-- Add admin role to users
+-- Admins bypass role and ownership checks of the API
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK (role IN ('employee', 'employer', 'admin'));
+
+-- Update comments
+COMMENT ON COLUMN users.role IS 'User role: employee (job seeker), employer (company/recruiter) or admin (bypasses authorization)';
@@ -0,0 +1,3 @@
+-- Revert 012_create_api_keys_table.sql
+
+DROP TABLE IF EXISTS api_keys;
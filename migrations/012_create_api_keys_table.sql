@@ -0,0 +1,26 @@
+-- Create api_keys table
+-- Machine credentials for service-to-service calls (e.g. the Telegram bot process)
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    api_key_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_api_keys_created_at ON api_keys(created_at DESC);
+
+-- Add comments
+COMMENT ON TABLE api_keys IS 'Hashed API keys for service-to-service authentication';
+COMMENT ON COLUMN api_keys.key_prefix IS 'Public part of the key used to find it, the secret part is never stored';
+COMMENT ON COLUMN api_keys.key_hash IS 'Hex encoded SHA-256 of the full key';
+COMMENT ON COLUMN api_keys.scopes IS 'Granted scopes: admin, on_behalf_of_user';
+COMMENT ON COLUMN api_keys.last_used_at IS 'Last successful authentication with the key (updated at most once a minute)';
+COMMENT ON COLUMN api_keys.revoked_at IS 'Revocation time, revoked keys are rejected';
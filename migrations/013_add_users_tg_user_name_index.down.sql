@@ -0,0 +1,3 @@
+-- Revert 013_add_users_tg_user_name_index.sql
+
+DROP INDEX IF EXISTS idx_users_tg_user_name_lower;
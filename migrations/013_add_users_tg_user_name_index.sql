@@ -0,0 +1,4 @@
+-- Add index for looking up users by Telegram username
+-- Usernames are case-insensitive in Telegram, so the index is on lower(tg_user_name)
+
+CREATE INDEX IF NOT EXISTS idx_users_tg_user_name_lower ON users(lower(tg_user_name));
@@ -0,0 +1,8 @@
+-- Revert 014_add_profile_archiving.sql
+-- Archived vacancies become visible again
+
+DROP INDEX IF EXISTS idx_vacancies_active_created_at;
+
+ALTER TABLE vacancies DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE employers DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE employees DROP COLUMN IF EXISTS archived_at;
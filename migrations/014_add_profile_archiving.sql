@@ -0,0 +1,15 @@
+-- Add archiving of role profiles and vacancies
+-- When a user changes role the profile of the previous role is archived instead of deleted,
+-- so reactions, matches and applications keep pointing at it and switching back restores it
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP;
+ALTER TABLE employers ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP;
+ALTER TABLE vacancies ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_vacancies_active_created_at ON vacancies(created_at DESC) WHERE archived_at IS NULL;
+
+-- Add comments
+COMMENT ON COLUMN employees.archived_at IS 'Set when the user switched to another role, archived employees are hidden from candidates';
+COMMENT ON COLUMN employers.archived_at IS 'Set when the user switched to another role';
+COMMENT ON COLUMN vacancies.archived_at IS 'Set when the employer switched to another role, archived vacancies are hidden from the API';
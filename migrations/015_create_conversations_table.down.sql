@@ -0,0 +1,3 @@
+-- Revert 015_create_conversations_table.sql
+
+DROP TABLE IF EXISTS conversations;
@@ -0,0 +1,23 @@
+-- Create conversations table
+-- State of multi-step Telegram bot dialogs, one row per chat, so drafts survive bot restarts.
+-- Not linked to users: a dialog can start before the user is registered (employer onboarding)
+
+CREATE TABLE IF NOT EXISTS conversations (
+    tg_chat_id VARCHAR(255) PRIMARY KEY,
+    flow VARCHAR(50) NOT NULL,
+    step INTEGER NOT NULL DEFAULT 0 CHECK (step >= 0),
+    draft JSONB NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_conversations_expires_at ON conversations(expires_at);
+
+-- Add comments
+COMMENT ON TABLE conversations IS 'Multi-step bot dialogs (FSM) with their drafts';
+COMMENT ON COLUMN conversations.flow IS 'Dialog scenario: employer, vacancy';
+COMMENT ON COLUMN conversations.step IS 'Index of the current step of the scenario';
+COMMENT ON COLUMN conversations.draft IS 'Answers entered so far, by step field';
+COMMENT ON COLUMN conversations.expires_at IS 'The dialog times out after this moment, the draft is kept and can be resumed';
@@ -0,0 +1,4 @@
+-- Revert 016_create_notifications_tables.sql
+
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS notification_preferences;
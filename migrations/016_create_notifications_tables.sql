@@ -0,0 +1,43 @@
+-- Create notification tables
+-- Per-user notification preferences and the queue of Telegram notifications with delivery status
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    new_vacancies BOOLEAN NOT NULL DEFAULT TRUE,
+    quiet_hours_start SMALLINT CHECK (quiet_hours_start BETWEEN 0 AND 23),
+    quiet_hours_end SMALLINT CHECK (quiet_hours_end BETWEEN 0 AND 23),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT notification_preferences_quiet_hours_check CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    notification_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    vacancy_id UUID REFERENCES vacancies(vacansie_id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'canceled')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_user_type_vacancy ON notifications(user_id, type, vacancy_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_notifications_vacancy_id ON notifications(vacancy_id);
+
+-- Add comments
+COMMENT ON TABLE notification_preferences IS 'Notification settings, users without a row get the defaults';
+COMMENT ON COLUMN notification_preferences.new_vacancies IS 'Notify the employee about new vacancies matching their tags';
+COMMENT ON COLUMN notification_preferences.quiet_hours_start IS 'Hour (0-23, in timezone) from which notifications are held back';
+COMMENT ON COLUMN notification_preferences.quiet_hours_end IS 'Hour (0-23, in timezone) at which held back notifications are delivered';
+COMMENT ON COLUMN notification_preferences.timezone IS 'IANA time zone of the quiet hours';
+COMMENT ON TABLE notifications IS 'Telegram notifications queue, one notification per user and subject';
+COMMENT ON COLUMN notifications.type IS 'Notification type: new_vacancy';
+COMMENT ON COLUMN notifications.status IS 'pending - waiting for delivery, sent, failed - attempts exhausted or chat unavailable, canceled - no longer relevant';
+COMMENT ON COLUMN notifications.next_attempt_at IS 'Earliest time of the next delivery attempt (retry delay, quiet hours, delivery lease)';
@@ -0,0 +1,14 @@
+-- Revert 017_add_candidate_liked_notifications.sql
+-- Candidate notifications are deleted, the old unique index does not allow several candidates per vacancy
+
+DELETE FROM notifications WHERE type = 'candidate_liked';
+
+DROP INDEX IF EXISTS idx_notifications_user_type_sent_at;
+DROP INDEX IF EXISTS idx_notifications_candidate_liked;
+DROP INDEX IF EXISTS idx_notifications_new_vacancy;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_user_type_vacancy ON notifications(user_id, type, vacancy_id);
+
+COMMENT ON COLUMN notifications.type IS 'Notification type: new_vacancy';
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS employee_id;
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS candidate_likes;
@@ -0,0 +1,17 @@
+-- Add notifications to employers about candidates who liked their vacancies
+-- A candidate_liked notification has both vacancy_id and employee_id, so the unique index
+-- of new_vacancy notifications no longer fits all types and is split per type
+
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS candidate_likes BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS employee_id UUID REFERENCES employees(employee_id) ON DELETE CASCADE;
+
+-- Create indexes
+DROP INDEX IF EXISTS idx_notifications_user_type_vacancy;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_new_vacancy ON notifications(user_id, vacancy_id) WHERE type = 'new_vacancy';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_candidate_liked ON notifications(vacancy_id, employee_id) WHERE type = 'candidate_liked';
+CREATE INDEX IF NOT EXISTS idx_notifications_user_type_sent_at ON notifications(user_id, type, sent_at) WHERE status = 'sent';
+
+-- Add comments
+COMMENT ON COLUMN notification_preferences.candidate_likes IS 'Notify the employer about candidates who liked their vacancies';
+COMMENT ON COLUMN notifications.type IS 'Notification type: new_vacancy, candidate_liked';
+COMMENT ON COLUMN notifications.employee_id IS 'Candidate of a candidate_liked notification';
@@ -0,0 +1,3 @@
+-- Revert 018_create_outbox_events_table.sql
+
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,30 @@
+-- Create outbox_events table
+-- Domain events about users, vacancies and reactions are written in the transaction of the change
+-- they describe and dispatched to the configured sinks in the background (at-least-once)
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    event_id UUID PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    delivered_sinks TEXT[] NOT NULL DEFAULT '{}',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(next_attempt_at) WHERE dispatched_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_dispatched_at ON outbox_events(dispatched_at) WHERE dispatched_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate ON outbox_events(aggregate_type, aggregate_id);
+
+-- Add comments
+COMMENT ON TABLE outbox_events IS 'Transactional outbox: domain events waiting for or done with dispatch to the sinks';
+COMMENT ON COLUMN outbox_events.type IS 'Event type <aggregate>.<action>, e.g. vacancy.created';
+COMMENT ON COLUMN outbox_events.payload IS 'State of the aggregate after the change, for deletions - its identifiers';
+COMMENT ON COLUMN outbox_events.delivered_sinks IS 'Sinks that already accepted the event, retries skip them';
+COMMENT ON COLUMN outbox_events.next_attempt_at IS 'Earliest time of the next dispatch attempt (retry delay, dispatch lease)';
+COMMENT ON COLUMN outbox_events.dispatched_at IS 'Set when every sink accepted the event, dispatched events are deleted after the retention period';
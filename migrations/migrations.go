@@ -0,0 +1,8 @@
+// Package migrations встраивает SQL миграции в бинарник, их применяет pkg/migrator.
+// NNN_name.sql - миграция версии NNN, NNN_name.down.sql - ее откат.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
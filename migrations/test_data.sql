@@ -67,15 +67,15 @@ INSERT INTO vacancies (vacansie_id, employer_id, tags, title, description, locat
      NOW(), NOW());
 
 -- Insert test reactions
-INSERT INTO reactions (id, employee_id, vacancy_id, created_at) VALUES
+INSERT INTO reactions (id, employee_id, vacancy_id, reaction, created_at, updated_at) VALUES
     ('aa0e8400-e29b-41d4-a716-446655440001', '660e8400-e29b-41d4-a716-446655440001',
-     '990e8400-e29b-41d4-a716-446655440001', NOW()),
+     '990e8400-e29b-41d4-a716-446655440001', 'like', NOW(), NOW()),
     ('aa0e8400-e29b-41d4-a716-446655440002', '660e8400-e29b-41d4-a716-446655440001',
-     '990e8400-e29b-41d4-a716-446655440002', NOW()),
+     '990e8400-e29b-41d4-a716-446655440002', 'dislike', NOW(), NOW()),
     ('aa0e8400-e29b-41d4-a716-446655440003', '660e8400-e29b-41d4-a716-446655440002',
-     '990e8400-e29b-41d4-a716-446655440003', NOW()),
+     '990e8400-e29b-41d4-a716-446655440003', 'like', NOW(), NOW()),
     ('aa0e8400-e29b-41d4-a716-446655440004', '660e8400-e29b-41d4-a716-446655440002',
-     '990e8400-e29b-41d4-a716-446655440004', NOW())
+     '990e8400-e29b-41d4-a716-446655440004', 'like', NOW(), NOW())
 ON CONFLICT (employee_id, vacancy_id) DO NOTHING;
 
 -- Verify data
@@ -0,0 +1,116 @@
+package migrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	ErrDuplicateMigration = errors.New("duplicate migration version")
+	ErrMissingUpMigration = errors.New("down migration without up migration")
+)
+
+// fileNamePattern - имя файла миграции: 001_create_users_table.sql или 001_create_users_table.down.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+?)(\.down)?\.sql$`)
+
+// Migration - одна версия схемы
+// Checksum - SHA-256 UpSQL, по нему проверяется, что примененная миграция не изменилась.
+// DownSQL пустой, если у миграции нет файла отката.
+type Migration struct {
+	Version  int64
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string
+}
+
+// HasDown проверяет, есть ли у миграции файл отката
+func (m *Migration) HasDown() bool {
+	return m.DownSQL != ""
+}
+
+// Load читает миграции из корня fsys и возвращает их по возрастанию версии.
+// Файлы, имя которых не начинается с номера версии (например, test_data.sql), пропускаются.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	downs := make(map[int64]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		if match[3] != "" {
+			if _, ok := downs[version]; ok {
+				return nil, fmt.Errorf("%w: %d", ErrDuplicateMigration, version)
+			}
+
+			downs[version] = string(content)
+
+			continue
+		}
+
+		if _, ok := byVersion[version]; ok {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateMigration, version)
+		}
+
+		byVersion[version] = &Migration{
+			Version:  version,
+			Name:     match[2],
+			UpSQL:    string(content),
+			Checksum: checksum(content),
+		}
+	}
+
+	for version, downSQL := range downs {
+		migration, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrMissingUpMigration, version)
+		}
+
+		migration.DownSQL = downSQL
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,53 @@
+package migrator_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "jobot/pkg/migrator"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"002_create_employees_table.sql":     {Data: []byte("CREATE TABLE employees ();")},
+		"001_create_users_table.sql":         {Data: []byte("CREATE TABLE users ();")},
+		"001_create_users_table.down.sql":    {Data: []byte("DROP TABLE users;")},
+		"010_create_applications_tables.sql": {Data: []byte("CREATE TABLE applications ();")},
+		"test_data.sql":                      {Data: []byte("INSERT INTO users DEFAULT VALUES;")},
+		"README.md":                          {Data: []byte("# Migrations")},
+	}
+
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_users_table", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE users;", migrations[0].DownSQL)
+	assert.True(t, migrations[0].HasDown())
+	assert.NotEmpty(t, migrations[0].Checksum)
+
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.False(t, migrations[1].HasDown())
+	assert.Equal(t, int64(10), migrations[2].Version)
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load(fstest.MapFS{
+		"001_create_users_table.sql": {Data: []byte("CREATE TABLE users ();")},
+		"001_create_people.sql":      {Data: []byte("CREATE TABLE people ();")},
+	})
+	assert.ErrorIs(t, err, ErrDuplicateMigration)
+
+	_, err = Load(fstest.MapFS{
+		"002_create_employees_table.down.sql": {Data: []byte("DROP TABLE employees;")},
+	})
+	assert.ErrorIs(t, err, ErrMissingUpMigration)
+}
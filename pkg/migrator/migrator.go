@@ -0,0 +1,402 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"jobot/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration was changed")
+	ErrUnknownVersion   = errors.New("applied migration version is unknown")
+	ErrNoDownMigration  = errors.New("migration has no down migration")
+	ErrInvalidSteps     = errors.New("steps must be positive")
+)
+
+const (
+	createTableQuery = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum VARCHAR(64) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`
+
+	// Ключ блокировки общий для всех экземпляров приложения, поэтому миграции
+	// одновременно применяет только один из них, остальные ждут.
+	lockQuery   = `SELECT pg_advisory_lock(hashtext('schema_migrations'))`
+	unlockQuery = `SELECT pg_advisory_unlock(hashtext('schema_migrations'))`
+
+	tableExistsQuery = `SELECT to_regclass('schema_migrations') IS NOT NULL`
+	appliedQuery     = `SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`
+	insertQuery      = `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`
+	deleteQuery      = `DELETE FROM schema_migrations WHERE version = $1`
+)
+
+// unlockTimeout - сколько ждать снятия блокировки, если контекст запуска уже отменен
+const unlockTimeout = 5 * time.Second
+
+// Migrator применяет миграции и хранит примененные версии в таблице schema_migrations.
+// Каждая миграция выполняется в своей транзакции вместе с записью в schema_migrations.
+type Migrator struct {
+	db         *pgxpool.Pool
+	migrations []Migration
+}
+
+// AppliedMigration - запись из schema_migrations
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// MigrationStatus - состояние версии для migrate status
+// Changed - файл примененной миграции изменился, Missing - версия применена, но файла миграции нет.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	HasDown   bool
+	Changed   bool
+	Missing   bool
+}
+
+// querier - общее у пула и соединения, чтобы читать schema_migrations без блокировки
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func New(db *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Migrations возвращает все известные миграции по возрастанию версии
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up применяет все непримененные миграции по возрастанию версии и возвращает их количество
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	log := logger.FromContext(ctx).Named("migrator")
+
+	count := 0
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.pendingUp(applied) {
+			log.Info("Applying migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+
+			err := inTx(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.UpSQL); err != nil {
+					return err
+				}
+
+				_, err := tx.Exec(ctx, insertQuery, migration.Version, migration.Name, migration.Checksum)
+
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			count++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	log.Info("Migrations applied", zap.Int("count", count))
+
+	return count, nil
+}
+
+// Down откатывает steps последних примененных миграций и возвращает их количество.
+// Если хотя бы у одной из них нет файла отката, ничего не откатывается.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, ErrInvalidSteps
+	}
+
+	log := logger.FromContext(ctx).Named("migrator")
+
+	count := 0
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		toRevert, err := m.pendingDown(applied, steps)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range toRevert {
+			log.Info("Reverting migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+
+			err := inTx(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.DownSQL); err != nil {
+					return err
+				}
+
+				_, err := tx.Exec(ctx, deleteQuery, migration.Version)
+
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			count++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	log.Info("Migrations reverted", zap.Int("count", count))
+
+	return count, nil
+}
+
+// PlanUp возвращает миграции, которые применит Up, не изменяя БД (dry-run)
+func (m *Migrator) PlanUp(ctx context.Context) ([]Migration, error) {
+	applied, err := m.verifiedApplied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.pendingUp(applied), nil
+}
+
+// PlanDown возвращает миграции в порядке отката, которые откатит Down, не изменяя БД (dry-run)
+func (m *Migrator) PlanDown(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, ErrInvalidSteps
+	}
+
+	applied, err := m.verifiedApplied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.pendingDown(applied, steps)
+}
+
+// Status возвращает состояние всех известных и примененных версий по возрастанию версии.
+// В отличие от Up и Down, измененные и неизвестные версии не считаются ошибкой, а отмечаются в статусе.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := queryApplied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]AppliedMigration, len(applied))
+	for _, record := range applied {
+		byVersion[record.Version] = record
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	known := make(map[int64]bool, len(m.migrations))
+
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, HasDown: migration.HasDown()}
+
+		if record, ok := byVersion[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.AppliedAt
+			status.Changed = record.Checksum != migration.Checksum
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for _, record := range applied {
+		if known[record.Version] {
+			continue
+		}
+
+		statuses = append(statuses, MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: &record.AppliedAt,
+			Missing:   true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// pendingUp возвращает непримененные миграции по возрастанию версии
+func (m *Migrator) pendingUp(applied map[int64]AppliedMigration) []Migration {
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending
+}
+
+// pendingDown возвращает steps последних примененных миграций по убыванию версии.
+// Если хотя бы у одной из них нет файла отката, возвращает ErrNoDownMigration.
+func (m *Migrator) pendingDown(applied map[int64]AppliedMigration, steps int) ([]Migration, error) {
+	var pending []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(pending) < steps; i-- {
+		if _, ok := applied[m.migrations[i].Version]; ok {
+			pending = append(pending, m.migrations[i])
+		}
+	}
+
+	for _, migration := range pending {
+		if !migration.HasDown() {
+			return nil, fmt.Errorf("%w: %d_%s", ErrNoDownMigration, migration.Version, migration.Name)
+		}
+	}
+
+	return pending, nil
+}
+
+// withLock выполняет fn на одном соединении под advisory lock и создает schema_migrations, если ее нет
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) (err error) {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, lockQuery); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+		defer cancel()
+
+		if _, unlockErr := conn.Exec(unlockCtx, unlockQuery); unlockErr != nil {
+			// Блокировка живет, пока живет сессия, поэтому соединение нельзя возвращать в пул
+			_ = conn.Conn().Close(unlockCtx)
+
+			if err == nil {
+				err = fmt.Errorf("failed to release migration lock: %w", unlockErr)
+			}
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, createTableQuery); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// verifiedApplied возвращает примененные миграции и проверяет, что все они известны и не изменились
+func (m *Migrator) verifiedApplied(ctx context.Context, q querier) (map[int64]AppliedMigration, error) {
+	applied, err := queryApplied(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	result := make(map[int64]AppliedMigration, len(applied))
+	for _, record := range applied {
+		migration, ok := known[record.Version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d_%s", ErrUnknownVersion, record.Version, record.Name)
+		}
+
+		if migration.Checksum != record.Checksum {
+			return nil, fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, record.Version, record.Name)
+		}
+
+		result[record.Version] = record
+	}
+
+	return result, nil
+}
+
+// queryApplied читает schema_migrations, если таблицы еще нет - примененных миграций нет
+func queryApplied(ctx context.Context, q querier) ([]AppliedMigration, error) {
+	var exists bool
+	if err := q.QueryRow(ctx, tableExistsQuery).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check schema_migrations table: %w", err)
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	rows, err := q.Query(ctx, appliedQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var applied []AppliedMigration
+	for rows.Next() {
+		var record AppliedMigration
+		if err := rows.Scan(&record.Version, &record.Name, &record.Checksum, &record.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+
+		applied = append(applied, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+func inTx(ctx context.Context, conn *pgxpool.Conn, fn func(tx pgx.Tx) error) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}